@@ -108,7 +108,7 @@ func (i *AdminHandler) NewPaymentMethod(c *gin.Context) {
 		return
 	}
 
-	err := i.usecase.NewPaymentMethod(method.PaymentMethod)
+	err := i.usecase.NewPaymentMethod(method.PaymentMethod, method.Kind)
 	if err != nil {
 		errorRes := response.ClientResponse(http.StatusBadRequest, "Could not add the payment method", nil, err.Error())
 		c.JSON(http.StatusBadRequest, errorRes)
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	interfaces "github.com/ahdaan98/pkg/usecase/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+	"github.com/ahdaan98/pkg/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type BulkOrderHandler struct {
+	bulkOrderUseCase interfaces.BulkOrderUseCase
+}
+
+func NewBulkOrderHandler(useCase interfaces.BulkOrderUseCase) *BulkOrderHandler {
+	return &BulkOrderHandler{
+		bulkOrderUseCase: useCase,
+	}
+}
+
+func (b *BulkOrderHandler) StartBulkJob(c *gin.Context) {
+	var request models.BulkOrderRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "fields provided are in wrong format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	adminId, _ := c.Get("id")
+	AdminID, _ := adminId.(int)
+
+	job, err := b.bulkOrderUseCase.StartBulkJob(AdminID, request)
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not start the bulk action", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusAccepted, "Successfully queued the bulk action", job, nil)
+	c.JSON(http.StatusAccepted, successRes)
+}
+
+func (b *BulkOrderHandler) GetBulkJob(c *gin.Context) {
+	jobID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid job ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	job, err := b.bulkOrderUseCase.GetBulkJob(jobID)
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not retrieve the bulk job", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved the bulk job", job, nil)
+	c.JSON(http.StatusOK, successRes)
+}
@@ -69,6 +69,32 @@ func (ca *CategoryHandler) EditCategory(c *gin.Context) {
 	c.JSON(http.StatusOK, successRes)
 }
 
+func (ca *CategoryHandler) SetCategoryTax(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errRes := response.ClientResponse(http.StatusBadRequest, "error in id", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errRes)
+		return
+	}
+
+	var tax models.CategoryTax
+	if err := c.ShouldBindJSON(&tax); err != nil {
+		errRes := response.ClientResponse(http.StatusBadRequest, "error binding json format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errRes)
+		return
+	}
+
+	Category, err := ca.usecase.SetCategoryTax(tax, id)
+	if err != nil {
+		errRes := response.ClientResponse(http.StatusBadRequest, "failed to set category tax", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "successfully set category tax...", Category, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
 func (ca *CategoryHandler) DeleteCategory(c *gin.Context) {
 	idstr := c.Query("id")
 	id, err := strconv.Atoi(idstr)
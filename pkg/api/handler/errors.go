@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ahdaan98/pkg/domain"
+)
+
+// errorStatus answers 403 when a user reaches for another user's order,
+// address, payment or document, and 400 for any other failure.
+func errorStatus(err error) int {
+	if errors.Is(err, domain.ErrForbidden) {
+		return http.StatusForbidden
+	}
+	return http.StatusBadRequest
+}
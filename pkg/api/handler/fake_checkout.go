@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/ahdaan98/pkg/gateway"
+	"github.com/ahdaan98/pkg/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+// FakeCheckoutHandler stands in for the razorpay checkout when the fake
+// gateway is selected, so a payment can be completed without going online.
+type FakeCheckoutHandler struct {
+	fake *gateway.Fake
+}
+
+func NewFakeCheckoutHandler(paymentGateway gateway.PaymentGateway) *FakeCheckoutHandler {
+	fake, _ := paymentGateway.(*gateway.Fake)
+	return &FakeCheckoutHandler{
+		fake: fake,
+	}
+}
+
+// Enabled reports whether the fake gateway is the one in use.
+func (handler *FakeCheckoutHandler) Enabled() bool {
+	return handler.fake != nil
+}
+
+// Pay pays a fake gateway order the way FAKE_GATEWAY_MODE says and sends the
+// browser on to /user/verifypayment with what the checkout would hand back.
+func (handler *FakeCheckoutHandler) Pay(c *gin.Context) {
+	razorId := c.PostForm("razor_id")
+	orderId := c.PostForm("order_id")
+
+	payment, signature, err := handler.fake.Pay(razorId)
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not pay the order", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	query := url.Values{
+		"order_id":           {orderId},
+		"payment_id":         {payment.ID},
+		"razor_id":           {razorId},
+		"razorpay_signature": {signature},
+	}
+	c.Redirect(http.StatusSeeOther, "/user/verifypayment?"+query.Encode())
+}
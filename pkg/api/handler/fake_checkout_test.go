@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ahdaan98/pkg/gateway"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert"
+)
+
+func TestFakeCheckoutPay(t *testing.T) {
+	fake := gateway.NewFake(gateway.FakeSuccess, 0)
+	order, _ := fake.CreateOrder(49950, "order_5")
+
+	handler := NewFakeCheckoutHandler(fake)
+	assert.Equal(t, true, handler.Enabled())
+
+	form := url.Values{"razor_id": {order.ID}, "order_id": {"5"}}
+	req := httptest.NewRequest(http.MethodPost, "/fake-checkout/pay", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.POST("/fake-checkout/pay", handler.Pay)
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusSeeOther, w.Code)
+	location, _ := url.Parse(w.Header().Get("Location"))
+	assert.Equal(t, "/user/verifypayment", location.Path)
+
+	query := location.Query()
+	assert.Equal(t, "5", query.Get("order_id"))
+	assert.Equal(t, order.ID, query.Get("razor_id"))
+	assert.Equal(t, true, fake.VerifySignature(order.ID, query.Get("payment_id"), query.Get("razorpay_signature")))
+}
+
+func TestFakeCheckoutDisabledForRazorpay(t *testing.T) {
+	handler := NewFakeCheckoutHandler(gateway.NewRazorpay("key", "secret"))
+	assert.Equal(t, false, handler.Enabled())
+}
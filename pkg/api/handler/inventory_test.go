@@ -199,6 +199,8 @@ func TestAddCategory(t *testing.T) {
             expectedData: map[string]interface{}{
                 "id":           float64(1),
                 "category_name": "New Category",
+                "hsn_code":      "",
+                "gst_rate":      float64(0),
             },
         },
         {
@@ -295,6 +297,8 @@ func TestEditCategory(t *testing.T) {
             expectedData: map[string]interface{}{
                 "id":             float64(123),
                 "category_name":  "Edited Category",
+                "hsn_code":       "",
+                "gst_rate":       float64(0),
             },
             expectedErrorMsg: "",
         },
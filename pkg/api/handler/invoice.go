@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	interfaces "github.com/ahdaan98/pkg/usecase/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+	"github.com/ahdaan98/pkg/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+// InvoiceDownloadPath is where signed invoice links are served.
+const InvoiceDownloadPath = "/user/invoices/download"
+
+type InvoiceHandler struct {
+	invoiceUseCase interfaces.InvoiceUseCase
+}
+
+func NewInvoiceHandler(useCase interfaces.InvoiceUseCase) *InvoiceHandler {
+	return &InvoiceHandler{
+		invoiceUseCase: useCase,
+	}
+}
+
+// PrintInvoice sends the invoice of one of the user's orders.
+func (i *InvoiceHandler) PrintInvoice(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Query("order_id"))
+	if err != nil {
+		err = errors.New("error in converting order id: " + err.Error())
+		errRes := response.ClientResponse(http.StatusBadRequest, "error in reading the order id", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errRes)
+		return
+	}
+
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
+
+	file, err := i.invoiceUseCase.GetUserInvoice(UserID, orderID)
+	if err != nil {
+		errRes := response.ClientResponse(errorStatus(err), "error in printing the invoice", nil, err.Error())
+		c.JSON(errorStatus(err), errRes)
+		return
+	}
+
+	sendDocument(c, file)
+}
+
+// GetOrderInvoice sends the invoice of any order to an admin.
+func (i *InvoiceHandler) GetOrderInvoice(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errRes)
+		return
+	}
+
+	file, err := i.invoiceUseCase.GetOrderInvoice(orderID)
+	if err != nil {
+		errRes := response.ClientResponse(http.StatusBadRequest, "error in printing the invoice", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errRes)
+		return
+	}
+
+	sendDocument(c, file)
+}
+
+func (i *InvoiceHandler) GetInvoice(c *gin.Context) {
+	invoiceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errRes := response.ClientResponse(http.StatusBadRequest, "Invalid invoice ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errRes)
+		return
+	}
+
+	file, err := i.invoiceUseCase.GetInvoice(invoiceID)
+	if err != nil {
+		errRes := response.ClientResponse(http.StatusBadRequest, "could not retrieve the invoice", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errRes)
+		return
+	}
+
+	sendDocument(c, file)
+}
+
+func (i *InvoiceHandler) GetCreditNote(c *gin.Context) {
+	creditNoteID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errRes := response.ClientResponse(http.StatusBadRequest, "Invalid credit note ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errRes)
+		return
+	}
+
+	file, err := i.invoiceUseCase.GetCreditNote(creditNoteID)
+	if err != nil {
+		errRes := response.ClientResponse(http.StatusBadRequest, "could not retrieve the credit note", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errRes)
+		return
+	}
+
+	sendDocument(c, file)
+}
+
+func (i *InvoiceHandler) GetUserCreditNote(c *gin.Context) {
+	creditNoteID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errRes := response.ClientResponse(http.StatusBadRequest, "Invalid credit note ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errRes)
+		return
+	}
+
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
+
+	file, err := i.invoiceUseCase.GetUserCreditNote(UserID, creditNoteID)
+	if err != nil {
+		errRes := response.ClientResponse(errorStatus(err), "could not retrieve the credit note", nil, err.Error())
+		c.JSON(errorStatus(err), errRes)
+		return
+	}
+
+	sendDocument(c, file)
+}
+
+// GetInvoiceLink signs a download link to the invoice of one of the user's
+// orders.
+func (i *InvoiceHandler) GetInvoiceLink(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errRes)
+		return
+	}
+
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
+
+	link, err := i.invoiceUseCase.GetUserInvoiceLink(UserID, orderID)
+	if err != nil {
+		errRes := response.ClientResponse(errorStatus(err), "could not create the invoice link", nil, err.Error())
+		c.JSON(errorStatus(err), errRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully created the invoice link", invoiceLinkURL(c, link), nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+// GetAdminInvoiceLink signs a download link to the invoice of any order.
+func (i *InvoiceHandler) GetAdminInvoiceLink(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errRes)
+		return
+	}
+
+	link, err := i.invoiceUseCase.GetInvoiceLink(orderID)
+	if err != nil {
+		errRes := response.ClientResponse(http.StatusBadRequest, "could not create the invoice link", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully created the invoice link", invoiceLinkURL(c, link), nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+// DownloadInvoice sends the invoice a signed link points to. It needs no
+// login; the signature is checked instead.
+func (i *InvoiceHandler) DownloadInvoice(c *gin.Context) {
+	invoiceID, err := strconv.Atoi(c.Query("invoice_id"))
+	if err != nil {
+		errRes := response.ClientResponse(http.StatusBadRequest, "Invalid invoice ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errRes)
+		return
+	}
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		errRes := response.ClientResponse(http.StatusBadRequest, "Invalid link expiry format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errRes)
+		return
+	}
+
+	file, err := i.invoiceUseCase.GetSignedInvoice(invoiceID, expires, c.Query("signature"))
+	if err != nil {
+		errRes := response.ClientResponse(http.StatusForbidden, "could not download the invoice", nil, err.Error())
+		c.JSON(http.StatusForbidden, errRes)
+		return
+	}
+
+	sendDocument(c, file)
+}
+
+// GetOrderDocuments lists the invoice and credit notes of an order.
+func (i *InvoiceHandler) GetOrderDocuments(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errRes)
+		return
+	}
+
+	documents, err := i.invoiceUseCase.GetOrderDocuments(orderID)
+	if err != nil {
+		errRes := response.ClientResponse(http.StatusBadRequest, "could not retrieve the order's invoices", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved the order's invoices", documents, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+// invoiceLinkURL points a signed link at the public download route on the
+// host the request came in on.
+func invoiceLinkURL(c *gin.Context, link models.InvoiceLink) models.InvoiceLink {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+
+	query := url.Values{}
+	query.Set("invoice_id", strconv.Itoa(link.InvoiceID))
+	query.Set("expires", strconv.FormatInt(link.Expires, 10))
+	query.Set("signature", link.Signature)
+	link.URL = scheme + "://" + c.Request.Host + InvoiceDownloadPath + "?" + query.Encode()
+
+	return link
+}
+
+func sendDocument(c *gin.Context, file models.DocumentFile) {
+	c.Header("Content-Disposition", "attachment;filename="+file.FileName)
+	c.File(file.Path)
+}
@@ -1,11 +1,11 @@
 package handler
 
 import (
+	"github.com/ahdaan98/pkg/domain"
 	interfaces "github.com/ahdaan98/pkg/usecase/interface"
 	models "github.com/ahdaan98/pkg/utils/models"
 	response "github.com/ahdaan98/pkg/utils/response"
 	"errors"
-	"fmt"
 	"net/http"
 	"strconv"
 
@@ -33,15 +33,43 @@ func (i *OrderHandler) OrderItemsFromCart(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, errorRes)
 		return
 	}
-	if err := i.orderUseCase.OrderItemsFromCart(UserID, order.AddressID, order.PaymentMethodID, order.CouponID); err != nil {
-		errorRes := response.ClientResponse(http.StatusBadRequest, "could not make the order", nil, err.Error())
-		c.JSON(http.StatusBadRequest, errorRes)
+	if err := i.orderUseCase.OrderItemsFromCart(UserID, order.AddressID, order.PaymentMethodID, order.CouponID, order.ShippingMethod); err != nil {
+		var stockErr *domain.InsufficientStockError
+		if errors.As(err, &stockErr) {
+			errorRes := response.ClientResponse(http.StatusConflict, "could not make the order", stockErr, err.Error())
+			c.JSON(http.StatusConflict, errorRes)
+			return
+		}
+		errorRes := response.ClientResponse(errorStatus(err), "could not make the order", nil, err.Error())
+		c.JSON(errorStatus(err), errorRes)
 		return
 	}
 	successRes := response.ClientResponse(http.StatusOK, "Successfully made the order", nil, nil)
 	c.JSON(http.StatusOK, successRes)
 }
 
+func (i *OrderHandler) GetCheckoutQuote(c *gin.Context) {
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
+
+	var query models.CheckoutQuoteQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "fields provided are in wrong format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	quote, err := i.orderUseCase.GetCheckoutQuote(UserID, query.AddressID, query.CouponID, query.ShippingMethod)
+	if err != nil {
+		errorRes := response.ClientResponse(errorStatus(err), "could not price the cart", nil, err.Error())
+		c.JSON(errorStatus(err), errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully priced the cart", quote, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
 func (i *OrderHandler) GetOrders(c *gin.Context) {
 
 	idString := c.Query("order_id")
@@ -53,10 +81,13 @@ func (i *OrderHandler) GetOrders(c *gin.Context) {
 		return
 	}
 
-	orders, err := i.orderUseCase.GetOrders(order_id)
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
+
+	orders, err := i.orderUseCase.GetOrders(UserID, order_id)
 	if err != nil {
-		errorRes := response.ClientResponse(http.StatusBadRequest, "could not retrieve orders", nil, err.Error())
-		c.JSON(http.StatusBadRequest, errorRes)
+		errorRes := response.ClientResponse(errorStatus(err), "could not retrieve orders", nil, err.Error())
+		c.JSON(errorStatus(err), errorRes)
 		return
 	}
 	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved all orders", orders, nil)
@@ -74,10 +105,13 @@ func (i OrderHandler) CancelOrder(c *gin.Context) {
 		return
 	}
 
-	err = i.orderUseCase.CancelOrder(orderID)
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
+
+	err = i.orderUseCase.CancelOrder(UserID, orderID, c.Query("refund_to"))
 	if err != nil {
-		errorRes := response.ClientResponse(http.StatusBadRequest, "could not cancel the order", nil, err.Error())
-		c.JSON(http.StatusBadRequest, errorRes)
+		errorRes := response.ClientResponse(errorStatus(err), "could not cancel the order", nil, err.Error())
+		c.JSON(errorStatus(err), errorRes)
 		return
 	}
 
@@ -118,26 +152,22 @@ func (i *OrderHandler) GetAllOrders(c *gin.Context) {
 }
 
 func (i *OrderHandler) GetAdminOrders(c *gin.Context) {
-
-	pageStr := c.Query("page")
-	page, err := strconv.Atoi(pageStr)
-
-	if err != nil {
-		errorRes := response.ClientResponse(http.StatusBadRequest, "page number not in right format", nil, err.Error())
+	var filter models.AdminOrderFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "filters provided are in wrong format", nil, err.Error())
 		c.JSON(http.StatusBadRequest, errorRes)
 		return
 	}
 
-	orders, err := i.orderUseCase.GetAdminOrders(page)
-
+	orders, err := i.orderUseCase.GetAdminOrders(filter)
 	if err != nil {
 		errorRes := response.ClientResponse(http.StatusBadRequest, "could not retrieve orders", nil, err.Error())
 		c.JSON(http.StatusBadRequest, errorRes)
 		return
 	}
+
 	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved all orders", orders, nil)
 	c.JSON(http.StatusOK, successRes)
-
 }
 
 func (i *OrderHandler) ApproveOrder(c *gin.Context) {
@@ -149,7 +179,10 @@ func (i *OrderHandler) ApproveOrder(c *gin.Context) {
 		return
 	}
 
-	err = i.orderUseCase.OrdersStatus(orderID)
+	adminId, _ := c.Get("id")
+	AdminID, _ := adminId.(int)
+
+	err = i.orderUseCase.OrdersStatus(AdminID, orderID)
 	if err != nil {
 		errorRes := response.ClientResponse(http.StatusBadRequest, "Could not approve order", nil, err.Error())
 		c.JSON(http.StatusBadRequest, errorRes)
@@ -160,53 +193,115 @@ func (i *OrderHandler) ApproveOrder(c *gin.Context) {
 	c.JSON(http.StatusOK, successRes)
 }
 
-func (o *OrderHandler) ReturnOrder(c *gin.Context) {
-	id := c.Query("order_id")
-	orderID, err := strconv.Atoi(id)
+func (i *OrderHandler) GetUserOrderItems(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
+
+	items, err := i.orderUseCase.GetUserOrderItems(UserID, orderID)
+	if err != nil {
+		errorRes := response.ClientResponse(errorStatus(err), "could not retrieve order items", nil, err.Error())
+		c.JSON(errorStatus(err), errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved order items", items, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (i *OrderHandler) Reorder(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
+
+	result, err := i.orderUseCase.Reorder(UserID, orderID)
+	if err != nil {
+		errorRes := response.ClientResponse(errorStatus(err), "could not reorder", nil, err.Error())
+		c.JSON(errorStatus(err), errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully added the order to the cart", result, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (i *OrderHandler) CancelOrderItems(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	var body models.CancelOrderItems
+	if err := c.ShouldBindJSON(&body); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "fields provided are in wrong format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
+
+	if err := i.orderUseCase.CancelOrderItems(UserID, orderID, body.Items, body.RefundTo); err != nil {
+		errorRes := response.ClientResponse(errorStatus(err), "could not cancel the items", nil, err.Error())
+		c.JSON(errorStatus(err), errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully canceled the items", nil, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (i *OrderHandler) GetUserOrderTimeline(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		errRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err)
-		c.JSON(http.StatusBadRequest, errRes)
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
 		return
 	}
 
-	fmt.Println("order id at handler", orderID)
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
 
-	err = o.orderUseCase.ReturnOrder(orderID)
+	timeline, err := i.orderUseCase.GetUserOrderTimeline(UserID, orderID)
 	if err != nil {
-		errRes := response.ClientResponse(http.StatusInternalServerError, "Failed to process order return", nil, err)
-		c.JSON(http.StatusInternalServerError, errRes)
+		errorRes := response.ClientResponse(errorStatus(err), "could not retrieve order timeline", nil, err.Error())
+		c.JSON(errorStatus(err), errorRes)
 		return
 	}
 
-	successRes := response.ClientResponse(http.StatusOK, "Order successfully returned", nil, nil)
+	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved order timeline", timeline, nil)
 	c.JSON(http.StatusOK, successRes)
 }
 
-func (O *OrderHandler) PrintInvoice(c *gin.Context) {
-    orderId := c.Query("order_id")
-    orderIdInt, err := strconv.Atoi(orderId)
-    if err != nil {
-        err = errors.New("error in converting order id: " + err.Error())
-        errRes := response.ClientResponse(http.StatusBadRequest, "error in reading the order id", nil, err)
-        c.JSON(http.StatusBadRequest, errRes)
-        return
-    }
-
-    pdf, err := O.orderUseCase.PrintInvoice(orderIdInt)
-    if err != nil {
-        errRes := response.ClientResponse(http.StatusBadRequest, "error in printing the invoice", nil, err.Error())
-        c.JSON(http.StatusBadRequest, errRes)
-        return
-    }
-
-    c.Header("Content-Disposition", "attachment;filename=invoice.pdf")
-    pdfFilePath := "pkg/salesReport/invoice.pdf"
-    err = pdf.OutputFileAndClose(pdfFilePath)
-    if err != nil {
-        errRes := response.ClientResponse(http.StatusBadRequest, "error in printing invoice", nil, err)
-        c.JSON(http.StatusBadRequest, errRes)
-        return
-    }
-
-    c.File(pdfFilePath)
-}
\ No newline at end of file
+func (i *OrderHandler) GetOrderTimeline(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	timeline, err := i.orderUseCase.GetOrderTimeline(orderID)
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not retrieve order timeline", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved order timeline", timeline, nil)
+	c.JSON(http.StatusOK, successRes)
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ahdaan98/pkg/domain"
+	usecase_mocks "github.com/ahdaan98/pkg/usecase/mocks"
+	"github.com/ahdaan98/pkg/utils/models"
+	"github.com/ahdaan98/pkg/utils/response"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert"
+	"github.com/golang/mock/gomock"
+)
+
+// TestForbiddenForOtherUsers checks that user endpoints act as the user from
+// the login token and answer 403 when the resource belongs to someone else.
+func TestForbiddenForOtherUsers(t *testing.T) {
+	const userID = 7
+
+	type mocks struct {
+		order   *usecase_mocks.MockOrderUseCase
+		payment *usecase_mocks.MockPaymentUseCase
+		invoice *usecase_mocks.MockInvoiceUseCase
+		returns *usecase_mocks.MockReturnUseCase
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		params gin.Params
+		body   string
+		stub   func(m mocks)
+		handle func(m mocks) gin.HandlerFunc
+	}{
+		{
+			name:   "order details",
+			method: http.MethodGet,
+			target: "/profile/orders?order_id=5",
+			stub: func(m mocks) {
+				m.order.EXPECT().GetOrders(userID, 5).Return(domain.OrderResponse{}, domain.ErrForbidden)
+			},
+			handle: func(m mocks) gin.HandlerFunc { return NewOrderHandler(m.order).GetOrders },
+		},
+		{
+			name:   "cancel order",
+			method: http.MethodDelete,
+			target: "/profile/orders?order_id=5",
+			stub: func(m mocks) {
+				m.order.EXPECT().CancelOrder(userID, 5, "").Return(domain.ErrForbidden)
+			},
+			handle: func(m mocks) gin.HandlerFunc { return NewOrderHandler(m.order).CancelOrder },
+		},
+		{
+			name:   "checkout to another user's address",
+			method: http.MethodPost,
+			target: "/check-out",
+			body:   `{"address_id": 9, "payment_id": 1}`,
+			stub: func(m mocks) {
+				m.order.EXPECT().OrderItemsFromCart(userID, 9, 1, 0, "").Return(domain.ErrForbidden)
+			},
+			handle: func(m mocks) gin.HandlerFunc { return NewOrderHandler(m.order).OrderItemsFromCart },
+		},
+		{
+			name:   "pay for another user's order",
+			method: http.MethodGet,
+			target: "/payment?order_id=5&user_id=1",
+			stub: func(m mocks) {
+				m.payment.EXPECT().MakePaymentRazorpay(5, userID).Return(models.CombinedOrderDetails{}, "", domain.ErrForbidden)
+			},
+			handle: func(m mocks) gin.HandlerFunc { return NewPaymentHandler(m.payment).MakePaymentRazorpay },
+		},
+		{
+			name:   "return another user's order",
+			method: http.MethodPost,
+			target: "/profile/returns",
+			body:   `{"order_id": 5, "reason": "damaged", "items": [{"order_item_id": 20, "quantity": 1}]}`,
+			stub: func(m mocks) {
+				m.returns.EXPECT().RequestReturn(userID, gomock.Any()).Return(models.ReturnRequestDetails{}, domain.ErrForbidden)
+			},
+			handle: func(m mocks) gin.HandlerFunc { return NewReturnHandler(m.returns).RequestReturn },
+		},
+		{
+			name:   "return items of another user's order",
+			method: http.MethodPut,
+			target: "/profile/orders/5/items/return",
+			params: gin.Params{{Key: "id", Value: "5"}},
+			body:   `{"items": [{"order_item_id": 20, "quantity": 1, "damaged_quantity": 1}]}`,
+			stub: func(m mocks) {
+				request := models.ReturnRequest{OrderID: 5, Reason: "items returned by customer", Items: []models.ReturnRequestItem{{OrderItemID: 20, Quantity: 1, DamagedQuantity: 1}}}
+				m.returns.EXPECT().RequestReturn(userID, request).Return(models.ReturnRequestDetails{}, domain.ErrForbidden)
+			},
+			handle: func(m mocks) gin.HandlerFunc { return NewReturnHandler(m.returns).ReturnOrderItems },
+		},
+		{
+			name:   "return another user's whole order",
+			method: http.MethodPut,
+			target: "/profile/orders/return?order_id=5",
+			body:   `{"damaged_items": [{"order_item_id": 20, "quantity": 1}]}`,
+			stub: func(m mocks) {
+				m.returns.EXPECT().ReturnOrder(userID, 5, []models.DamagedItem{{OrderItemID: 20, Quantity: 1}}).Return(models.ReturnRequestDetails{}, domain.ErrForbidden)
+			},
+			handle: func(m mocks) gin.HandlerFunc { return NewReturnHandler(m.returns).ReturnOrder },
+		},
+		{
+			name:   "return another user's whole order without a body",
+			method: http.MethodPut,
+			target: "/profile/orders/return?order_id=5",
+			stub: func(m mocks) {
+				m.returns.EXPECT().ReturnOrder(userID, 5, nil).Return(models.ReturnRequestDetails{}, domain.ErrForbidden)
+			},
+			handle: func(m mocks) gin.HandlerFunc { return NewReturnHandler(m.returns).ReturnOrder },
+		},
+		{
+			name:   "invoice of another user's order",
+			method: http.MethodGet,
+			target: "/invoice/print?order_id=5",
+			stub: func(m mocks) {
+				m.invoice.EXPECT().GetUserInvoice(userID, 5).Return(models.DocumentFile{}, domain.ErrForbidden)
+			},
+			handle: func(m mocks) gin.HandlerFunc { return NewInvoiceHandler(m.invoice).PrintInvoice },
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := mocks{
+				order:   usecase_mocks.NewMockOrderUseCase(ctrl),
+				payment: usecase_mocks.NewMockPaymentUseCase(ctrl),
+				invoice: usecase_mocks.NewMockInvoiceUseCase(ctrl),
+				returns: usecase_mocks.NewMockReturnUseCase(ctrl),
+			}
+			tc.stub(m)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = tc.params
+			// as set by UserAuthMiddleware
+			c.Set("id", userID)
+
+			tc.handle(m)(c)
+
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			var res response.Response
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+			assert.Equal(t, domain.ErrForbidden.Error(), res.Error)
+		})
+	}
+}
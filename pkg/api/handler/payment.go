@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"github.com/ahdaan98/pkg/config"
+	"github.com/ahdaan98/pkg/domain"
+	"github.com/ahdaan98/pkg/gateway"
 	interfaces "github.com/ahdaan98/pkg/usecase/interface"
 	"github.com/ahdaan98/pkg/utils/response"
 	"errors"
-	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -21,15 +24,13 @@ func NewPaymentHandler(useCase interfaces.PaymentUseCase) *PaymentHandler {
 	}
 }
 
+// MakePaymentRazorpay opens the razorpay checkout for one of the user's
+// orders. The user is the one logged in, never one named in the request.
 func (handler *PaymentHandler) MakePaymentRazorpay(c *gin.Context) {
-	userId := c.Query("user_id")
-	userIdInt, err := strconv.Atoi(userId)
-	if err != nil {
+	id, _ := c.Get("id")
+	userIdInt, _ := id.(int)
+	userId := strconv.Itoa(userIdInt)
 
-		errRes := response.ClientResponse(http.StatusBadRequest, "error", nil, errors.New("error in converting string to int userid"+err.Error()))
-		c.JSON(http.StatusBadRequest, errRes)
-		return
-	}
 	orderId := c.Query("order_id")
 	orderIdInt, err := strconv.Atoi(orderId)
 	if err != nil {
@@ -38,43 +39,163 @@ func (handler *PaymentHandler) MakePaymentRazorpay(c *gin.Context) {
 		return
 	}
 
-	fmt.Println("Order iddddddd", orderIdInt)
 	body, razorId, err := handler.payment.MakePaymentRazorpay(orderIdInt, userIdInt)
-	fmt.Println(" inside Order iddddddd", body.OrderId)
-
-	fmt.Println("body in handler", body)
 	if err != nil {
-		errRes := response.ClientResponse(http.StatusBadRequest, "error", nil, err.Error())
-		c.JSON(http.StatusBadRequest, errRes)
+		errRes := response.ClientResponse(errorStatus(err), "error", nil, err.Error())
+		c.JSON(errorStatus(err), errRes)
 		return
 	}
-	fmt.Println("body next", body.FinalPrice, razorId, userId, body.OrderId, body.Name, body.FinalPrice)
 
-	c.HTML(http.StatusOK, "index.html", gin.H{
+	cfg, _ := config.LoadEnvVariables()
+	page := "index.html"
+	if gateway.UsesFake(cfg) {
+		page = "fake_checkout.html"
+	}
+	c.HTML(http.StatusOK, page, gin.H{
 		"final_price": body.FinalPrice * 100,
 		"razor_id":    razorId,
 		"user_id":     userId,
 		"order_id":    body.OrderId,
 		"user_name":   body.Name,
 		"total":       int(body.FinalPrice),
+		"key_id":      cfg.KEY_ID_FOR_PAY,
 	})
 }
 
+// VerifyPayment confirms a payment from the razorpay checkout callback, which
+// carries razorpay's signature of the order and payment ids.
 func (handler *PaymentHandler) VerifyPayment(c *gin.Context) {
 	orderId := c.Query("order_id")
 	paymentId := c.Query("payment_id")
 	razorId := c.Query("razor_id")
+	signature := c.Query("razorpay_signature")
 
-	fmt.Println("details order, payment, razor", orderId)
-	fmt.Println("details order, payment, razor", paymentId)
-	fmt.Println("details order, payment, razor", razorId)
+	if paymentId == "" || razorId == "" || signature == "" {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not update payment details", nil, "payment_id, razor_id and razorpay_signature are required")
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
 
-	if err := handler.payment.SavePaymentDetails(paymentId, razorId, orderId); err != nil {
-		errorRes := response.ClientResponse(http.StatusInternalServerError, "could not update payment details", nil, err.Error())
-		c.JSON(http.StatusOK, errorRes)
+	if err := handler.payment.SavePaymentDetails(paymentId, razorId, orderId, signature); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not update payment details", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
 		return
 	}
 
 	successRes := response.ClientResponse(http.StatusOK, "Successfully updated payment details", nil, nil)
 	c.JSON(http.StatusOK, successRes)
-}
\ No newline at end of file
+}
+
+const RazorpaySignatureHeader = "X-Razorpay-Signature"
+
+// RazorpayWebhook receives payment and refund events from razorpay. The
+// signature covers the raw body, so the body is passed on unparsed.
+func (handler *PaymentHandler) RazorpayWebhook(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not read the webhook event", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	if err := handler.payment.HandleRazorpayWebhook(body, c.GetHeader(RazorpaySignatureHeader)); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrInvalidSignature) {
+			status = http.StatusUnauthorized
+		}
+		errorRes := response.ClientResponse(status, "could not process the webhook event", nil, err.Error())
+		c.JSON(status, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully processed the webhook event", nil, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (handler *PaymentHandler) GetUserRefunds(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
+
+	refunds, err := handler.payment.GetUserRefunds(UserID, orderID)
+	if err != nil {
+		errorRes := response.ClientResponse(errorStatus(err), "could not retrieve refunds", nil, err.Error())
+		c.JSON(errorStatus(err), errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved refunds", refunds, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (handler *PaymentHandler) GetOrderRefunds(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	refunds, err := handler.payment.GetOrderRefunds(orderID)
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not retrieve refunds", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved refunds", refunds, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+// RetryPayment starts a new payment attempt for an unpaid order and returns
+// what the checkout needs to collect it.
+func (handler *PaymentHandler) RetryPayment(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
+
+	attempt, err := handler.payment.RetryPayment(UserID, orderID)
+	if err != nil {
+		errorRes := response.ClientResponse(errorStatus(err), "could not retry the payment", nil, err.Error())
+		c.JSON(errorStatus(err), errorRes)
+		return
+	}
+
+	cfg, _ := config.LoadEnvVariables()
+	successRes := response.ClientResponse(http.StatusOK, "Successfully started a new payment attempt", gin.H{
+		"attempt": attempt,
+		"key_id":  cfg.KEY_ID_FOR_PAY,
+	}, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (handler *PaymentHandler) GetPaymentAttempts(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	attempts, err := handler.payment.GetPaymentAttempts(orderID)
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not retrieve payment attempts", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved payment attempts", attempts, nil)
+	c.JSON(http.StatusOK, successRes)
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	interfaces "github.com/ahdaan98/pkg/usecase/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+	"github.com/ahdaan98/pkg/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type PinCodeHandler struct {
+	pinCodeUseCase interfaces.PinCodeUseCase
+}
+
+func NewPinCodeHandler(useCase interfaces.PinCodeUseCase) *PinCodeHandler {
+	return &PinCodeHandler{
+		pinCodeUseCase: useCase,
+	}
+}
+
+// ImportPinCodes loads the serviceability table from an uploaded CSV file.
+func (p *PinCodeHandler) ImportPinCodes(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "upload the pin codes as a csv file", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not open the uploaded file", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+	defer file.Close()
+
+	result, err := p.pinCodeUseCase.ImportPinCodes(file)
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not import the pin codes", result, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully imported the pin codes", result, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (p *PinCodeHandler) SetPinCode(c *gin.Context) {
+	var pin models.PinCode
+	if err := c.ShouldBindJSON(&pin); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "fields provided are in wrong format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	saved, err := p.pinCodeUseCase.SetPinCode(pin)
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not save the pin code", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully saved the pin code", saved, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (p *PinCodeHandler) GetPinCodes(c *gin.Context) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	count, _ := strconv.Atoi(c.Query("count"))
+
+	pins, err := p.pinCodeUseCase.GetPinCodes(page, count)
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not retrieve pin codes", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved pin codes", pins, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (p *PinCodeHandler) DeletePinCode(c *gin.Context) {
+	if err := p.pinCodeUseCase.DeletePinCode(c.Param("pin")); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not delete the pin code", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully deleted the pin code", nil, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+// CheckDelivery tells a shopper whether a product can be delivered to a pin
+// code and by when.
+func (p *PinCodeHandler) CheckDelivery(c *gin.Context) {
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid product ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	estimate, err := p.pinCodeUseCase.CheckDelivery(productID, c.Query("pin"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not check delivery", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully checked delivery", estimate, nil)
+	c.JSON(http.StatusOK, successRes)
+}
@@ -0,0 +1,353 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	interfaces "github.com/ahdaan98/pkg/usecase/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+	"github.com/ahdaan98/pkg/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type ReturnHandler struct {
+	returnUseCase interfaces.ReturnUseCase
+}
+
+func NewReturnHandler(useCase interfaces.ReturnUseCase) *ReturnHandler {
+	return &ReturnHandler{
+		returnUseCase: useCase,
+	}
+}
+
+func (r *ReturnHandler) RequestReturn(c *gin.Context) {
+	var request models.ReturnRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "fields provided are in wrong format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
+
+	details, err := r.returnUseCase.RequestReturn(UserID, request)
+	if err != nil {
+		errorRes := response.ClientResponse(errorStatus(err), "could not request the return", nil, err.Error())
+		c.JSON(errorStatus(err), errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully requested the return", details, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+// ReturnOrderItems keeps the order items return endpoint working. The items
+// are no longer refunded at once, a return request is opened for them.
+func (r *ReturnHandler) ReturnOrderItems(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	var body models.ReturnOrderItems
+	if err := c.ShouldBindJSON(&body); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "fields provided are in wrong format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+	if body.Reason == "" {
+		body.Reason = "items returned by customer"
+	}
+
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
+
+	request := models.ReturnRequest{OrderID: orderID, Reason: body.Reason, Items: body.Items, RefundTo: body.RefundTo}
+	details, err := r.returnUseCase.RequestReturn(UserID, request)
+	if err != nil {
+		errorRes := response.ClientResponse(errorStatus(err), "could not return the items", nil, err.Error())
+		c.JSON(errorStatus(err), errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully requested the return", details, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+// ReturnOrder keeps the whole order return endpoint working. A return request
+// is opened for every unit of the order that is not returned yet.
+func (r *ReturnHandler) ReturnOrder(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Query("order_id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	// the body is optional, it only lists items that came back damaged
+	var body models.ReturnOrder
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			errorRes := response.ClientResponse(http.StatusBadRequest, "fields provided are in wrong format", nil, err.Error())
+			c.JSON(http.StatusBadRequest, errorRes)
+			return
+		}
+	}
+
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
+
+	details, err := r.returnUseCase.ReturnOrder(UserID, orderID, body.DamagedItems)
+	if err != nil {
+		errorRes := response.ClientResponse(errorStatus(err), "could not return the order", nil, err.Error())
+		c.JSON(errorStatus(err), errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully requested the return", details, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (r *ReturnHandler) GetUserReturns(c *gin.Context) {
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
+
+	returns, err := r.returnUseCase.GetUserReturns(UserID)
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not retrieve returns", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved returns", returns, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (r *ReturnHandler) GetUserReturn(c *gin.Context) {
+	returnID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid return ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
+
+	details, err := r.returnUseCase.GetUserReturn(UserID, returnID)
+	if err != nil {
+		errorRes := response.ClientResponse(errorStatus(err), "could not retrieve the return", nil, err.Error())
+		c.JSON(errorStatus(err), errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved the return", details, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (r *ReturnHandler) CancelReturn(c *gin.Context) {
+	returnID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid return ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
+
+	if err := r.returnUseCase.CancelReturn(UserID, returnID); err != nil {
+		errorRes := response.ClientResponse(errorStatus(err), "could not cancel the return", nil, err.Error())
+		c.JSON(errorStatus(err), errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully canceled the return", nil, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (r *ReturnHandler) GetReturns(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "page number not in right format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	returns, err := r.returnUseCase.GetReturns(c.Query("status"), page)
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not retrieve returns", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved returns", returns, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (r *ReturnHandler) GetReturn(c *gin.Context) {
+	returnID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid return ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	details, err := r.returnUseCase.GetReturn(returnID)
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not retrieve the return", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved the return", details, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (r *ReturnHandler) ApproveReturn(c *gin.Context) {
+	returnID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid return ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	// the note is optional when approving
+	var decision models.ReturnDecision
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&decision); err != nil {
+			errorRes := response.ClientResponse(http.StatusBadRequest, "fields provided are in wrong format", nil, err.Error())
+			c.JSON(http.StatusBadRequest, errorRes)
+			return
+		}
+	}
+
+	if err := r.returnUseCase.ApproveReturn(returnID, decision.Note); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not approve the return", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully approved the return", nil, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (r *ReturnHandler) RejectReturn(c *gin.Context) {
+	returnID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid return ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	var decision models.ReturnDecision
+	if err := c.ShouldBindJSON(&decision); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "fields provided are in wrong format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	if err := r.returnUseCase.RejectReturn(returnID, decision.Note); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not reject the return", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully rejected the return", nil, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (r *ReturnHandler) SchedulePickup(c *gin.Context) {
+	returnID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid return ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	var pickup models.ReturnPickup
+	if err := c.ShouldBindJSON(&pickup); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "fields provided are in wrong format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	if err := r.returnUseCase.SchedulePickup(returnID, pickup.PickupDate); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not schedule the pickup", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully scheduled the pickup", nil, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (r *ReturnHandler) ReceiveReturn(c *gin.Context) {
+	returnID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid return ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	if err := r.returnUseCase.ReceiveReturn(returnID); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not mark the return as received", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully received the return", nil, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (r *ReturnHandler) InspectReturn(c *gin.Context) {
+	returnID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid return ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	// without a body every returned unit passed inspection
+	var inspection models.ReturnInspection
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&inspection); err != nil {
+			errorRes := response.ClientResponse(http.StatusBadRequest, "fields provided are in wrong format", nil, err.Error())
+			c.JSON(http.StatusBadRequest, errorRes)
+			return
+		}
+	}
+
+	if err := r.returnUseCase.InspectReturn(returnID, inspection.Items); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not record the inspection", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully inspected the return", nil, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (r *ReturnHandler) RefundReturn(c *gin.Context) {
+	returnID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid return ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	adminId, _ := c.Get("id")
+	AdminID, _ := adminId.(int)
+
+	if err := r.returnUseCase.RefundReturn(AdminID, returnID); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not refund the return", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully refunded the return", nil, nil)
+	c.JSON(http.StatusOK, successRes)
+}
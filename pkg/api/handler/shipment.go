@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	interfaces "github.com/ahdaan98/pkg/usecase/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+	"github.com/ahdaan98/pkg/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type ShipmentHandler struct {
+	shipmentUseCase interfaces.ShipmentUseCase
+}
+
+func NewShipmentHandler(useCase interfaces.ShipmentUseCase) *ShipmentHandler {
+	return &ShipmentHandler{
+		shipmentUseCase: useCase,
+	}
+}
+
+func (s *ShipmentHandler) CreateShipment(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	var shipment models.ShipmentRequest
+	if err := c.ShouldBindJSON(&shipment); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "fields provided are in wrong format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	adminId, _ := c.Get("id")
+	AdminID, _ := adminId.(int)
+
+	details, err := s.shipmentUseCase.CreateShipment(AdminID, orderID, shipment)
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not ship the order", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully shipped the order", details, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (s *ShipmentHandler) GetShipment(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	details, err := s.shipmentUseCase.GetShipment(orderID)
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not retrieve the shipment", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved the shipment", details, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (s *ShipmentHandler) GetUserShipment(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid order ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	userId, _ := c.Get("id")
+	UserID, _ := userId.(int)
+
+	details, err := s.shipmentUseCase.GetUserShipment(UserID, orderID)
+	if err != nil {
+		errorRes := response.ClientResponse(errorStatus(err), "could not retrieve the tracking details", nil, err.Error())
+		c.JSON(errorStatus(err), errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved the tracking details", details, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+// TrackingWebhook receives tracking scans from the courier.
+func (s *ShipmentHandler) TrackingWebhook(c *gin.Context) {
+	var event models.ShipmentEventRequest
+	if err := c.ShouldBindJSON(&event); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "fields provided are in wrong format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	if err := s.shipmentUseCase.RecordTrackingEvent(event); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not record the tracking event", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully recorded the tracking event", nil, nil)
+	c.JSON(http.StatusOK, successRes)
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	interfaces "github.com/ahdaan98/pkg/usecase/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+	"github.com/ahdaan98/pkg/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type ShippingZoneHandler struct {
+	shippingZoneUseCase interfaces.ShippingZoneUseCase
+}
+
+func NewShippingZoneHandler(useCase interfaces.ShippingZoneUseCase) *ShippingZoneHandler {
+	return &ShippingZoneHandler{
+		shippingZoneUseCase: useCase,
+	}
+}
+
+func (s *ShippingZoneHandler) AddShippingZone(c *gin.Context) {
+	var zone models.ShippingZoneRequest
+	if err := c.ShouldBindJSON(&zone); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "fields provided are in wrong format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	added, err := s.shippingZoneUseCase.AddShippingZone(zone)
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not add the shipping zone", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully added the shipping zone", added, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (s *ShippingZoneHandler) EditShippingZone(c *gin.Context) {
+	zoneID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid shipping zone ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	var zone models.ShippingZoneRequest
+	if err := c.ShouldBindJSON(&zone); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "fields provided are in wrong format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	edited, err := s.shippingZoneUseCase.EditShippingZone(zoneID, zone)
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not edit the shipping zone", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully edited the shipping zone", edited, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (s *ShippingZoneHandler) DeleteShippingZone(c *gin.Context) {
+	zoneID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid shipping zone ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	if err := s.shippingZoneUseCase.DeleteShippingZone(zoneID); err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not delete the shipping zone", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully deleted the shipping zone", nil, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (s *ShippingZoneHandler) GetShippingZones(c *gin.Context) {
+	zones, err := s.shippingZoneUseCase.GetShippingZones()
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not retrieve shipping zones", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved shipping zones", zones, nil)
+	c.JSON(http.StatusOK, successRes)
+}
+
+func (s *ShippingZoneHandler) GetShippingZone(c *gin.Context) {
+	zoneID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "Invalid shipping zone ID format", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	zone, err := s.shippingZoneUseCase.GetShippingZone(zoneID)
+	if err != nil {
+		errorRes := response.ClientResponse(http.StatusBadRequest, "could not retrieve the shipping zone", nil, err.Error())
+		c.JSON(http.StatusBadRequest, errorRes)
+		return
+	}
+
+	successRes := response.ClientResponse(http.StatusOK, "Successfully retrieved the shipping zone", zone, nil)
+	c.JSON(http.StatusOK, successRes)
+}
@@ -553,9 +553,9 @@ func TestGetOrders(t *testing.T) {
 			c.Request = req
 
 			if tc.mockError == nil {
-				mockOrderUseCase.EXPECT().GetOrders(gomock.Any()).Return(tc.mockReturn, tc.mockError).AnyTimes()
+				mockOrderUseCase.EXPECT().GetOrders(gomock.Any(), gomock.Any()).Return(tc.mockReturn, tc.mockError).AnyTimes()
 			} else {
-				mockOrderUseCase.EXPECT().GetOrders(gomock.Any()).Return(tc.mockReturn, tc.mockError).AnyTimes()
+				mockOrderUseCase.EXPECT().GetOrders(gomock.Any(), gomock.Any()).Return(tc.mockReturn, tc.mockError).AnyTimes()
 			}
 
 			handler.GetOrders(c)
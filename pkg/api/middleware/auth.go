@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"fmt"
 	"github.com/ahdaan98/pkg/config"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
@@ -21,11 +22,15 @@ func AuthMiddleware(c *gin.Context) {
 
 	// Decode/validate it
 	// Parse takes the token string and a function for looking up the key. The latter is especially
-	_, err = jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
 		return []byte(cfg.ACCESS_KEY_ADMIN), nil
 	})
 
-	if err != nil {
+	if err != nil || !token.Valid {
 
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization token, please log in"})
 		c.Abort()
@@ -33,5 +38,24 @@ func AuthMiddleware(c *gin.Context) {
 
 	}
 
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization token, please log in"})
+		c.Abort()
+		return
+	}
+
+	role, ok := claims["role"].(string)
+	if !ok || role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access"})
+		c.Abort()
+		return
+	}
+
+	c.Set("role", role)
+	if id, ok := claims["id"].(float64); ok {
+		c.Set("id", int(id))
+	}
+
 	c.Next()
 }
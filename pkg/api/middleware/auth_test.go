@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// there is no .env next to the tests, so the admin key is empty
+	sign := func(key string, claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name     string
+		cookie   string
+		wantCode int
+		wantID   interface{}
+	}{
+		{
+			name:     "admin token passes with its id",
+			cookie:   sign("", jwt.MapClaims{"id": 3, "role": "admin"}),
+			wantCode: http.StatusOK,
+			wantID:   3,
+		},
+		{
+			name:     "no token",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "token signed with another key",
+			cookie:   sign("other", jwt.MapClaims{"id": 3, "role": "admin"}),
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "customer token is refused",
+			cookie:   sign("", jwt.MapClaims{"id": 3, "role": "client"}),
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "token without a role is refused",
+			cookie:   sign("", jwt.MapClaims{"id": 3}),
+			wantCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotID interface{}
+			router := gin.New()
+			router.GET("/admin/orders", AuthMiddleware, func(c *gin.Context) {
+				gotID, _ = c.Get("id")
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/orders", nil)
+			if tc.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "admin", Value: tc.cookie})
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.wantCode, w.Code)
+			assert.Equal(t, tc.wantID, gotID)
+		})
+	}
+}
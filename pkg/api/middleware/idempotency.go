@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ahdaan98/pkg/config"
+	"github.com/ahdaan98/pkg/domain"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	defaultIdempotencyKeyTTL = 24 * time.Hour
+)
+
+// Idempotency makes a route safe to retry. A request carrying an
+// Idempotency-Key header is handled once; repeating it with the same key and
+// the same request gets the stored response back instead of running the
+// handler again. Requests without the header pass straight through.
+type Idempotency struct {
+	repo interfaces.IdempotencyRepository
+	ttl  time.Duration
+}
+
+func NewIdempotency(repo interfaces.IdempotencyRepository, cfg config.Config) *Idempotency {
+	ttl, err := time.ParseDuration(cfg.IDEMPOTENCY_KEY_TTL)
+	if err != nil || ttl <= 0 {
+		ttl = defaultIdempotencyKeyTTL
+	}
+
+	return &Idempotency{
+		repo: repo,
+		ttl:  ttl,
+	}
+}
+
+func (i *Idempotency) Handle(c *gin.Context) {
+	key := c.GetHeader(IdempotencyKeyHeader)
+	if key == "" {
+		c.Next()
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "could not read request body"})
+		c.Abort()
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	userID := 0
+	if id, ok := c.Get("id"); ok {
+		userID, _ = id.(int)
+	}
+
+	hash := requestHash(c.Request, body)
+
+	reserved, err := i.repo.ReserveKey(domain.IdempotencyKey{
+		Key:         key,
+		UserID:      userID,
+		Method:      c.Request.Method,
+		Path:        c.Request.URL.Path,
+		RequestHash: hash,
+		ExpiresAt:   time.Now().Add(i.ttl),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not store idempotency key"})
+		c.Abort()
+		return
+	}
+
+	if !reserved {
+		i.replay(c, key, userID, hash)
+		return
+	}
+
+	recorder := &responseRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+
+	// a panicking handler never answers, so the key is freed for a retry
+	// before the panic goes on to gin's recovery
+	defer func() {
+		if p := recover(); p != nil {
+			i.release(key, userID)
+			panic(p)
+		}
+	}()
+
+	c.Next()
+
+	// a server error may be temporary, so the key is freed for a retry
+	if recorder.Status() >= http.StatusInternalServerError {
+		i.release(key, userID)
+		return
+	}
+
+	if err := i.repo.SaveResponse(key, userID, recorder.Status(), recorder.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+		log.Printf("idempotency: could not save the response for key %q: %v", key, err)
+	}
+}
+
+func (i *Idempotency) release(key string, userID int) {
+	if err := i.repo.ReleaseKey(key, userID); err != nil {
+		log.Printf("idempotency: could not release key %q: %v", key, err)
+	}
+}
+
+func (i *Idempotency) replay(c *gin.Context, key string, userID int, hash string) {
+	stored, err := i.repo.GetKey(key, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not read idempotency key"})
+		c.Abort()
+		return
+	}
+
+	if stored.RequestHash != hash {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Idempotency-Key was already used for a different request"})
+		c.Abort()
+		return
+	}
+
+	if stored.ResponseStatus == 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "a request with this Idempotency-Key is still being processed"})
+		c.Abort()
+		return
+	}
+
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Data(stored.ResponseStatus, stored.ContentType, stored.ResponseBody)
+	c.Abort()
+}
+
+// requestHash identifies a request by its method, path, query and body, so a
+// key cannot be replayed for a different request.
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(r.URL.Path))
+	h.Write([]byte{0})
+	h.Write([]byte(r.URL.Query().Encode()))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder keeps a copy of everything the handler writes.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ahdaan98/pkg/config"
+	"github.com/ahdaan98/pkg/domain"
+	"github.com/ahdaan98/pkg/repository/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const body = `{"address_id":1,"payment_id":1}`
+
+	// the hash of a POST /check-out with body, as the middleware computes it
+	req := httptest.NewRequest(http.MethodPost, "/check-out", nil)
+	hash := requestHash(req, []byte(body))
+
+	tests := []struct {
+		name        string
+		key         string
+		handlerCode int
+		// panics makes the handler panic instead of answering
+		panics     bool
+		stub       func(repo *mocks.MockIdempotencyRepository)
+		wantCode   int
+		wantBody   string
+		wantCalls  int
+		wantReplay bool
+	}{
+		{
+			name:        "no key passes through",
+			handlerCode: http.StatusCreated,
+			stub:        func(repo *mocks.MockIdempotencyRepository) {},
+			wantCode:    http.StatusCreated,
+			wantBody:    `{"order":1}`,
+			wantCalls:   1,
+		},
+		{
+			name:        "first request is stored",
+			key:         "abc",
+			handlerCode: http.StatusCreated,
+			stub: func(repo *mocks.MockIdempotencyRepository) {
+				repo.EXPECT().ReserveKey(gomock.Any()).Return(true, nil)
+				repo.EXPECT().SaveResponse("abc", 7, http.StatusCreated, "application/json; charset=utf-8", []byte(`{"order":1}`)).Return(nil)
+			},
+			wantCode:  http.StatusCreated,
+			wantBody:  `{"order":1}`,
+			wantCalls: 1,
+		},
+		{
+			name: "repeat gets the stored response",
+			key:  "abc",
+			stub: func(repo *mocks.MockIdempotencyRepository) {
+				repo.EXPECT().ReserveKey(gomock.Any()).Return(false, nil)
+				repo.EXPECT().GetKey("abc", 7).Return(domain.IdempotencyKey{RequestHash: hash, ResponseStatus: http.StatusCreated, ContentType: "application/json; charset=utf-8", ResponseBody: []byte(`{"order":1}`)}, nil)
+			},
+			wantCode:   http.StatusCreated,
+			wantBody:   `{"order":1}`,
+			wantCalls:  0,
+			wantReplay: true,
+		},
+		{
+			name: "key reused for another request",
+			key:  "abc",
+			stub: func(repo *mocks.MockIdempotencyRepository) {
+				repo.EXPECT().ReserveKey(gomock.Any()).Return(false, nil)
+				repo.EXPECT().GetKey("abc", 7).Return(domain.IdempotencyKey{RequestHash: "other", ResponseStatus: http.StatusCreated}, nil)
+			},
+			wantCode:  http.StatusUnprocessableEntity,
+			wantCalls: 0,
+		},
+		{
+			name: "first request still running",
+			key:  "abc",
+			stub: func(repo *mocks.MockIdempotencyRepository) {
+				repo.EXPECT().ReserveKey(gomock.Any()).Return(false, nil)
+				repo.EXPECT().GetKey("abc", 7).Return(domain.IdempotencyKey{RequestHash: hash}, nil)
+			},
+			wantCode:  http.StatusConflict,
+			wantCalls: 0,
+		},
+		{
+			name:        "server error frees the key",
+			key:         "abc",
+			handlerCode: http.StatusInternalServerError,
+			stub: func(repo *mocks.MockIdempotencyRepository) {
+				repo.EXPECT().ReserveKey(gomock.Any()).Return(true, nil)
+				repo.EXPECT().ReleaseKey("abc", 7).Return(nil)
+			},
+			wantCode:  http.StatusInternalServerError,
+			wantCalls: 1,
+		},
+		{
+			name:   "panicking handler frees the key",
+			key:    "abc",
+			panics: true,
+			stub: func(repo *mocks.MockIdempotencyRepository) {
+				repo.EXPECT().ReserveKey(gomock.Any()).Return(true, nil)
+				repo.EXPECT().ReleaseKey("abc", 7).Return(nil)
+			},
+			wantCode:  http.StatusInternalServerError,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockIdempotencyRepository(ctrl)
+			tc.stub(repo)
+
+			calls := 0
+			router := gin.New()
+			router.Use(gin.RecoveryWithWriter(io.Discard))
+			router.POST("/check-out", func(c *gin.Context) {
+				c.Set("id", 7)
+			}, NewIdempotency(repo, config.Config{}).Handle, func(c *gin.Context) {
+				calls++
+				if tc.panics {
+					panic("handler failed")
+				}
+				c.JSON(tc.handlerCode, gin.H{"order": 1})
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/check-out", strings.NewReader(body))
+			if tc.key != "" {
+				req.Header.Set(IdempotencyKeyHeader, tc.key)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.wantCode, w.Code)
+			assert.Equal(t, tc.wantCalls, calls)
+			if tc.wantBody != "" {
+				assert.Equal(t, tc.wantBody, w.Body.String())
+			}
+			assert.Equal(t, tc.wantReplay, w.Header().Get(IdempotentReplayedHeader) == "true")
+		})
+	}
+}
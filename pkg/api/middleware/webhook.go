@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/ahdaan98/pkg/config"
+	"github.com/gin-gonic/gin"
+)
+
+const CourierSecretHeader = "X-Courier-Secret"
+
+// CourierWebhookMiddleware only lets through requests carrying the secret
+// shared with the courier.
+func CourierWebhookMiddleware(c *gin.Context) {
+	cfg, _ := config.LoadEnvVariables()
+
+	secret := c.GetHeader(CourierSecretHeader)
+	if cfg.COURIER_WEBHOOK_SECRET == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(cfg.COURIER_WEBHOOK_SECRET)) != 1 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid webhook secret"})
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
@@ -4,28 +4,37 @@ import (
 	"log"
 
 	"github.com/ahdaan98/pkg/api/handler"
+	"github.com/ahdaan98/pkg/api/middleware"
 	"github.com/ahdaan98/pkg/config"
 	"github.com/ahdaan98/pkg/routes"
+	"github.com/ahdaan98/pkg/scheduler"
 	"github.com/gin-gonic/gin"
 )
 
 type ServerHTTP struct {
-	engine *gin.Engine
+	engine    *gin.Engine
+	scheduler *scheduler.Scheduler
 }
 
-func NewServerHTTP(userHandler *handler.UserHandler, adminHandler *handler.AdminHandler, categoryHandler *handler.CategoryHandler,brandHandler *handler.BrandHandler, inventoryHandler *handler.InventoryHandler, cartHandler *handler.CartHandler, orderHandler *handler.OrderHandler, paymentHandler *handler.PaymentHandler, walletHandler *handler.WalletHandler, couponHandler *handler.CouponHandler) *ServerHTTP {
+func NewServerHTTP(userHandler *handler.UserHandler, adminHandler *handler.AdminHandler, categoryHandler *handler.CategoryHandler,brandHandler *handler.BrandHandler, inventoryHandler *handler.InventoryHandler, cartHandler *handler.CartHandler, orderHandler *handler.OrderHandler, paymentHandler *handler.PaymentHandler, walletHandler *handler.WalletHandler, couponHandler *handler.CouponHandler, returnHandler *handler.ReturnHandler, shipmentHandler *handler.ShipmentHandler, bulkOrderHandler *handler.BulkOrderHandler, shippingZoneHandler *handler.ShippingZoneHandler, pinCodeHandler *handler.PinCodeHandler, invoiceHandler *handler.InvoiceHandler, fakeCheckoutHandler *handler.FakeCheckoutHandler, idempotency *middleware.Idempotency, scheduler *scheduler.Scheduler) *ServerHTTP {
 	engine := gin.Default()
 
 	engine.LoadHTMLGlob("pkg/templates/*.html")
-	routes.UserRoutes(engine.Group("/user"), categoryHandler, brandHandler, inventoryHandler, userHandler, cartHandler, orderHandler, paymentHandler, walletHandler, couponHandler)
-	routes.AdminRoutes(engine.Group("/admin"),categoryHandler, brandHandler, inventoryHandler,adminHandler,orderHandler, couponHandler)
+	routes.UserRoutes(engine.Group("/user"), categoryHandler, brandHandler, inventoryHandler, userHandler, cartHandler, orderHandler, paymentHandler, walletHandler, couponHandler, returnHandler, shipmentHandler, pinCodeHandler, invoiceHandler, idempotency)
+	routes.AdminRoutes(engine.Group("/admin"),categoryHandler, brandHandler, inventoryHandler,adminHandler,orderHandler, couponHandler, returnHandler, shipmentHandler, bulkOrderHandler, shippingZoneHandler, pinCodeHandler, invoiceHandler, paymentHandler)
+	routes.WebhookRoutes(engine.Group("/webhooks"), shipmentHandler, paymentHandler)
+	if fakeCheckoutHandler.Enabled() {
+		routes.FakeCheckoutRoutes(engine.Group("/fake-checkout"), fakeCheckoutHandler)
+	}
 
 	return &ServerHTTP{
-		engine: engine,
+		engine:    engine,
+		scheduler: scheduler,
 	}
 }
 func (s *ServerHTTP) Start() {
 	cfg,_:=config.LoadEnvVariables()
+	s.scheduler.Start()
 	err := s.engine.Run(":"+cfg.PORT)
 	if err != nil {
 		log.Fatal("gin engin couldn't start")
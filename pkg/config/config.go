@@ -17,6 +17,33 @@ type Config struct {
 	KEY_ID_FOR_PAY     string
 	SECRET_KEY_FOR_PAY string
 	PORT               string
+	// IDEMPOTENCY_KEY_TTL is how long a stored Idempotency-Key response is
+	// replayed, as a Go duration such as "24h".
+	IDEMPOTENCY_KEY_TTL string
+	// COURIER_WEBHOOK_SECRET is the secret the courier sends with tracking
+	// events.
+	COURIER_WEBHOOK_SECRET string
+	// UNPAID_ORDER_TTL is how long an online payment order may stay unpaid
+	// before it is canceled, as a Go duration such as "30m".
+	UNPAID_ORDER_TTL string
+	// SELLER_GSTIN and SELLER_STATE identify the seller on GST invoices.
+	// Orders shipped within SELLER_STATE pay CGST and SGST, others IGST.
+	SELLER_GSTIN string
+	SELLER_STATE string
+	// INVOICE_DIR is where rendered invoices and credit notes are stored.
+	INVOICE_DIR string
+	// INVOICE_LINK_SECRET signs invoice download links that work without
+	// logging in. INVOICE_LINK_TTL is how long such a link is valid, as a Go
+	// duration such as "72h".
+	INVOICE_LINK_SECRET string
+	INVOICE_LINK_TTL    string
+	// RAZORPAY_WEBHOOK_SECRET is the secret razorpay signs webhook events with.
+	RAZORPAY_WEBHOOK_SECRET string
+	// PAYMENT_GATEWAY is razorpay, the default, or fake for the in-process
+	// gateway. FAKE_GATEWAY_MODE makes fake payments succeed, fail or wait to
+	// be captured: success, failure or delayed.
+	PAYMENT_GATEWAY   string
+	FAKE_GATEWAY_MODE string
 }
 
 func LoadEnvVariables() (Config, error) {
@@ -26,15 +53,26 @@ func LoadEnvVariables() (Config, error) {
 	}
 
 	config := Config{
-		DBUrl:              os.Getenv("DB_URL"),
-		AUTHTOKEN:          os.Getenv("DB_AUTHTOKEN"),
-		ACCOUNTSID:         os.Getenv("DB_ACCOUNTSID"),
-		SERVICESID:         os.Getenv("DB_SERVICESID"),
-		ACCESS_KEY_ADMIN:   os.Getenv("ACCESS_KEY_ADMIN"),
-		ACCESS_KEY_USER:    os.Getenv("ACCESS_KEY_USER"),
-		KEY_ID_FOR_PAY:     os.Getenv("KEY_ID_FOR_PAY"),
-		SECRET_KEY_FOR_PAY: os.Getenv("SECRET_KEY_FOR_PAY"),
-		PORT:               os.Getenv("PORT"),
+		DBUrl:                   os.Getenv("DB_URL"),
+		AUTHTOKEN:               os.Getenv("DB_AUTHTOKEN"),
+		ACCOUNTSID:              os.Getenv("DB_ACCOUNTSID"),
+		SERVICESID:              os.Getenv("DB_SERVICESID"),
+		ACCESS_KEY_ADMIN:        os.Getenv("ACCESS_KEY_ADMIN"),
+		ACCESS_KEY_USER:         os.Getenv("ACCESS_KEY_USER"),
+		KEY_ID_FOR_PAY:          os.Getenv("KEY_ID_FOR_PAY"),
+		SECRET_KEY_FOR_PAY:      os.Getenv("SECRET_KEY_FOR_PAY"),
+		PORT:                    os.Getenv("PORT"),
+		IDEMPOTENCY_KEY_TTL:     os.Getenv("IDEMPOTENCY_KEY_TTL"),
+		COURIER_WEBHOOK_SECRET:  os.Getenv("COURIER_WEBHOOK_SECRET"),
+		UNPAID_ORDER_TTL:        os.Getenv("UNPAID_ORDER_TTL"),
+		SELLER_GSTIN:            os.Getenv("SELLER_GSTIN"),
+		SELLER_STATE:            os.Getenv("SELLER_STATE"),
+		INVOICE_DIR:             os.Getenv("INVOICE_DIR"),
+		INVOICE_LINK_SECRET:     os.Getenv("INVOICE_LINK_SECRET"),
+		INVOICE_LINK_TTL:        os.Getenv("INVOICE_LINK_TTL"),
+		RAZORPAY_WEBHOOK_SECRET: os.Getenv("RAZORPAY_WEBHOOK_SECRET"),
+		PAYMENT_GATEWAY:         os.Getenv("PAYMENT_GATEWAY"),
+		FAKE_GATEWAY_MODE:       os.Getenv("FAKE_GATEWAY_MODE"),
 	}
 
 	return config, nil
@@ -51,24 +51,71 @@ func ConnectDB(cfg config.Config) (*gorm.DB, error) {
 
 	}
 
+	// gorm only creates a check constraint when it is missing, so drop the
+	// payment status check to have it recreated with the current statuses
+	if DB.Migrator().HasConstraint(&domain.Order{}, "chk_orders_payment_status") {
+		if err := DB.Migrator().DropConstraint(&domain.Order{}, "chk_orders_payment_status"); err != nil {
+			return DB, err
+		}
+	}
 	if err := DB.AutoMigrate(domain.Order{}); err != nil {
 		return DB, err
 	}
+	// created_at comes from gorm.Model, which has no index tag for it; admins
+	// list orders newest first
+	if err := DB.Exec("CREATE INDEX IF NOT EXISTS idx_orders_created_at ON orders (created_at)").Error; err != nil {
+		return DB, err
+	}
 	if err := DB.AutoMigrate(domain.OrderItem{}); err != nil {
 		return DB, err
 	}
+	// items placed before item level statuses existed follow their order
+	if err := DB.Exec("UPDATE order_items SET status = orders.order_status FROM orders WHERE orders.id = order_items.order_id AND order_items.status IS NULL").Error; err != nil {
+		return DB, err
+	}
+	// items placed before the product snapshot existed copy the product as it
+	// is now; the unit price and coupon share come from what was charged
+	if err := DB.Exec(backfillOrderItemSnapshot).Error; err != nil {
+		return DB, err
+	}
+	// orders placed before the quote was stored were never shipped or taxed
+	// and only ever had a coupon taken off
+	if err := DB.Exec(backfillOrderTotals).Error; err != nil {
+		return DB, err
+	}
+	if err := DB.AutoMigrate(domain.OrderItemTax{}); err != nil {
+		return DB, err
+	}
+	if err := DB.AutoMigrate(domain.DocumentSequence{}, domain.Invoice{}, domain.CreditNote{}, domain.CreditNoteItem{}); err != nil {
+		return DB, err
+	}
+	// invoices issued before the buyer was copied onto them take the buyer
+	// as the order shows it now
+	if err := DB.Exec(backfillInvoiceBuyer).Error; err != nil {
+		return DB, err
+	}
 	if err := DB.AutoMigrate(domain.PaymentMethod{}); err != nil {
 		return DB, err
 	}
-	if err := DB.AutoMigrate(domain.Payment{}); err != nil {
+	if err := DB.Exec(classifyPaymentMethods).Error; err != nil {
 		return DB, err
 	}
+	if err := DB.AutoMigrate(domain.PaymentAttempt{}, domain.Refund{}); err != nil {
+		return DB, err
+	}
+	// payments kept one row per gateway order before attempts existed; an
+	// unpaid order with a payment id on it means that payment failed
+	if DB.Migrator().HasTable("payments") {
+		if err := DB.Exec(copyPaymentsToAttempts).Error; err != nil {
+			return DB, err
+		}
+	}
 
 	if err := DB.AutoMigrate(domain.Wallet{}); err != nil {
 		return DB, err
 	}
 
-	if err := DB.AutoMigrate(domain.Coupon{}); err != nil {
+	if err := DB.AutoMigrate(domain.Coupon{}, domain.CouponRedemption{}); err != nil {
 		return DB, err
 	}
 
@@ -80,11 +127,114 @@ func ConnectDB(cfg config.Config) (*gorm.DB, error) {
 		return DB, err
 	}
 
+	if err := DB.AutoMigrate(domain.OrderStatusHistory{}); err != nil {
+		return DB, err
+	}
+
+	if err := DB.AutoMigrate(domain.ReturnRequest{}, domain.ReturnRequestItem{}, domain.ReturnRequestImage{}); err != nil {
+		return DB, err
+	}
+
+	if err := DB.AutoMigrate(domain.Shipment{}, domain.ShipmentEvent{}); err != nil {
+		return DB, err
+	}
+
+	if err := DB.AutoMigrate(domain.IdempotencyKey{}); err != nil {
+		return DB, err
+	}
+
+	if err := DB.AutoMigrate(domain.BulkOrderJob{}, domain.BulkOrderJobResult{}); err != nil {
+		return DB, err
+	}
+
+	if err := DB.AutoMigrate(domain.ShippingZone{}, domain.ShippingZoneArea{}, domain.ShippingRate{}, domain.PinCode{}); err != nil {
+		return DB, err
+	}
+
 	CheckAndCreateAdmin(DB)
 
 	return DB, nil
 }
 
+const backfillOrderTotals = `
+UPDATE orders o
+SET subtotal = s.subtotal,
+	discount = GREATEST(s.subtotal - o.final_price, 0),
+	shipping = 0,
+	tax = 0
+FROM (
+	SELECT x.id, COALESCE(SUM(oi.total_price), x.final_price) AS subtotal
+	FROM orders x
+	LEFT JOIN order_items oi ON oi.order_id = x.id
+	WHERE x.subtotal IS NULL
+	GROUP BY x.id
+) s
+WHERE s.id = o.id
+`
+
+const backfillOrderItemSnapshot = `
+UPDATE order_items oi
+SET product_name = COALESCE(snap.product_name, ''),
+	sku = COALESCE(snap.sku, ''),
+	brand_name = COALESCE(snap.brand_name, ''),
+	category_name = COALESCE(snap.category_name, ''),
+	unit_price = COALESCE(oi.total_price / NULLIF(oi.quantity, 0), 0),
+	discount = COALESCE(ROUND(CAST(oi.total_price - snap.charged AS numeric), 2), 0)
+FROM (
+	SELECT x.id, inv.product_name, inv.sku, b.brand_name, c.category_name,
+	x.total_price * o.final_price / NULLIF(SUM(x.total_price) OVER (PARTITION BY x.order_id), 0) AS charged
+	FROM order_items x
+	JOIN orders o ON o.id = x.order_id
+	LEFT JOIN inventories inv ON inv.id = x.inventory_id
+	LEFT JOIN brands b ON b.id = inv.brand_id
+	LEFT JOIN categories c ON c.id = inv.category_id
+	WHERE x.product_name IS NULL
+) snap
+WHERE snap.id = oi.id
+`
+
+const backfillInvoiceBuyer = `
+UPDATE invoices inv
+SET buyer_name = COALESCE(u.name, ''),
+	buyer_email = COALESCE(u.email, ''),
+	buyer_phone = COALESCE(u.phone, ''),
+	buyer_house_name = COALESCE(a.house_name, ''),
+	buyer_street = COALESCE(a.street, ''),
+	buyer_city = COALESCE(a.city, ''),
+	buyer_state = COALESCE(a.state, ''),
+	buyer_pin = COALESCE(a.pin, '')
+FROM orders o
+LEFT JOIN users u ON u.id = o.user_id
+LEFT JOIN addresses a ON a.id = o.address_id
+WHERE o.id = inv.order_id AND inv.buyer_name IS NULL
+`
+
+// classifyPaymentMethods gives methods added before they had a kind the one
+// their name suggests.
+const classifyPaymentMethods = `
+UPDATE payment_methods SET kind = CASE
+	WHEN payment_name ILIKE '%razorpay%' THEN 'ONLINE'
+	WHEN LOWER(TRIM(payment_name)) = 'cod' OR payment_name ILIKE '%cash on delivery%' THEN 'COD'
+	ELSE 'OTHER'
+END
+WHERE kind IS NULL OR kind = ''
+`
+
+const copyPaymentsToAttempts = `
+INSERT INTO payment_attempts (order_id, gateway_order_id, payment_id, amount, status, failure_reason, created_at, updated_at)
+SELECT p.order_id, p.razer_id, COALESCE(p.payment, ''), ROUND(o.final_price * 100),
+	CASE
+		WHEN COALESCE(p.payment, '') = '' THEN 'CREATED'
+		WHEN o.payment_status = 'NOT PAID' THEN 'FAILED'
+		ELSE 'CAPTURED'
+	END,
+	'', o.created_at, NOW()
+FROM payments p
+JOIN orders o ON o.id = p.order_id
+WHERE p.razer_id IS NOT NULL
+ON CONFLICT (gateway_order_id) DO NOTHING
+`
+
 func Migration(db *gorm.DB) error {
 	if err := db.AutoMigrate(&domain.Inventory{}); err != nil {
 		return err
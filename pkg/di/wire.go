@@ -6,10 +6,16 @@ package di
 import (
 	http "github.com/ahdaan98/pkg/api"
 	"github.com/ahdaan98/pkg/api/handler"
+	"github.com/ahdaan98/pkg/api/middleware"
 	"github.com/ahdaan98/pkg/config"
 	"github.com/ahdaan98/pkg/db"
+	"github.com/ahdaan98/pkg/events"
+	"github.com/ahdaan98/pkg/gateway"
 	helper "github.com/ahdaan98/pkg/helper"
+	"github.com/ahdaan98/pkg/pricing"
 	"github.com/ahdaan98/pkg/repository"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	"github.com/ahdaan98/pkg/scheduler"
 	"github.com/ahdaan98/pkg/usecase"
 	"github.com/google/wire"
 )
@@ -19,6 +25,12 @@ func InitializeAPI(cfg config.Config) (*http.ServerHTTP,error){
 		db.ConnectDB,
 
 		helper.NewHelper,
+		events.NewBus,
+		pricing.NewCheckoutCalculator,
+		wire.Bind(new(pricing.TaxClasses), new(interfaces.InventoryRepository)),
+		wire.Bind(new(pricing.ShippingZones), new(interfaces.ShippingZoneRepository)),
+		middleware.NewIdempotency,
+		scheduler.NewScheduler,
 
 		handler.NewBrandHandler,
 		handler.NewCategoryHandler,
@@ -30,6 +42,13 @@ func InitializeAPI(cfg config.Config) (*http.ServerHTTP,error){
 		handler.NewPaymentHandler,
 		handler.NewWalletHandler,
 		handler.NewCouponHandler,
+		handler.NewReturnHandler,
+		handler.NewShipmentHandler,
+		handler.NewBulkOrderHandler,
+		handler.NewShippingZoneHandler,
+		handler.NewPinCodeHandler,
+		handler.NewInvoiceHandler,
+		handler.NewFakeCheckoutHandler,
 
 		usecase.NewBrandUseCase,
 		usecase.NewCategoryUseCase,
@@ -38,9 +57,16 @@ func InitializeAPI(cfg config.Config) (*http.ServerHTTP,error){
 		usecase.NewAdminUseCase,
 		usecase.NewCartUseCase,
 		usecase.NewOrderUseCase,
+		gateway.New,
 		usecase.NewPaymentUseCase,
 		usecase.NewWalletUseCase,
 		usecase.NewCouponUseCase,
+		usecase.NewReturnUseCase,
+		usecase.NewShipmentUseCase,
+		usecase.NewBulkOrderUseCase,
+		usecase.NewShippingZoneUseCase,
+		usecase.NewPinCodeUseCase,
+		usecase.NewInvoiceUseCase,
 
 		repository.NewBrandRepository,
 		repository.NewCategoryRepository,
@@ -50,8 +76,17 @@ func InitializeAPI(cfg config.Config) (*http.ServerHTTP,error){
 		repository.NewCartRepository,
 		repository.NewOrderRepository,
 		repository.NewPaymentRepository,
+		repository.NewRefundRepository,
 		repository.NewWalletRepository,
 		repository.NewCouponRepository,
+		repository.NewTransactionRepository,
+		repository.NewReturnRepository,
+		repository.NewShipmentRepository,
+		repository.NewIdempotencyRepository,
+		repository.NewBulkJobRepository,
+		repository.NewShippingZoneRepository,
+		repository.NewPinCodeRepository,
+		repository.NewInvoiceRepository,
 
 		http.NewServerHTTP,
 	 )
@@ -9,10 +9,15 @@ package di
 import (
 	"github.com/ahdaan98/pkg/api"
 	"github.com/ahdaan98/pkg/api/handler"
+	"github.com/ahdaan98/pkg/api/middleware"
 	"github.com/ahdaan98/pkg/config"
 	"github.com/ahdaan98/pkg/db"
+	"github.com/ahdaan98/pkg/events"
+	"github.com/ahdaan98/pkg/gateway"
 	"github.com/ahdaan98/pkg/helper"
+	"github.com/ahdaan98/pkg/pricing"
 	"github.com/ahdaan98/pkg/repository"
+	"github.com/ahdaan98/pkg/scheduler"
 	"github.com/ahdaan98/pkg/usecase"
 )
 
@@ -26,7 +31,9 @@ func InitializeAPI(cfg config.Config) (*http.ServerHTTP, error) {
 	userRepository := repository.NewUserRepository(gormDB)
 	interfacesHelper := helper.NewHelper(cfg)
 	inventoryRepository := repository.NewInventoryRespository(gormDB)
-	userUseCase := usecase.NewUserUseCase(userRepository, interfacesHelper, cfg, inventoryRepository)
+	shippingZoneRepository := repository.NewShippingZoneRepository(gormDB)
+	calculator := pricing.NewCheckoutCalculator(cfg, inventoryRepository, shippingZoneRepository)
+	userUseCase := usecase.NewUserUseCase(userRepository, interfacesHelper, cfg, inventoryRepository, calculator)
 	userHandler := handler.NewUserHandler(userUseCase, interfacesHelper)
 	adminRepository := repository.NewAdminRepository(gormDB)
 	adminUseCase := usecase.NewAdminUseCase(adminRepository, userRepository,interfacesHelper)
@@ -40,20 +47,45 @@ func InitializeAPI(cfg config.Config) (*http.ServerHTTP, error) {
 	inventoryUseCase := usecase.NewInventoryUseCase(inventoryRepository)
 	inventoryHandler := handler.NewInventoryHandler(inventoryUseCase)
 	cartRepository := repository.NewCartRepository(gormDB)
-	cartUseCase := usecase.NewCartUseCase(cartRepository, inventoryRepository, userUseCase, adminRepository)
+	pinCodeRepository := repository.NewPinCodeRepository(gormDB)
+	cartUseCase := usecase.NewCartUseCase(cartRepository, inventoryRepository, userUseCase, adminRepository, pinCodeRepository, calculator)
 	cartHandler := handler.NewCartHandler(cartUseCase)
 	orderRepository := repository.NewOrderRepository(gormDB)
 	walletRepository := repository.NewWalletRepository(gormDB)
 	couponRepository := repository.NewCouponRepository(gormDB)
-	orderUseCase := usecase.NewOrderUseCase(orderRepository, userUseCase, walletRepository, cartRepository, couponRepository)
+	transactionRepository := repository.NewTransactionRepository(gormDB)
+	publisher := events.NewBus()
+	orderUseCase := usecase.NewOrderUseCase(orderRepository, userUseCase, cartUseCase, walletRepository, cartRepository, couponRepository, pinCodeRepository, transactionRepository, calculator, publisher, cfg)
 	orderHandler := handler.NewOrderHandler(orderUseCase)
 	paymentRepository := repository.NewPaymentRepository(gormDB)
-	paymentUseCase := usecase.NewPaymentUseCase(orderRepository, paymentRepository)
+	refundRepository := repository.NewRefundRepository(gormDB)
+	paymentGateway := gateway.New(cfg)
+	paymentUseCase := usecase.NewPaymentUseCase(orderRepository, paymentRepository, refundRepository, transactionRepository, paymentGateway, cfg)
 	paymentHandler := handler.NewPaymentHandler(paymentUseCase)
 	walletUsecase := usecase.NewWalletUseCase(walletRepository)
 	walletHandler := handler.NewWalletHandler(walletUsecase)
 	couponUseCase := usecase.NewCouponUseCase(couponRepository, orderRepository, cartRepository)
 	couponHandler := handler.NewCouponHandler(couponUseCase)
-	serverHTTP := http.NewServerHTTP(userHandler, adminHandler, categoryHandler, brandHandler, inventoryHandler, cartHandler, orderHandler, paymentHandler, walletHandler, couponHandler)
+	returnRepository := repository.NewReturnRepository(gormDB)
+	returnUseCase := usecase.NewReturnUseCase(returnRepository, transactionRepository)
+	returnHandler := handler.NewReturnHandler(returnUseCase)
+	shipmentRepository := repository.NewShipmentRepository(gormDB)
+	shipmentUseCase := usecase.NewShipmentUseCase(shipmentRepository, orderRepository, transactionRepository)
+	shipmentHandler := handler.NewShipmentHandler(shipmentUseCase)
+	bulkJobRepository := repository.NewBulkJobRepository(gormDB)
+	bulkOrderUseCase := usecase.NewBulkOrderUseCase(bulkJobRepository, orderRepository, transactionRepository)
+	bulkOrderHandler := handler.NewBulkOrderHandler(bulkOrderUseCase)
+	shippingZoneUseCase := usecase.NewShippingZoneUseCase(shippingZoneRepository, transactionRepository)
+	shippingZoneHandler := handler.NewShippingZoneHandler(shippingZoneUseCase)
+	pinCodeUseCase := usecase.NewPinCodeUseCase(pinCodeRepository, inventoryRepository, interfacesHelper)
+	pinCodeHandler := handler.NewPinCodeHandler(pinCodeUseCase)
+	invoiceRepository := repository.NewInvoiceRepository(gormDB)
+	invoiceUseCase := usecase.NewInvoiceUseCase(invoiceRepository, orderRepository, cfg)
+	invoiceHandler := handler.NewInvoiceHandler(invoiceUseCase)
+	fakeCheckoutHandler := handler.NewFakeCheckoutHandler(paymentGateway)
+	idempotencyRepository := repository.NewIdempotencyRepository(gormDB)
+	idempotency := middleware.NewIdempotency(idempotencyRepository, cfg)
+	schedulerScheduler := scheduler.NewScheduler(orderUseCase, bulkOrderUseCase, paymentUseCase)
+	serverHTTP := http.NewServerHTTP(userHandler, adminHandler, categoryHandler, brandHandler, inventoryHandler, cartHandler, orderHandler, paymentHandler, walletHandler, couponHandler, returnHandler, shipmentHandler, bulkOrderHandler, shippingZoneHandler, pinCodeHandler, invoiceHandler, fakeCheckoutHandler, idempotency, schedulerScheduler)
 	return serverHTTP, nil
 }
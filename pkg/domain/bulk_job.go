@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+const (
+	BulkActionShip    = "ship"
+	BulkActionDeliver = "deliver"
+	BulkActionCancel  = "cancel"
+)
+
+const (
+	BulkJobStatusQueued    = "QUEUED"
+	BulkJobStatusRunning   = "RUNNING"
+	BulkJobStatusCompleted = "COMPLETED"
+)
+
+const (
+	BulkResultPending   = "PENDING"
+	BulkResultSucceeded = "SUCCEEDED"
+	BulkResultFailed    = "FAILED"
+)
+
+// BulkOrderJob is one admin action applied to many orders in the background.
+// The counters are kept up to date as orders are processed so the job can be
+// polled for progress.
+type BulkOrderJob struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	AdminID    int        `json:"admin_id"`
+	Action     string     `json:"action" gorm:"not null"`
+	Status     string     `json:"status" gorm:"not null;index"`
+	Total      int        `json:"total"`
+	Processed  int        `json:"processed" gorm:"default:0"`
+	Succeeded  int        `json:"succeeded" gorm:"default:0"`
+	Failed     int        `json:"failed" gorm:"default:0"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+// BulkOrderJobResult is the outcome of a bulk job for a single order.
+type BulkOrderJobResult struct {
+	ID             uint         `json:"id" gorm:"primaryKey"`
+	BulkOrderJobID uint         `json:"bulk_order_job_id" gorm:"not null;uniqueIndex:idx_bulk_order_job_results_order"`
+	BulkOrderJob   BulkOrderJob `json:"-" gorm:"foreignkey:BulkOrderJobID;constraint:OnDelete:CASCADE"`
+	OrderID        uint         `json:"order_id" gorm:"not null;uniqueIndex:idx_bulk_order_job_results_order"`
+	Status         string       `json:"status" gorm:"not null"`
+	Message        string       `json:"message"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
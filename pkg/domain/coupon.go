@@ -1,8 +1,24 @@
 package domain
 
+import "time"
+
 type Coupon struct {
 	ID           uint   `json:"id" gorm:"primaryKey"`
 	CouponName   string `json:"coupon_name"`
 	Status       bool   `json:"status" gorm:"column:status;default:true;check:status IN ('true', 'false')"`
 	DiscountRate int    `json:"discount_rate"`
-}
\ No newline at end of file
+}
+
+// CouponRedemption records a coupon used on an order. The redemption is
+// released when the order is canceled.
+type CouponRedemption struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	CouponID   uint       `json:"coupon_id" gorm:"not null;index"`
+	Coupon     Coupon     `json:"-" gorm:"foreignkey:CouponID"`
+	UserID     uint       `json:"user_id" gorm:"not null;index"`
+	OrderID    uint       `json:"order_id" gorm:"not null;uniqueIndex"`
+	Order      Order      `json:"-" gorm:"foreignkey:OrderID;constraint:OnDelete:CASCADE"`
+	Discount   float64    `json:"discount"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ReleasedAt *time.Time `json:"released_at"`
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrForbidden is returned when a user reaches for an order, address, payment
+// or document that belongs to another user.
+var ErrForbidden = errors.New("you do not have access to this resource")
+
+// ErrInvalidSignature is returned when a webhook is not signed with the
+// shared secret.
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// InsufficientStockError is returned when an order asks for more units of a
+// product than are left in inventories.stock.
+type InsufficientStockError struct {
+	ProductID   int
+	ProductName string
+	Requested   int
+	Available   int
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("insufficient stock for %s: requested %d, available %d", e.ProductName, e.Requested, e.Available)
+}
+
+// InvalidTransitionError is returned when an order is asked to move to a
+// status the order state machine does not allow from its current one.
+type InvalidTransitionError struct {
+	Field string
+	From  string
+	To    string
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("%s cannot change from %s to %s", e.Field, e.From, e.To)
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// IdempotencyKey is a client supplied Idempotency-Key together with the
+// response the request it first came with produced. ResponseStatus stays 0
+// while that request is still being handled.
+type IdempotencyKey struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	Key            string    `json:"key" gorm:"column:idempotency_key;not null;uniqueIndex:idx_idempotency_keys_key_user"`
+	UserID         int       `json:"user_id" gorm:"not null;uniqueIndex:idx_idempotency_keys_key_user"`
+	Method         string    `json:"method"`
+	Path           string    `json:"path"`
+	RequestHash    string    `json:"request_hash" gorm:"not null"`
+	ResponseStatus int       `json:"response_status" gorm:"default:0"`
+	ResponseBody   []byte    `json:"-"`
+	ContentType    string    `json:"content_type"`
+	CreatedAt      time.Time `json:"created_at"`
+	ExpiresAt      time.Time `json:"expires_at" gorm:"index"`
+}
@@ -3,17 +3,29 @@ package domain
 type Inventory struct {
 	ID          uint     `json:"id" gorm:"primaryKey"`
 	ProductName string   `json:"product_name" gorm:"not null"`
+	SKU         string   `json:"sku" gorm:"index"`
 	BrandID     uint     `json:"brand_id" gorm:"not null"`
 	Brand       Brand    `json:"brand" gorm:"foreignKey:BrandID;constraint:OnDelete:CASCADE"`
 	CategoryID  uint     `json:"category_id" gorm:"not null"`
 	Category    Category `json:"category" gorm:"foreignKey:CategoryID;constraint:OnDelete:CASCADE"`
 	Stock       int      `json:"stock" gorm:"not null"`
-	Price       float64  `json:"price" gorm:"not null"`
+	// DamagedStock counts returned units that are kept out of sellable stock.
+	DamagedStock int     `json:"damaged_stock" gorm:"default:0"`
+	Price        float64 `json:"price" gorm:"not null"`
+	// HSNCode and GSTRate override the category's when set.
+	HSNCode string   `json:"hsn_code"`
+	GSTRate *float64 `json:"gst_rate"`
+	// WeightGrams is the shipping weight of one unit.
+	WeightGrams int `json:"weight_grams" gorm:"default:0"`
 }
 
 type Category struct {
 	ID           uint   `json:"id" gorm:"primaryKey"`
 	CategoryName string `json:"category_name" gorm:"not null"`
+	// HSNCode and GSTRate apply to the category's products unless a
+	// product sets its own.
+	HSNCode string  `json:"hsn_code"`
+	GSTRate float64 `json:"gst_rate" gorm:"default:0"`
 }
 
 type Brand struct {
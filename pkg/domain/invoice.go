@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Document series, each numbered from 1 in every financial year.
+const (
+	SeriesInvoice    = "INV"
+	SeriesCreditNote = "CN"
+)
+
+// DocumentSequence is the last number given out in a series for a financial
+// year. Numbers are taken inside the transaction that issues the document, so
+// a rolled back document hands its number back and the series has no gaps.
+type DocumentSequence struct {
+	Series        string `gorm:"primaryKey"`
+	FinancialYear string `gorm:"primaryKey"`
+	LastNumber    int    `gorm:"not null"`
+}
+
+// Invoice is issued once per order when it is delivered. The PDF is rendered
+// the first time it is fetched and kept at FilePath. Buyer is copied from the
+// order when the invoice is issued, so later changes to the user or the
+// address do not change it.
+type Invoice struct {
+	ID            uint         `json:"id" gorm:"primaryKey"`
+	OrderID       uint         `json:"order_id" gorm:"uniqueIndex;not null"`
+	Order         Order        `json:"-" gorm:"foreignkey:OrderID"`
+	Number        string       `json:"number" gorm:"uniqueIndex;not null"`
+	FinancialYear string       `json:"financial_year" gorm:"not null"`
+	FilePath      string       `json:"-"`
+	IssuedAt      time.Time    `json:"issued_at"`
+	Buyer         InvoiceBuyer `json:"buyer" gorm:"embedded;embeddedPrefix:buyer_"`
+}
+
+// InvoiceBuyer is who an invoice is billed and shipped to.
+type InvoiceBuyer struct {
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Phone     string `json:"phone"`
+	HouseName string `json:"house_name"`
+	Street    string `json:"street"`
+	City      string `json:"city"`
+	State     string `json:"state"`
+	Pin       string `json:"pin"`
+}
+
+// CreditNote reverses part of an invoice when invoiced items are canceled or
+// returned.
+type CreditNote struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	InvoiceID     uint      `json:"invoice_id" gorm:"index;not null"`
+	Invoice       Invoice   `json:"-" gorm:"foreignkey:InvoiceID"`
+	OrderID       uint      `json:"order_id" gorm:"index;not null"`
+	Number        string    `json:"number" gorm:"uniqueIndex;not null"`
+	FinancialYear string    `json:"financial_year" gorm:"not null"`
+	Amount        float64   `json:"amount"`
+	Reason        string    `json:"reason"`
+	FilePath      string    `json:"-"`
+	IssuedAt      time.Time `json:"issued_at"`
+}
+
+type CreditNoteItem struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	CreditNoteID uint       `json:"credit_note_id" gorm:"index;not null"`
+	CreditNote   CreditNote `json:"-" gorm:"foreignkey:CreditNoteID;constraint:OnDelete:CASCADE"`
+	OrderItemID  uint       `json:"order_item_id"`
+	ProductName  string     `json:"product_name"`
+	Quantity     int        `json:"quantity"`
+	Amount       float64    `json:"amount"`
+}
+
+// FinancialYear is the Indian financial year, April to March, that t falls
+// in, written like "2024-25".
+func FinancialYear(t time.Time) string {
+	year := t.Year()
+	if t.Month() < time.April {
+		year--
+	}
+	return fmt.Sprintf("%d-%02d", year, (year+1)%100)
+}
+
+// DocumentNumber formats the sequence'th document of a series, such as
+// "INV/2024-25/000042".
+func DocumentNumber(series, financialYear string, sequence int) string {
+	return fmt.Sprintf("%s/%s/%06d", series, financialYear, sequence)
+}
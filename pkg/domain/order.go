@@ -1,24 +1,48 @@
 package domain
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Kinds of payment method. ONLINE methods are paid through the payment gateway
+// at checkout and COD methods in cash on delivery.
+const (
+	PaymentKindOnline = "ONLINE"
+	PaymentKindCOD    = "COD"
+	PaymentKindOther  = "OTHER"
+)
 
 type PaymentMethod struct {
 	ID           uint   `gorm:"primarykey"`
 	Payment_Name string `json:"payment_name"`
+	Kind         string `json:"kind"`
 	IsDeleted    bool   `json:"is_deleted" gorm:"default:false"`
 }
 
 type Order struct {
 	gorm.Model
-	UserID          uint          `json:"user_id" gorm:"not null"`
+	UserID          uint          `json:"user_id" gorm:"not null;index"`
 	Users           User          `json:"-" gorm:"foreignkey:UserID"`
 	AddressID       uint          `json:"address_id" gorm:"not null"`
 	Address         Address       `json:"-" gorm:"foreignkey:AddressID"`
-	PaymentMethodID uint          `json:"paymentmethod_id"`
+	PaymentMethodID uint          `json:"paymentmethod_id" gorm:"index"`
 	PaymentMethod   PaymentMethod `json:"-" gorm:"foreignkey:PaymentMethodID"`
 	FinalPrice      float64       `json:"price"`
-	OrderStatus     string        `json:"order_status" gorm:"order_status:4;default:'PENDING';check:order_status IN ('PENDING', 'SHIPPED','DELIVERED','CANCELED','RETURNED')"`
-	PaymentStatus   string        `json:"payment_status" gorm:"payment_status:4;default:'NOT PAID';check:payment_status IN ('PAID', 'NOT PAID','REFUND IN PROGRESS','RETURNED TO WALLET')"`
+	OrderStatus     string        `json:"order_status" gorm:"order_status:4;default:'PENDING';index;check:order_status IN ('PENDING', 'SHIPPED','DELIVERED','CANCELED','RETURNED')"`
+	PaymentStatus   string        `json:"payment_status" gorm:"payment_status:4;default:'NOT PAID';index;check:payment_status IN ('PAID', 'NOT PAID','PARTIALLY REFUNDED','REFUND IN PROGRESS','RETURNED TO WALLET','REFUNDED')"`
+	// Subtotal, Discount, Shipping and Tax are the checkout quote the order
+	// was placed with; FinalPrice is its grand total.
+	Subtotal float64 `json:"subtotal"`
+	Discount float64 `json:"discount"`
+	Shipping float64 `json:"shipping"`
+	Tax      float64 `json:"tax"`
+	// ShippingMethod is STANDARD or EXPRESS.
+	ShippingMethod string `json:"shipping_method" gorm:"default:'STANDARD'"`
+	// PaymentExpiresAt is when an unpaid online payment order is canceled.
+	// It is nil for orders that are paid on delivery.
+	PaymentExpiresAt *time.Time `json:"payment_expires_at" gorm:"index"`
 }
 
 type OrderResponse struct {
@@ -41,6 +65,29 @@ type OrderItem struct {
 	Inventory   Inventory `json:"-" gorm:"foreignkey:InventoryID"`
 	Quantity    int       `json:"quantity"`
 	TotalPrice  float64   `json:"total_price"`
+	// Status follows the order until every unit of the item is canceled
+	// or returned, then becomes CANCELED or RETURNED.
+	Status           string `json:"status"`
+	CanceledQuantity int    `json:"canceled_quantity" gorm:"default:0"`
+	ReturnedQuantity int    `json:"returned_quantity" gorm:"default:0"`
+	// DamagedQuantity is the part of a returned item that came back unsellable.
+	DamagedQuantity int `json:"damaged_quantity" gorm:"default:0"`
+	// RefundAmount is the share of the order's final price, coupon discount
+	// included, that belongs to the canceled and returned units.
+	RefundAmount float64 `json:"refund_amount" gorm:"default:0"`
+
+	// The product as it was sold. Invoices and reports read these instead of
+	// the inventory, which admins may edit after the order was placed.
+	ProductName  string  `json:"product_name"`
+	SKU          string  `json:"sku"`
+	BrandName    string  `json:"brand_name"`
+	CategoryName string  `json:"category_name"`
+	UnitPrice    float64 `json:"unit_price" gorm:"default:0"`
+	// Discount is the item's share of the order's coupon discount.
+	Discount  float64 `json:"discount" gorm:"default:0"`
+	HSNCode   string  `json:"hsn_code"`
+	TaxRate   float64 `json:"tax_rate" gorm:"default:0"`
+	TaxAmount float64 `json:"tax_amount" gorm:"default:0"`
 }
 
 type OrderDetails struct {
@@ -56,6 +103,8 @@ type OrdersDetails struct {
 	Total         float64 `json:"total"`
 }
 
+// OrderItemInv is the copy of the items that orders used to be written with.
+// Order items now carry their own snapshot; the table is kept for old rows.
 type OrderItemInv struct {
 	ID          int     `json:"id" gorm:"id"`
 	ProductName string  `json:"product_name"`
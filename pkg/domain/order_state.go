@@ -0,0 +1,82 @@
+package domain
+
+import "time"
+
+const (
+	OrderStatusPending   = "PENDING"
+	OrderStatusShipped   = "SHIPPED"
+	OrderStatusDelivered = "DELIVERED"
+	OrderStatusCanceled  = "CANCELED"
+	OrderStatusReturned  = "RETURNED"
+)
+
+const (
+	PaymentStatusPaid              = "PAID"
+	PaymentStatusNotPaid           = "NOT PAID"
+	PaymentStatusPartiallyRefunded = "PARTIALLY REFUNDED"
+	PaymentStatusRefundInProgress  = "REFUND IN PROGRESS"
+	PaymentStatusReturnedToWallet  = "RETURNED TO WALLET"
+	PaymentStatusRefunded          = "REFUNDED"
+)
+
+const (
+	ActorUser   = "user"
+	ActorAdmin  = "admin"
+	ActorSystem = "system"
+)
+
+// orderStatusTransitions lists, for every order status, the statuses an order
+// is allowed to move to next. Statuses without an entry are final.
+var orderStatusTransitions = map[string][]string{
+	OrderStatusPending:   {OrderStatusShipped, OrderStatusCanceled},
+	OrderStatusShipped:   {OrderStatusDelivered, OrderStatusCanceled},
+	OrderStatusDelivered: {OrderStatusReturned},
+}
+
+var paymentStatusTransitions = map[string][]string{
+	PaymentStatusNotPaid:           {PaymentStatusPaid},
+	PaymentStatusPaid:              {PaymentStatusPartiallyRefunded, PaymentStatusRefundInProgress, PaymentStatusReturnedToWallet, PaymentStatusRefunded},
+	PaymentStatusPartiallyRefunded: {PaymentStatusRefundInProgress, PaymentStatusReturnedToWallet, PaymentStatusRefunded},
+	PaymentStatusRefundInProgress:  {PaymentStatusPartiallyRefunded, PaymentStatusReturnedToWallet, PaymentStatusRefunded},
+}
+
+func CanChangeOrderStatus(from, to string) bool {
+	return allowed(orderStatusTransitions, from, to)
+}
+
+func CanChangePaymentStatus(from, to string) bool {
+	return allowed(paymentStatusTransitions, from, to)
+}
+
+func allowed(transitions map[string][]string, from, to string) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Actor is whoever caused an order to change state.
+type Actor struct {
+	Role string
+	ID   int
+}
+
+type OrderStatusHistory struct {
+	ID                    uint      `json:"id" gorm:"primaryKey"`
+	OrderID               uint      `json:"order_id" gorm:"not null;index"`
+	Order                 Order     `json:"-" gorm:"foreignkey:OrderID;constraint:OnDelete:CASCADE"`
+	PreviousOrderStatus   string    `json:"previous_order_status"`
+	OrderStatus           string    `json:"order_status"`
+	PreviousPaymentStatus string    `json:"previous_payment_status"`
+	PaymentStatus         string    `json:"payment_status"`
+	ActorRole             string    `json:"actor_role" gorm:"not null"`
+	ActorID               int       `json:"actor_id"`
+	Reason                string    `json:"reason"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+func (OrderStatusHistory) TableName() string {
+	return "order_status_history"
+}
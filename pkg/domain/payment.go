@@ -1,9 +1,34 @@
 package domain
 
-type Payment struct {
-	ID      uint   `json:"id" gorm:"primaryKey;not null"`
-	OrderId int    `json:"order_id"`
-	Order   Order  `json:"-" gorm:"foreignkey:OrderId"`
-	RazerID string `json:"razor_id"`
-	Payment string `json:"payment_id"`
-}
\ No newline at end of file
+import "time"
+
+// Statuses of a payment attempt.
+const (
+	PaymentCreated  = "CREATED"
+	PaymentCaptured = "CAPTURED"
+	PaymentFailed   = "FAILED"
+	// PaymentRefunded is a captured payment that did not pay for the order,
+	// such as one made after the order was canceled, and is given back.
+	PaymentRefunded = "REFUNDED"
+)
+
+// PaymentAttempt is one try at paying for an order through the gateway. Every
+// checkout and every retry makes a gateway order of its own, so an order can
+// have many attempts but only one of them is ever CAPTURED.
+type PaymentAttempt struct {
+	ID             uint   `json:"id" gorm:"primaryKey;not null"`
+	OrderID        uint   `json:"order_id" gorm:"not null;index"`
+	Order          Order  `json:"-" gorm:"foreignkey:OrderID;constraint:OnDelete:CASCADE"`
+	GatewayOrderID string `json:"gateway_order_id" gorm:"not null;uniqueIndex"`
+	// PaymentID is the gateway payment made against the gateway order, empty
+	// until the customer pays.
+	PaymentID string `json:"payment_id" gorm:"index"`
+	// Amount is what the gateway order was made for, in paise.
+	Amount int64 `json:"amount"`
+	// Status is CREATED until the gateway reports the payment captured or
+	// failed.
+	Status        string    `json:"status" gorm:"not null;default:'CREATED'"`
+	FailureReason string    `json:"failure_reason"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
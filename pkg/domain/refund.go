@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+const (
+	RefundInitiated = "INITIATED"
+	RefundSending   = "SENDING"
+	RefundProcessed = "PROCESSED"
+	RefundFailed    = "FAILED"
+)
+
+// Where a refund is paid to. ORIGINAL refunds go back through the payment
+// gateway to the card or account the order was paid with.
+const (
+	RefundToWallet   = "WALLET"
+	RefundToOriginal = "ORIGINAL"
+)
+
+// Refund is money given back for canceled or returned units of an order.
+// Wallet refunds are PROCESSED as soon as they are made. ORIGINAL refunds stay
+// INITIATED until the gateway reports them processed or failed; a failed one
+// is credited to the wallet instead. They are SENDING while a server is asking
+// the gateway for them.
+type Refund struct {
+	ID      uint    `json:"id" gorm:"primaryKey"`
+	OrderID uint    `json:"order_id" gorm:"not null;index"`
+	Order   Order   `json:"-" gorm:"foreignkey:OrderID;constraint:OnDelete:CASCADE"`
+	Method  string  `json:"method" gorm:"not null"`
+	Amount  float64 `json:"amount" gorm:"not null"`
+	// PaymentID is the gateway payment an ORIGINAL refund is made against and
+	// GatewayRefundID the refund the gateway created for it, NULL until then.
+	PaymentID       string    `json:"payment_id"`
+	GatewayRefundID *string   `json:"gateway_refund_id" gorm:"uniqueIndex"`
+	Status          string    `json:"status" gorm:"not null;default:'INITIATED';index"`
+	Attempts        int       `json:"attempts" gorm:"default:0"`
+	FailureReason   string    `json:"failure_reason"`
+	Reason          string    `json:"reason"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	// Unapplied refunds give back a payment that never paid for the order, so
+	// they leave the order's payment status alone.
+	Unapplied bool `json:"unapplied" gorm:"not null;default:false"`
+}
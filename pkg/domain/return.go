@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+const (
+	ReturnStatusRequested       = "REQUESTED"
+	ReturnStatusApproved        = "APPROVED"
+	ReturnStatusRejected        = "REJECTED"
+	ReturnStatusPickupScheduled = "PICKUP SCHEDULED"
+	ReturnStatusReceived        = "RECEIVED"
+	ReturnStatusInspected       = "INSPECTED"
+	ReturnStatusRefunded        = "REFUNDED"
+	ReturnStatusCanceled        = "CANCELED"
+)
+
+// returnStatusTransitions is the path a return request takes from the
+// customer's request to the refund. Statuses without an entry are final.
+var returnStatusTransitions = map[string][]string{
+	ReturnStatusRequested:       {ReturnStatusApproved, ReturnStatusRejected, ReturnStatusCanceled},
+	ReturnStatusApproved:        {ReturnStatusPickupScheduled, ReturnStatusCanceled},
+	ReturnStatusPickupScheduled: {ReturnStatusReceived},
+	ReturnStatusReceived:        {ReturnStatusInspected},
+	ReturnStatusInspected:       {ReturnStatusRefunded},
+}
+
+func CanChangeReturnStatus(from, to string) bool {
+	return allowed(returnStatusTransitions, from, to)
+}
+
+type ReturnRequest struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	OrderID    uint       `json:"order_id" gorm:"not null;index"`
+	Order      Order      `json:"-" gorm:"foreignkey:OrderID;constraint:OnDelete:CASCADE"`
+	UserID     uint       `json:"user_id" gorm:"not null;index"`
+	Users      User       `json:"-" gorm:"foreignkey:UserID"`
+	Status     string     `json:"status" gorm:"not null;default:'REQUESTED'"`
+	Reason     string     `json:"reason" gorm:"not null"`
+	AdminNote  string     `json:"admin_note"`
+	PickupDate *time.Time `json:"pickup_date"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	// RefundTo is where the customer asked for the refund to go, WALLET or
+	// ORIGINAL; empty leaves it to the default.
+	RefundTo string `json:"refund_to"`
+}
+
+type ReturnRequestItem struct {
+	ID              uint          `json:"id" gorm:"primaryKey"`
+	ReturnRequestID uint          `json:"return_request_id" gorm:"not null;index"`
+	ReturnRequest   ReturnRequest `json:"-" gorm:"foreignkey:ReturnRequestID;constraint:OnDelete:CASCADE"`
+	OrderItemID     uint          `json:"order_item_id" gorm:"not null"`
+	OrderItem       OrderItem     `json:"-" gorm:"foreignkey:OrderItemID"`
+	Quantity        int           `json:"quantity" gorm:"not null"`
+	DamagedQuantity int           `json:"damaged_quantity" gorm:"default:0"`
+}
+
+type ReturnRequestImage struct {
+	ID              uint          `json:"id" gorm:"primaryKey"`
+	ReturnRequestID uint          `json:"return_request_id" gorm:"not null;index"`
+	ReturnRequest   ReturnRequest `json:"-" gorm:"foreignkey:ReturnRequestID;constraint:OnDelete:CASCADE"`
+	Image           string        `json:"image"`
+}
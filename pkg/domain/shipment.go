@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+const (
+	ShipmentStatusDispatched     = "DISPATCHED"
+	ShipmentStatusInTransit      = "IN TRANSIT"
+	ShipmentStatusOutForDelivery = "OUT FOR DELIVERY"
+	ShipmentStatusDeliveryFailed = "DELIVERY FAILED"
+	ShipmentStatusDelivered      = "DELIVERED"
+)
+
+// IsShipmentStatus reports whether status is one a courier may report.
+func IsShipmentStatus(status string) bool {
+	switch status {
+	case ShipmentStatusDispatched, ShipmentStatusInTransit, ShipmentStatusOutForDelivery, ShipmentStatusDeliveryFailed, ShipmentStatusDelivered:
+		return true
+	}
+	return false
+}
+
+// Shipment is the parcel an order was handed to a courier in. Status follows
+// the latest tracking event.
+type Shipment struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	OrderID        uint      `json:"order_id" gorm:"not null;uniqueIndex"`
+	Order          Order     `json:"-" gorm:"foreignkey:OrderID;constraint:OnDelete:CASCADE"`
+	Carrier        string    `json:"carrier" gorm:"not null;uniqueIndex:idx_shipments_carrier_tracking"`
+	TrackingNumber string    `json:"tracking_number" gorm:"not null;uniqueIndex:idx_shipments_carrier_tracking"`
+	Packages       int       `json:"packages" gorm:"not null;default:1"`
+	WeightKg       float64   `json:"weight_kg"`
+	Status         string    `json:"status" gorm:"not null"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ShipmentEvent is a single tracking scan reported by the courier. The same
+// scan posted twice is stored once.
+type ShipmentEvent struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	ShipmentID  uint      `json:"shipment_id" gorm:"not null;uniqueIndex:idx_shipment_events_scan"`
+	Shipment    Shipment  `json:"-" gorm:"foreignkey:ShipmentID;constraint:OnDelete:CASCADE"`
+	Status      string    `json:"status" gorm:"not null;uniqueIndex:idx_shipment_events_scan"`
+	Location    string    `json:"location"`
+	Description string    `json:"description"`
+	OccurredAt  time.Time `json:"occurred_at" gorm:"not null;uniqueIndex:idx_shipment_events_scan"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+// Shipping methods a customer can choose at checkout.
+const (
+	ShippingStandard = "STANDARD"
+	ShippingExpress  = "EXPRESS"
+)
+
+// What a shipping rate is looked up by.
+const (
+	RateByWeight = "WEIGHT"
+	RateByValue  = "ORDER_VALUE"
+)
+
+// ShippingZone is an area orders are shipped to at the same rates. Standard
+// shipping is free for orders worth FreeShippingThreshold or more.
+type ShippingZone struct {
+	ID                    uint    `json:"id" gorm:"primaryKey"`
+	Name                  string  `json:"name" gorm:"not null"`
+	FreeShippingThreshold float64 `json:"free_shipping_threshold" gorm:"default:0"`
+}
+
+// ShippingZoneArea puts a state, or a range of pin codes when PinFrom is set,
+// into a zone. A pin code range wins over a state.
+type ShippingZoneArea struct {
+	ID             uint         `json:"id" gorm:"primaryKey"`
+	ShippingZoneID uint         `json:"shipping_zone_id" gorm:"index;not null"`
+	ShippingZone   ShippingZone `json:"-" gorm:"foreignKey:ShippingZoneID;constraint:OnDelete:CASCADE"`
+	State          string       `json:"state"`
+	PinFrom        int          `json:"pin_from" gorm:"default:0"`
+	PinTo          int          `json:"pin_to" gorm:"default:0"`
+}
+
+// ShippingRate charges Charge for orders of a zone whose weight in grams or
+// value in rupees is at least MinValue and, when MaxValue is set, below it.
+type ShippingRate struct {
+	ID             uint         `json:"id" gorm:"primaryKey"`
+	ShippingZoneID uint         `json:"shipping_zone_id" gorm:"index;not null"`
+	ShippingZone   ShippingZone `json:"-" gorm:"foreignKey:ShippingZoneID;constraint:OnDelete:CASCADE"`
+	Method         string       `json:"method" gorm:"not null"`
+	Basis          string       `json:"basis" gorm:"not null"`
+	MinValue       float64      `json:"min_value" gorm:"default:0"`
+	MaxValue       float64      `json:"max_value" gorm:"default:0"`
+	Charge         float64      `json:"charge" gorm:"not null"`
+}
+
+// PinCode records whether orders can be delivered to a pin code, whether
+// they may be paid for on delivery there and how many days delivery takes.
+// Pin codes missing from the table are not serviceable.
+type PinCode struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	Pin          string    `json:"pin" gorm:"uniqueIndex;size:6;not null"`
+	Serviceable  bool      `json:"serviceable" gorm:"default:false"`
+	CODAllowed   bool      `json:"cod_allowed" gorm:"default:false"`
+	DeliveryDays int       `json:"delivery_days" gorm:"default:0"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"unicode"
+)
+
+// GST components. An order shipped within the seller's state pays CGST and
+// SGST, half the rate each; one shipped to another state pays IGST.
+const (
+	TaxCGST = "CGST"
+	TaxSGST = "SGST"
+	TaxIGST = "IGST"
+)
+
+// OrderItemTax is one GST component charged on an order item.
+type OrderItemTax struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	OrderItemID uint      `json:"order_item_id" gorm:"index;not null"`
+	OrderItem   OrderItem `json:"-" gorm:"foreignKey:OrderItemID;constraint:OnDelete:CASCADE"`
+	Component   string    `json:"component" gorm:"not null"`
+	Rate        float64   `json:"rate" gorm:"not null"`
+	Amount      float64   `json:"amount" gorm:"not null"`
+}
+
+// ValidateTaxClass checks an HSN code and GST rate given for a product or
+// category. The HSN code may be left empty.
+func ValidateTaxClass(hsnCode string, gstRate float64) error {
+	if hsnCode != "" {
+		if len(hsnCode) != 4 && len(hsnCode) != 6 && len(hsnCode) != 8 {
+			return errors.New("hsn code must have 4, 6 or 8 digits")
+		}
+		for _, r := range hsnCode {
+			if !unicode.IsDigit(r) {
+				return errors.New("hsn code must have 4, 6 or 8 digits")
+			}
+		}
+	}
+	if gstRate < 0 || gstRate > 100 {
+		return errors.New("gst rate must be between 0 and 100")
+	}
+
+	return nil
+}
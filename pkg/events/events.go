@@ -0,0 +1,57 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	OrderPlaced = "order.placed"
+)
+
+type Event struct {
+	Name    string
+	OrderID int
+	UserID  int
+	At      time.Time
+}
+
+type Handler func(Event)
+
+type Publisher interface {
+	Publish(event Event)
+	Subscribe(name string, handler Handler)
+}
+
+// Bus is an in-process Publisher. Handlers run synchronously in the order
+// they were subscribed.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewBus() Publisher {
+	return &Bus{
+		handlers: make(map[string][]Handler),
+	}
+}
+
+func (b *Bus) Subscribe(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+func (b *Bus) Publish(event Event) {
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+
+	b.mu.RLock()
+	handlers := b.handlers[event.Name]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
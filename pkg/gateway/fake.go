@@ -0,0 +1,175 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Modes of the fake gateway.
+const (
+	FakeSuccess = "success"
+	FakeFailure = "failure"
+	FakeDelayed = "delayed"
+)
+
+const (
+	defaultCaptureDelay = time.Minute
+	fakeKeySecret       = "fake_key_secret"
+)
+
+// Fake is an in-process gateway for development and tests. Pay stands in for
+// the customer completing the checkout. In success mode the payment is
+// captured at once and in failure mode it fails. In delayed mode it stays
+// authorized until it is captured or the capture delay has passed.
+type Fake struct {
+	mu       sync.Mutex
+	mode     string
+	delay    time.Duration
+	now      func() time.Time
+	seq      int
+	orders   map[string]Order
+	payments map[string]*fakePayment
+}
+
+type fakePayment struct {
+	Payment
+	refunded     int64
+	authorizedAt time.Time
+}
+
+func NewFake(mode string, delay time.Duration) *Fake {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode != FakeFailure && mode != FakeDelayed {
+		mode = FakeSuccess
+	}
+
+	return &Fake{
+		mode:     mode,
+		delay:    delay,
+		now:      time.Now,
+		orders:   map[string]Order{},
+		payments: map[string]*fakePayment{},
+	}
+}
+
+func (f *Fake) CreateOrder(amount int64, receipt string) (Order, error) {
+	if amount <= 0 {
+		return Order{}, errors.New("amount must be positive")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	order := Order{ID: f.nextID("order"), Amount: amount, Currency: "INR"}
+	f.orders[order.ID] = order
+	return order, nil
+}
+
+// Pay makes a payment for the whole order the way the current mode says and
+// returns it with the signature the checkout would hand back.
+func (f *Fake) Pay(orderID string) (Payment, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	order, ok := f.orders[orderID]
+	if !ok {
+		return Payment{}, "", errors.New("order does not exist")
+	}
+
+	payment := &fakePayment{
+		Payment:      Payment{ID: f.nextID("pay"), OrderID: order.ID, Amount: order.Amount},
+		authorizedAt: f.now(),
+	}
+	switch f.mode {
+	case FakeFailure:
+		payment.Status = StatusFailed
+		payment.FailureReason = "payment declined by the fake gateway"
+	case FakeDelayed:
+		payment.Status = StatusAuthorized
+	default:
+		payment.Status = StatusCaptured
+	}
+	f.payments[payment.ID] = payment
+
+	return payment.Payment, sign(fakeKeySecret, order.ID, payment.ID), nil
+}
+
+func (f *Fake) Capture(paymentID string, amount int64) (Payment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	payment, err := f.payment(paymentID)
+	if err != nil {
+		return Payment{}, err
+	}
+	switch payment.Status {
+	case StatusAuthorized:
+	case StatusCaptured:
+		return payment.Payment, nil
+	default:
+		return Payment{}, errors.New("payment cannot be captured")
+	}
+	if amount != payment.Amount {
+		return Payment{}, errors.New("capture amount does not match the payment")
+	}
+
+	payment.Status = StatusCaptured
+	return payment.Payment, nil
+}
+
+func (f *Fake) Refund(paymentID string, amount int64, receipt string) (Refund, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	payment, err := f.payment(paymentID)
+	if err != nil {
+		return Refund{}, err
+	}
+	if payment.Status != StatusCaptured && payment.Status != StatusRefunded {
+		return Refund{}, errors.New("only captured payments can be refunded")
+	}
+	if amount <= 0 || payment.refunded+amount > payment.Amount {
+		return Refund{}, errors.New("refund exceeds what is left of the payment")
+	}
+
+	payment.refunded += amount
+	if payment.refunded == payment.Amount {
+		payment.Status = StatusRefunded
+	}
+	return Refund{ID: f.nextID("rfnd"), PaymentID: paymentID, Amount: amount, Status: RefundProcessed}, nil
+}
+
+func (f *Fake) PaymentStatus(paymentID string) (Payment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	payment, err := f.payment(paymentID)
+	if err != nil {
+		return Payment{}, err
+	}
+	return payment.Payment, nil
+}
+
+func (f *Fake) VerifySignature(orderID, paymentID, signature string) bool {
+	return verify(fakeKeySecret, orderID, paymentID, signature)
+}
+
+// payment finds a payment, capturing delayed payments whose delay has passed.
+func (f *Fake) payment(paymentID string) (*fakePayment, error) {
+	payment, ok := f.payments[paymentID]
+	if !ok {
+		return nil, errors.New("payment does not exist")
+	}
+	if payment.Status == StatusAuthorized && !f.now().Before(payment.authorizedAt.Add(f.delay)) {
+		payment.Status = StatusCaptured
+	}
+	return payment, nil
+}
+
+func (f *Fake) nextID(prefix string) string {
+	f.seq++
+	return fmt.Sprintf("%s_fake%06d", prefix, f.seq)
+}
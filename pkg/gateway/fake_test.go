@@ -0,0 +1,81 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeModes(t *testing.T) {
+	tests := []struct {
+		mode       string
+		wantStatus string
+	}{
+		{mode: FakeSuccess, wantStatus: StatusCaptured},
+		{mode: FakeFailure, wantStatus: StatusFailed},
+		{mode: FakeDelayed, wantStatus: StatusAuthorized},
+		{mode: "", wantStatus: StatusCaptured},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.mode, func(t *testing.T) {
+			fake := NewFake(tc.mode, time.Hour)
+
+			order, err := fake.CreateOrder(49950, "order_5")
+			assert.NoError(t, err)
+			assert.Equal(t, int64(49950), order.Amount)
+
+			payment, signature, err := fake.Pay(order.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, payment.Status)
+			assert.Equal(t, order.ID, payment.OrderID)
+			assert.True(t, fake.VerifySignature(order.ID, payment.ID, signature))
+			assert.False(t, fake.VerifySignature(order.ID, "pay_other", signature))
+			assert.False(t, fake.VerifySignature(order.ID, payment.ID, "not-hex"))
+		})
+	}
+}
+
+func TestFakeDelayedCapture(t *testing.T) {
+	fake := NewFake(FakeDelayed, time.Minute)
+	now := time.Now()
+	fake.now = func() time.Time { return now }
+
+	order, _ := fake.CreateOrder(1000, "order_1")
+	first, _, _ := fake.Pay(order.ID)
+	second, _, _ := fake.Pay(order.ID)
+
+	_, err := fake.Refund(first.ID, 1000, "refund_1")
+	assert.EqualError(t, err, "only captured payments can be refunded")
+	_, err = fake.Capture(first.ID, 500)
+	assert.EqualError(t, err, "capture amount does not match the payment")
+
+	captured, err := fake.Capture(first.ID, 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusCaptured, captured.Status)
+
+	// left alone, the payment is captured once the delay has passed
+	now = now.Add(time.Minute)
+	status, err := fake.PaymentStatus(second.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusCaptured, status.Status)
+}
+
+func TestFakeRefund(t *testing.T) {
+	fake := NewFake(FakeSuccess, 0)
+	order, _ := fake.CreateOrder(1000, "order_1")
+	payment, _, _ := fake.Pay(order.ID)
+
+	refund, err := fake.Refund(payment.ID, 400, "refund_1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(400), refund.Amount)
+
+	_, err = fake.Refund(payment.ID, 700, "refund_2")
+	assert.EqualError(t, err, "refund exceeds what is left of the payment")
+
+	_, err = fake.Refund(payment.ID, 600, "refund_2")
+	assert.NoError(t, err)
+	status, _ := fake.PaymentStatus(payment.ID)
+	assert.Equal(t, StatusRefunded, status.Status)
+}
@@ -0,0 +1,102 @@
+// Package gateway talks to the payment provider. The razorpay gateway is used
+// in production; the fake gateway runs in process for development and tests.
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/ahdaan98/pkg/config"
+)
+
+// Statuses of a payment at the gateway.
+const (
+	StatusCreated    = "created"
+	StatusAuthorized = "authorized"
+	StatusCaptured   = "captured"
+	StatusFailed     = "failed"
+	StatusRefunded   = "refunded"
+)
+
+// Statuses of a refund at the gateway.
+const (
+	RefundPending   = "pending"
+	RefundProcessed = "processed"
+	RefundFailed    = "failed"
+)
+
+const (
+	ProviderRazorpay = "razorpay"
+	ProviderFake     = "fake"
+)
+
+// PaymentGateway creates payment orders with the provider and follows the
+// payments made against them. Amounts are in paise.
+type PaymentGateway interface {
+	CreateOrder(amount int64, receipt string) (Order, error)
+	Capture(paymentID string, amount int64) (Payment, error)
+	// Refund gives back part or all of a captured payment. The gateway
+	// reports the receipt back with the refund's webhook events.
+	Refund(paymentID string, amount int64, receipt string) (Refund, error)
+	PaymentStatus(paymentID string) (Payment, error)
+	// VerifySignature checks the signature the checkout hands back for a
+	// payment made against an order.
+	VerifySignature(orderID, paymentID, signature string) bool
+}
+
+type Order struct {
+	ID       string
+	Amount   int64
+	Currency string
+}
+
+type Payment struct {
+	ID            string
+	OrderID       string
+	Amount        int64
+	Status        string
+	FailureReason string
+}
+
+type Refund struct {
+	ID        string
+	PaymentID string
+	Amount    int64
+	Status    string
+}
+
+// New picks the gateway named by PAYMENT_GATEWAY, razorpay by default.
+func New(cfg config.Config) PaymentGateway {
+	if UsesFake(cfg) {
+		return NewFake(cfg.FAKE_GATEWAY_MODE, defaultCaptureDelay)
+	}
+	return NewRazorpay(cfg.KEY_ID_FOR_PAY, cfg.SECRET_KEY_FOR_PAY)
+}
+
+// UsesFake reports whether PAYMENT_GATEWAY selects the fake gateway.
+func UsesFake(cfg config.Config) bool {
+	return strings.EqualFold(cfg.PAYMENT_GATEWAY, ProviderFake)
+}
+
+// sign is the hex HMAC-SHA256 of "order_id|payment_id", the signature
+// razorpay's checkout returns.
+func sign(secret, orderID, paymentID string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(orderID + "|" + paymentID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func verify(secret, orderID, paymentID, signature string) bool {
+	if secret == "" {
+		return false
+	}
+
+	given, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	want, _ := hex.DecodeString(sign(secret, orderID, paymentID))
+	return hmac.Equal(given, want)
+}
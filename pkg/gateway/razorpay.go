@@ -0,0 +1,84 @@
+package gateway
+
+import (
+	"errors"
+
+	"github.com/razorpay/razorpay-go"
+)
+
+type razorpayGateway struct {
+	client    *razorpay.Client
+	keySecret string
+}
+
+func NewRazorpay(keyID, keySecret string) PaymentGateway {
+	return &razorpayGateway{
+		client:    razorpay.NewClient(keyID, keySecret),
+		keySecret: keySecret,
+	}
+}
+
+func (r *razorpayGateway) CreateOrder(amount int64, receipt string) (Order, error) {
+	body, err := r.client.Order.Create(map[string]interface{}{
+		"amount":   amount,
+		"currency": "INR",
+		"receipt":  receipt,
+	}, nil)
+	if err != nil {
+		return Order{}, err
+	}
+
+	id, _ := body["id"].(string)
+	if id == "" {
+		return Order{}, errors.New("razorpay did not return an order id")
+	}
+
+	return Order{ID: id, Amount: amount, Currency: "INR"}, nil
+}
+
+func (r *razorpayGateway) Capture(paymentID string, amount int64) (Payment, error) {
+	body, err := r.client.Payment.Capture(paymentID, int(amount), map[string]interface{}{"currency": "INR"}, nil)
+	if err != nil {
+		return Payment{}, err
+	}
+
+	return razorpayPayment(body), nil
+}
+
+func (r *razorpayGateway) Refund(paymentID string, amount int64, receipt string) (Refund, error) {
+	body, err := r.client.Payment.Refund(paymentID, int(amount), map[string]interface{}{"receipt": receipt}, nil)
+	if err != nil {
+		return Refund{}, err
+	}
+
+	id, _ := body["id"].(string)
+	status, _ := body["status"].(string)
+	return Refund{ID: id, PaymentID: paymentID, Amount: amount, Status: status}, nil
+}
+
+func (r *razorpayGateway) PaymentStatus(paymentID string) (Payment, error) {
+	body, err := r.client.Payment.Fetch(paymentID, nil, nil)
+	if err != nil {
+		return Payment{}, err
+	}
+
+	return razorpayPayment(body), nil
+}
+
+func (r *razorpayGateway) VerifySignature(orderID, paymentID, signature string) bool {
+	return verify(r.keySecret, orderID, paymentID, signature)
+}
+
+// razorpayPayment reads a payment entity; razorpay returns numbers as JSON
+// numbers, which decode to float64.
+func razorpayPayment(body map[string]interface{}) Payment {
+	payment := Payment{}
+	payment.ID, _ = body["id"].(string)
+	payment.OrderID, _ = body["order_id"].(string)
+	payment.Status, _ = body["status"].(string)
+	payment.FailureReason, _ = body["error_description"].(string)
+	if amount, ok := body["amount"].(float64); ok {
+		payment.Amount = int64(amount)
+	}
+	return payment
+}
@@ -0,0 +1,79 @@
+package pricing
+
+import (
+	"strings"
+
+	"github.com/ahdaan98/pkg/domain"
+	"github.com/ahdaan98/pkg/utils/models"
+)
+
+// TaxLine is one GST component charged on a line.
+type TaxLine struct {
+	Component string  `json:"component"`
+	Rate      float64 `json:"rate"`
+	Amount    float64 `json:"amount"`
+}
+
+// TaxClasses looks up the HSN code and GST rate of products.
+type TaxClasses interface {
+	GetTaxClasses(inventoryIDs []int) ([]models.TaxClass, error)
+}
+
+// GST charges GST on what is left of each line after its discounts. Orders
+// shipped within sellerState pay CGST and SGST at half the rate each, orders
+// shipped anywhere else pay IGST. Nothing is charged until the address is
+// known, as GST depends on where the order goes.
+func GST(sellerState string, classes TaxClasses) Rule {
+	return func(q *Quote, in Input) error {
+		if in.Address == nil || len(q.Lines) == 0 {
+			return nil
+		}
+
+		ids := make([]int, 0, len(q.Lines))
+		for _, l := range q.Lines {
+			ids = append(ids, l.InventoryID)
+		}
+		found, err := classes.GetTaxClasses(ids)
+		if err != nil {
+			return err
+		}
+		byID := make(map[int]models.TaxClass, len(found))
+		for _, c := range found {
+			byID[c.InventoryID] = c
+		}
+
+		intraState := sameState(in.Address.State, sellerState)
+		for i := range q.Lines {
+			l := &q.Lines[i]
+			class := byID[l.InventoryID]
+			l.HSNCode = class.HSNCode
+			l.TaxRate = class.GSTRate
+			l.Taxes = nil
+			l.Tax = 0
+			if class.GSTRate <= 0 || l.Net() <= 0 {
+				continue
+			}
+
+			if intraState {
+				half := class.GSTRate / 2
+				l.Taxes = []TaxLine{
+					{Component: domain.TaxCGST, Rate: half, Amount: round(l.Net() * half / 100)},
+					{Component: domain.TaxSGST, Rate: half, Amount: round(l.Net() * half / 100)},
+				}
+			} else {
+				l.Taxes = []TaxLine{
+					{Component: domain.TaxIGST, Rate: class.GSTRate, Amount: round(l.Net() * class.GSTRate / 100)},
+				}
+			}
+			for _, t := range l.Taxes {
+				l.Tax = round(l.Tax + t.Amount)
+			}
+		}
+
+		return nil
+	}
+}
+
+func sameState(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
@@ -0,0 +1,73 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/ahdaan98/pkg/domain"
+	"github.com/ahdaan98/pkg/utils/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type taxClasses []models.TaxClass
+
+func (t taxClasses) GetTaxClasses(inventoryIDs []int) ([]models.TaxClass, error) {
+	return t, nil
+}
+
+func TestGST(t *testing.T) {
+	items := []Item{
+		{InventoryID: 1, ProductName: "Shirt", Quantity: 2, UnitPrice: 500},
+		{InventoryID: 2, ProductName: "Book", Quantity: 1, UnitPrice: 200},
+	}
+	classes := taxClasses{
+		{InventoryID: 1, HSNCode: "6205", GSTRate: 12},
+		{InventoryID: 2, HSNCode: "4901", GSTRate: 0},
+	}
+
+	tests := []struct {
+		name      string
+		in        Input
+		wantTaxes [][]TaxLine
+		wantQuote Quote
+	}{
+		{
+			name:      "no tax before the address is known",
+			in:        Input{Items: items},
+			wantTaxes: [][]TaxLine{nil, nil},
+			wantQuote: Quote{Subtotal: 1200, GrandTotal: 1200},
+		},
+		{
+			name: "cgst and sgst within the seller's state",
+			in:   Input{Items: items, Address: &domain.Address{State: " kerala "}},
+			wantTaxes: [][]TaxLine{
+				{{Component: domain.TaxCGST, Rate: 6, Amount: 60}, {Component: domain.TaxSGST, Rate: 6, Amount: 60}},
+				nil,
+			},
+			wantQuote: Quote{Subtotal: 1200, Tax: 120, GrandTotal: 1320},
+		},
+		{
+			name: "igst to another state on the discounted price",
+			in:   Input{Items: items, Address: &domain.Address{State: "Karnataka"}, Coupon: &Coupon{ID: 1, Amount: 120}},
+			wantTaxes: [][]TaxLine{
+				{{Component: domain.TaxIGST, Rate: 12, Amount: 108}},
+				nil,
+			},
+			wantQuote: Quote{Subtotal: 1200, OrderDiscount: 120, Tax: 108, GrandTotal: 1188},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			quote, err := NewCalculator(CouponDiscount, GST("Kerala", classes)).Quote(tc.in)
+			assert.NoError(t, err)
+
+			for i, l := range quote.Lines {
+				assert.Equal(t, tc.wantTaxes[i], l.Taxes)
+			}
+			assert.Equal(t, tc.wantQuote.Subtotal, quote.Subtotal)
+			assert.Equal(t, tc.wantQuote.OrderDiscount, quote.OrderDiscount)
+			assert.Equal(t, tc.wantQuote.Tax, quote.Tax)
+			assert.Equal(t, tc.wantQuote.GrandTotal, quote.GrandTotal)
+		})
+	}
+}
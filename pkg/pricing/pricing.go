@@ -0,0 +1,241 @@
+// Package pricing works out what an order costs. A Calculator prices the
+// cart lines and then runs its rules over the quote, each of which may add
+// discounts, shipping or tax. The cart, the checkout quote and the placed
+// order are all priced by the same calculator, so they always agree.
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ahdaan98/pkg/config"
+	"github.com/ahdaan98/pkg/domain"
+)
+
+// Item is a product in the cart being priced.
+type Item struct {
+	InventoryID int
+	ProductName string
+	CategoryID  uint
+	BrandID     uint
+	Quantity    int
+	UnitPrice   float64
+	WeightGrams int
+}
+
+// Coupon is a coupon the customer applied. Amount is taken off the order.
+type Coupon struct {
+	ID     int
+	Amount float64
+}
+
+// Input is everything a quote is worked out from. Address and Coupon are nil
+// when they are not known yet, as for the cart. ShippingMethod defaults to
+// standard shipping.
+type Input struct {
+	UserID         int
+	Items          []Item
+	Address        *domain.Address
+	Coupon         *Coupon
+	ShippingMethod string
+}
+
+// Adjustment is a discount or charge with the reason for it.
+type Adjustment struct {
+	Code        string  `json:"code"`
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+}
+
+// Line is a priced item. Its WeightGrams is the weight of all its units.
+type Line struct {
+	InventoryID   int          `json:"inventory_id"`
+	ProductName   string       `json:"product_name"`
+	CategoryID    uint         `json:"category_id"`
+	BrandID       uint         `json:"brand_id"`
+	Quantity      int          `json:"quantity"`
+	UnitPrice     float64      `json:"unit_price"`
+	WeightGrams   int          `json:"weight_grams"`
+	Subtotal      float64      `json:"subtotal"`
+	LineDiscounts []Adjustment `json:"line_discounts"`
+	LineDiscount  float64      `json:"line_discount"`
+	// OrderDiscount is the line's share of the order discounts.
+	OrderDiscount float64   `json:"order_discount"`
+	HSNCode       string    `json:"hsn_code"`
+	TaxRate       float64   `json:"tax_rate"`
+	Taxes         []TaxLine `json:"taxes"`
+	Tax           float64   `json:"tax"`
+	Total         float64   `json:"total"`
+}
+
+// Net is what the line costs after every discount and before tax.
+func (l Line) Net() float64 {
+	return round(l.Subtotal - l.LineDiscount - l.OrderDiscount)
+}
+
+// Discount is everything taken off the line.
+func (l Line) Discount() float64 {
+	return round(l.LineDiscount + l.OrderDiscount)
+}
+
+type Quote struct {
+	Lines          []Line       `json:"lines"`
+	Subtotal       float64      `json:"subtotal"`
+	LineDiscount   float64      `json:"line_discount"`
+	OrderDiscounts []Adjustment `json:"order_discounts"`
+	OrderDiscount  float64      `json:"order_discount"`
+	ShippingMethod string       `json:"shipping_method,omitempty"`
+	Shipping       float64      `json:"shipping"`
+	Tax            float64      `json:"tax"`
+	GrandTotal     float64      `json:"grand_total"`
+}
+
+// Discount is everything taken off the order.
+func (q Quote) Discount() float64 {
+	return round(q.LineDiscount + q.OrderDiscount)
+}
+
+// AddLineDiscount takes a discount off one line. It never takes off more than
+// is left of the line and returns the amount it took.
+func (q *Quote) AddLineDiscount(line int, discount Adjustment) float64 {
+	l := &q.Lines[line]
+	discount.Amount = math.Min(round(discount.Amount), l.Net())
+	if discount.Amount <= 0 {
+		return 0
+	}
+
+	l.LineDiscounts = append(l.LineDiscounts, discount)
+	l.LineDiscount = round(l.LineDiscount + discount.Amount)
+	return discount.Amount
+}
+
+// AddOrderDiscount takes a discount off the whole order and spreads it over
+// the lines in proportion to what is left of them, so that tax is worked out
+// on what the customer actually pays. It never takes off more than is left of
+// the order and returns the amount it took.
+func (q *Quote) AddOrderDiscount(discount Adjustment) float64 {
+	var net float64
+	last := -1
+	for i, l := range q.Lines {
+		if l.Net() > 0 {
+			net += l.Net()
+			last = i
+		}
+	}
+
+	discount.Amount = math.Min(round(discount.Amount), round(net))
+	if discount.Amount <= 0 {
+		return 0
+	}
+
+	// the last line absorbs the rounding difference so the shares add up
+	// to the discount exactly
+	var spread float64
+	shares := make([]float64, len(q.Lines))
+	for i, l := range q.Lines {
+		if l.Net() <= 0 {
+			continue
+		}
+		if i == last {
+			shares[i] = round(discount.Amount - spread)
+		} else {
+			shares[i] = round(discount.Amount * l.Net() / net)
+			spread += shares[i]
+		}
+	}
+	for i := range q.Lines {
+		q.Lines[i].OrderDiscount = round(q.Lines[i].OrderDiscount + shares[i])
+	}
+
+	q.OrderDiscounts = append(q.OrderDiscounts, discount)
+	q.OrderDiscount = round(q.OrderDiscount + discount.Amount)
+	return discount.Amount
+}
+
+// Rule adds to a quote. Rules run in order once the lines are priced, so each
+// rule sees the discounts and charges added by the rules before it.
+type Rule func(q *Quote, in Input) error
+
+type Calculator struct {
+	rules []Rule
+}
+
+func NewCalculator(rules ...Rule) *Calculator {
+	return &Calculator{
+		rules: rules,
+	}
+}
+
+// NewCheckoutCalculator is the calculator orders are priced with. Shipping
+// and tax come after the discounts so they are worked out on the discounted
+// price.
+func NewCheckoutCalculator(cfg config.Config, classes TaxClasses, zones ShippingZones) *Calculator {
+	return NewCalculator(
+		CouponDiscount,
+		Shipping(zones),
+		GST(cfg.SELLER_STATE, classes),
+	)
+}
+
+// Quote prices the items and applies the rules.
+func (c *Calculator) Quote(in Input) (Quote, error) {
+	var q Quote
+	for _, item := range in.Items {
+		if item.Quantity <= 0 || item.UnitPrice < 0 {
+			return Quote{}, errors.New("cart has an item with an invalid quantity or price")
+		}
+		q.Lines = append(q.Lines, Line{
+			InventoryID: item.InventoryID,
+			ProductName: item.ProductName,
+			CategoryID:  item.CategoryID,
+			BrandID:     item.BrandID,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			WeightGrams: item.WeightGrams * item.Quantity,
+			Subtotal:    round(item.UnitPrice * float64(item.Quantity)),
+		})
+	}
+
+	for _, rule := range c.rules {
+		if err := rule(&q, in); err != nil {
+			return Quote{}, err
+		}
+	}
+
+	q.Subtotal, q.LineDiscount, q.Tax = 0, 0, 0
+	var lines float64
+	for i := range q.Lines {
+		l := &q.Lines[i]
+		l.Total = round(l.Net() + l.Tax)
+		q.Subtotal += l.Subtotal
+		q.LineDiscount += l.LineDiscount
+		q.Tax += l.Tax
+		lines += l.Total
+	}
+	q.Subtotal = round(q.Subtotal)
+	q.LineDiscount = round(q.LineDiscount)
+	q.Tax = round(q.Tax)
+	q.Shipping = round(q.Shipping)
+	q.GrandTotal = round(lines + q.Shipping)
+
+	return q, nil
+}
+
+// CouponCode marks the order discount that comes from a coupon.
+const CouponCode = "COUPON"
+
+// CouponDiscount takes the applied coupon off the order.
+func CouponDiscount(q *Quote, in Input) error {
+	if in.Coupon == nil {
+		return nil
+	}
+
+	q.AddOrderDiscount(Adjustment{Code: CouponCode, Description: fmt.Sprintf("coupon %d", in.Coupon.ID), Amount: in.Coupon.Amount})
+	return nil
+}
+
+// round rounds an amount to paise.
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,108 @@
+package pricing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuote(t *testing.T) {
+	items := []Item{
+		{InventoryID: 1, ProductName: "Shirt", Quantity: 3, UnitPrice: 199.99},
+		{InventoryID: 2, ProductName: "Cap", Quantity: 1, UnitPrice: 100},
+	}
+
+	tests := []struct {
+		name      string
+		rules     []Rule
+		in        Input
+		wantLines []Line
+		wantQuote Quote
+		wantErr   error
+	}{
+		{
+			name: "lines are priced in paise",
+			in:   Input{Items: items},
+			wantLines: []Line{
+				{InventoryID: 1, ProductName: "Shirt", Quantity: 3, UnitPrice: 199.99, Subtotal: 599.97, Total: 599.97},
+				{InventoryID: 2, ProductName: "Cap", Quantity: 1, UnitPrice: 100, Subtotal: 100, Total: 100},
+			},
+			wantQuote: Quote{Subtotal: 699.97, GrandTotal: 699.97},
+		},
+		{
+			name:  "coupon is spread over the lines by value",
+			rules: []Rule{CouponDiscount},
+			in:    Input{Items: items, Coupon: &Coupon{ID: 4, Amount: 100}},
+			wantLines: []Line{
+				{InventoryID: 1, ProductName: "Shirt", Quantity: 3, UnitPrice: 199.99, Subtotal: 599.97, OrderDiscount: 85.71, Total: 514.26},
+				{InventoryID: 2, ProductName: "Cap", Quantity: 1, UnitPrice: 100, Subtotal: 100, OrderDiscount: 14.29, Total: 85.71},
+			},
+			wantQuote: Quote{
+				Subtotal:       699.97,
+				OrderDiscounts: []Adjustment{{Code: CouponCode, Description: "coupon 4", Amount: 100}},
+				OrderDiscount:  100,
+				GrandTotal:     599.97,
+			},
+		},
+		{
+			name:  "coupon never takes the order below zero",
+			rules: []Rule{CouponDiscount},
+			in:    Input{Items: items[1:], Coupon: &Coupon{ID: 4, Amount: 500}},
+			wantLines: []Line{
+				{InventoryID: 2, ProductName: "Cap", Quantity: 1, UnitPrice: 100, Subtotal: 100, OrderDiscount: 100, Total: 0},
+			},
+			wantQuote: Quote{
+				Subtotal:       100,
+				OrderDiscounts: []Adjustment{{Code: CouponCode, Description: "coupon 4", Amount: 100}},
+				OrderDiscount:  100,
+				GrandTotal:     0,
+			},
+		},
+		{
+			name: "rules see the discounts before them",
+			rules: []Rule{
+				func(q *Quote, in Input) error {
+					q.AddLineDiscount(0, Adjustment{Code: "SALE", Amount: 99.97})
+					return nil
+				},
+				CouponDiscount,
+				func(q *Quote, in Input) error {
+					q.Shipping = 40
+					return nil
+				},
+			},
+			in: Input{Items: items, Coupon: &Coupon{ID: 4, Amount: 60}},
+			wantLines: []Line{
+				{InventoryID: 1, ProductName: "Shirt", Quantity: 3, UnitPrice: 199.99, Subtotal: 599.97, LineDiscounts: []Adjustment{{Code: "SALE", Amount: 99.97}}, LineDiscount: 99.97, OrderDiscount: 50, Total: 450},
+				{InventoryID: 2, ProductName: "Cap", Quantity: 1, UnitPrice: 100, Subtotal: 100, OrderDiscount: 10, Total: 90},
+			},
+			wantQuote: Quote{
+				Subtotal:       699.97,
+				LineDiscount:   99.97,
+				OrderDiscounts: []Adjustment{{Code: CouponCode, Description: "coupon 4", Amount: 60}},
+				OrderDiscount:  60,
+				Shipping:       40,
+				GrandTotal:     580,
+			},
+		},
+		{
+			name:    "invalid quantity",
+			in:      Input{Items: []Item{{InventoryID: 1, Quantity: 0, UnitPrice: 10}}},
+			wantErr: errors.New("cart has an item with an invalid quantity or price"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote, err := NewCalculator(tt.rules...).Quote(tt.in)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr != nil {
+				return
+			}
+
+			tt.wantQuote.Lines = tt.wantLines
+			assert.Equal(t, tt.wantQuote, quote)
+		})
+	}
+}
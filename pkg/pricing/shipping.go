@@ -0,0 +1,91 @@
+package pricing
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/ahdaan98/pkg/domain"
+	"github.com/ahdaan98/pkg/utils/models"
+)
+
+// ShippingZones finds the zone an address is in.
+type ShippingZones interface {
+	// FindShippingZone returns the zone covering a pin code, or else the
+	// state. The zone's ID is 0 when none covers the address.
+	FindShippingZone(state string, pin int) (models.ShippingZone, error)
+}
+
+// Shipping charges for delivering the order to its address by the chosen
+// method, from the rate table of the address's zone. The order's weight or
+// its value after discounts picks the rate, and standard shipping is free
+// once the value reaches the zone's threshold. Addresses outside every zone
+// are not charged, and nothing is charged until the address is known.
+func Shipping(zones ShippingZones) Rule {
+	return func(q *Quote, in Input) error {
+		if in.Address == nil || len(q.Lines) == 0 {
+			return nil
+		}
+
+		method := strings.ToUpper(strings.TrimSpace(in.ShippingMethod))
+		if method == "" {
+			method = domain.ShippingStandard
+		}
+		if method != domain.ShippingStandard && method != domain.ShippingExpress {
+			return errors.New("shipping method must be STANDARD or EXPRESS")
+		}
+		q.ShippingMethod = method
+
+		pin, _ := strconv.Atoi(strings.TrimSpace(in.Address.Pin))
+		zone, err := zones.FindShippingZone(in.Address.State, pin)
+		if err != nil {
+			return err
+		}
+		if zone.ID == 0 {
+			return nil
+		}
+
+		var value float64
+		var weight int
+		for _, l := range q.Lines {
+			value += l.Net()
+			weight += l.WeightGrams
+		}
+		value = round(value)
+
+		rate, ok := matchShippingRate(zone.Rates, method, value, weight)
+		if !ok {
+			if method == domain.ShippingExpress {
+				return errors.New("express shipping is not available for this address")
+			}
+			return errors.New("no shipping rate matches this order")
+		}
+
+		q.Shipping = rate.Charge
+		if method == domain.ShippingStandard && zone.FreeShippingThreshold > 0 && value >= zone.FreeShippingThreshold {
+			q.Shipping = 0
+		}
+
+		return nil
+	}
+}
+
+// matchShippingRate picks the first rate of the method whose band holds the
+// order's weight or value.
+func matchShippingRate(rates []models.ShippingRate, method string, value float64, weight int) (models.ShippingRate, bool) {
+	for _, r := range rates {
+		if r.Method != method {
+			continue
+		}
+
+		measure := value
+		if r.Basis == domain.RateByWeight {
+			measure = float64(weight)
+		}
+		if measure >= r.MinValue && (r.MaxValue == 0 || measure < r.MaxValue) {
+			return r, true
+		}
+	}
+
+	return models.ShippingRate{}, false
+}
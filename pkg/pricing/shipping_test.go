@@ -0,0 +1,88 @@
+package pricing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ahdaan98/pkg/domain"
+	"github.com/ahdaan98/pkg/utils/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type shippingZone models.ShippingZone
+
+func (z shippingZone) FindShippingZone(state string, pin int) (models.ShippingZone, error) {
+	if pin < 670000 || pin > 695999 {
+		return models.ShippingZone{}, nil
+	}
+	return models.ShippingZone(z), nil
+}
+
+func TestShipping(t *testing.T) {
+	zone := shippingZone{ID: 1, Name: "Kerala", FreeShippingThreshold: 1000, Rates: []models.ShippingRate{
+		{Method: domain.ShippingStandard, Basis: domain.RateByValue, MaxValue: 500, Charge: 60},
+		{Method: domain.ShippingStandard, Basis: domain.RateByValue, MinValue: 500, Charge: 40},
+		{Method: domain.ShippingExpress, Basis: domain.RateByWeight, MaxValue: 2000, Charge: 150},
+	}}
+	kochi := &domain.Address{State: "Kerala", Pin: "682001"}
+	shirt := Item{InventoryID: 1, Quantity: 2, UnitPrice: 300, WeightGrams: 400}
+	laptop := Item{InventoryID: 2, Quantity: 1, UnitPrice: 1200, WeightGrams: 2500}
+
+	tests := []struct {
+		name         string
+		in           Input
+		wantShipping float64
+		wantErr      error
+	}{
+		{
+			name:         "nothing charged before the address is known",
+			in:           Input{Items: []Item{shirt}},
+			wantShipping: 0,
+		},
+		{
+			name:         "address outside every zone",
+			in:           Input{Items: []Item{shirt}, Address: &domain.Address{State: "Delhi", Pin: "110001"}},
+			wantShipping: 0,
+		},
+		{
+			name:         "standard rate by order value",
+			in:           Input{Items: []Item{shirt}, Address: kochi},
+			wantShipping: 40,
+		},
+		{
+			name:         "rate picked on the value after discounts",
+			in:           Input{Items: []Item{shirt}, Address: kochi, Coupon: &Coupon{ID: 1, Amount: 200}},
+			wantShipping: 60,
+		},
+		{
+			name:         "standard shipping free past the threshold",
+			in:           Input{Items: []Item{laptop}, Address: kochi},
+			wantShipping: 0,
+		},
+		{
+			name:         "express rate by weight is never free",
+			in:           Input{Items: []Item{shirt}, Address: kochi, ShippingMethod: "express"},
+			wantShipping: 150,
+		},
+		{
+			name:    "express not offered for heavy orders",
+			in:      Input{Items: []Item{laptop}, Address: kochi, ShippingMethod: domain.ShippingExpress},
+			wantErr: errors.New("express shipping is not available for this address"),
+		},
+		{
+			name:    "unknown method",
+			in:      Input{Items: []Item{shirt}, Address: kochi, ShippingMethod: "drone"},
+			wantErr: errors.New("shipping method must be STANDARD or EXPRESS"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			quote, err := NewCalculator(CouponDiscount, Shipping(zone)).Quote(tc.in)
+			assert.Equal(t, tc.wantErr, err)
+			if err == nil {
+				assert.Equal(t, tc.wantShipping, quote.Shipping)
+			}
+		})
+	}
+}
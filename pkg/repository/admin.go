@@ -100,9 +100,9 @@ func (ad *adminRepository) GetAdminByEmail(email string) (models.AdminDetailsRes
 	return resp, nil
 }
 
-func (i *adminRepository) NewPaymentMethod(pay string) error {
+func (i *adminRepository) NewPaymentMethod(pay, kind string) error {
 
-	if err := i.DB.Exec("insert into payment_methods(payment_name)values($1)", pay).Error; err != nil {
+	if err := i.DB.Exec("insert into payment_methods(payment_name,kind)values($1,$2)", pay, kind).Error; err != nil {
 		return err
 	}
 
@@ -250,15 +250,20 @@ func (ad *adminRepository) DashBoardProductDetails() (models.DashBoardProduct, e
 func (ad *adminRepository) SalesByYear(yearInt int) ([]models.OrderDetailsAdmin, error) {
 	var orderDetails []models.OrderDetailsAdmin
 
-	query := `SELECT i.product_name, SUM(oi.total_price) AS total_amount
+	query := `SELECT oi.product_name, SUM(oi.total_price) AS total_amount
               FROM orders o
               JOIN order_items oi ON o.id = oi.order_id
-              JOIN inventories i ON oi.inventory_id = i.id
               WHERE o.payment_status = 'PAID'
                 AND EXTRACT(YEAR FROM o.created_at) = ?
-              GROUP BY i.product_name`
+              GROUP BY oi.product_name
+              UNION ALL
+              SELECT 'Shipping charges', SUM(o.shipping)
+              FROM orders o
+              WHERE o.payment_status = 'PAID'
+                AND EXTRACT(YEAR FROM o.created_at) = ?
+              HAVING SUM(o.shipping) > 0`
 
-	if err := ad.DB.Raw(query, yearInt).Scan(&orderDetails).Error; err != nil {
+	if err := ad.DB.Raw(query, yearInt, yearInt).Scan(&orderDetails).Error; err != nil {
 		return []models.OrderDetailsAdmin{}, err
 	}
 
@@ -270,16 +275,22 @@ func (ad *adminRepository) SalesByYear(yearInt int) ([]models.OrderDetailsAdmin,
 func (ad *adminRepository) SalesByMonth(yearInt int, monthInt int) ([]models.OrderDetailsAdmin, error) {
 	var orderDetails []models.OrderDetailsAdmin
 
-	query := `SELECT i.product_name, SUM(oi.total_price) AS total_amount
+	query := `SELECT oi.product_name, SUM(oi.total_price) AS total_amount
               FROM orders o
               JOIN order_items oi ON o.id = oi.order_id
-              JOIN inventories i ON oi.inventory_id = i.id
               WHERE o.payment_status = 'PAID'
 			  AND EXTRACT(YEAR FROM o.created_at) = ?
 			  AND EXTRACT(MONTH FROM o.created_at) = ?
-              GROUP BY i.product_name`
+              GROUP BY oi.product_name
+              UNION ALL
+              SELECT 'Shipping charges', SUM(o.shipping)
+              FROM orders o
+              WHERE o.payment_status = 'PAID'
+			  AND EXTRACT(YEAR FROM o.created_at) = ?
+			  AND EXTRACT(MONTH FROM o.created_at) = ?
+              HAVING SUM(o.shipping) > 0`
 
-	if err := ad.DB.Raw(query, yearInt, monthInt).Scan(&orderDetails).Error; err != nil {
+	if err := ad.DB.Raw(query, yearInt, monthInt, yearInt, monthInt).Scan(&orderDetails).Error; err != nil {
 		return []models.OrderDetailsAdmin{}, err
 	}
 
@@ -291,17 +302,24 @@ func (ad *adminRepository) SalesByMonth(yearInt int, monthInt int) ([]models.Ord
 func (ad *adminRepository) SalesByDay(yearInt int, monthInt int, dayInt int) ([]models.OrderDetailsAdmin, error) {
 	var orderDetails []models.OrderDetailsAdmin
 
-	query := `SELECT i.product_name, SUM(oi.total_price) AS total_amount
+	query := `SELECT oi.product_name, SUM(oi.total_price) AS total_amount
               FROM orders o
               JOIN order_items oi ON o.id = oi.order_id
-              JOIN inventories i ON oi.inventory_id = i.id
               WHERE o.payment_status = 'PAID'
 			  AND EXTRACT(YEAR FROM o.created_at) = ?
 			  AND EXTRACT(MONTH FROM o.created_at) = ?
                 AND EXTRACT(DAY FROM o.created_at) = ?
-              GROUP BY i.product_name`
+              GROUP BY oi.product_name
+              UNION ALL
+              SELECT 'Shipping charges', SUM(o.shipping)
+              FROM orders o
+              WHERE o.payment_status = 'PAID'
+			  AND EXTRACT(YEAR FROM o.created_at) = ?
+			  AND EXTRACT(MONTH FROM o.created_at) = ?
+                AND EXTRACT(DAY FROM o.created_at) = ?
+              HAVING SUM(o.shipping) > 0`
 
-	if err := ad.DB.Raw(query, yearInt, monthInt, dayInt).Scan(&orderDetails).Error; err != nil {
+	if err := ad.DB.Raw(query, yearInt, monthInt, dayInt, yearInt, monthInt, dayInt).Scan(&orderDetails).Error; err != nil {
 		return []models.OrderDetailsAdmin{}, err
 	}
 
@@ -316,24 +334,23 @@ func (ad *adminRepository) CustomSalesReportByDate(startTime time.Time, endTime
 	if result.Error != nil {
 		return models.SalesReport{}, result.Error
 	}
-	result = ad.DB.Raw("SELECT COUNT(*) FROM orders").Scan(&salesReport.TotalOrders)
+	result = ad.DB.Raw("SELECT COALESCE(SUM(shipping),0) FROM orders WHERE payment_status='PAID' AND created_at >= ? AND created_at <= ?", startTime, endTime).Scan(&salesReport.TotalShipping)
 	if result.Error != nil {
 		return models.SalesReport{}, result.Error
 	}
-	result = ad.DB.Raw("SELECT COUNT(*) FROM orders WHERE payment_status = 'PAID' and created_at >= ? AND created_at <= ?", startTime, endTime).Scan(&salesReport.CompletedOrders)
+	result = ad.DB.Raw("SELECT COUNT(*) FROM orders").Scan(&salesReport.TotalOrders)
 	if result.Error != nil {
 		return models.SalesReport{}, result.Error
 	}
-	result = ad.DB.Raw("SELECT COUNT(*) FROM orders WHERE order_status = 'PENDING'  AND created_at >= ? AND created_at<=?", startTime, endTime).Scan(&salesReport.PendingOrders)
+	result = ad.DB.Raw("SELECT COUNT(*) FROM orders WHERE payment_status = 'PAID' and created_at >= ? AND created_at <= ?", startTime, endTime).Scan(&salesReport.CompletedOrders)
 	if result.Error != nil {
 		return models.SalesReport{}, result.Error
 	}
-	var productID int
-	result = ad.DB.Raw("SELECT inventory_id FROM order_items GROUP BY inventory_id order by SUM(quantity) DESC LIMIT 1").Scan(&productID)
+	result = ad.DB.Raw("SELECT COUNT(*) FROM orders WHERE order_status = 'PENDING'  AND created_at >= ? AND created_at<=?", startTime, endTime).Scan(&salesReport.PendingOrders)
 	if result.Error != nil {
 		return models.SalesReport{}, result.Error
 	}
-	result = ad.DB.Raw("SELECT product_name FROM inventories WHERE id = ?", productID).Scan(&salesReport.TrendingProduct)
+	result = ad.DB.Raw("SELECT product_name FROM order_items GROUP BY product_name ORDER BY SUM(quantity) DESC LIMIT 1").Scan(&salesReport.TrendingProduct)
 	if result.Error != nil {
 		return models.SalesReport{}, result.Error
 	}
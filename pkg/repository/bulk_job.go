@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/ahdaan98/pkg/domain"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+	"gorm.io/gorm"
+)
+
+// bulkJobLease is how long a running job may go without progress before it is
+// considered abandoned and can be claimed again.
+const bulkJobLease = "2 minutes"
+
+type bulkJobRepository struct {
+	DB *gorm.DB
+}
+
+func NewBulkJobRepository(DB *gorm.DB) interfaces.BulkJobRepository {
+	return &bulkJobRepository{
+		DB: DB,
+	}
+}
+
+// CreateBulkJob stores a queued job with a pending result for every order.
+func (b *bulkJobRepository) CreateBulkJob(adminID int, action string, orderIDs []int) (int, error) {
+	var id int
+
+	err := b.DB.Transaction(func(tx *gorm.DB) error {
+		query := `
+		INSERT INTO bulk_order_jobs (admin_id, action, status, total, processed, succeeded, failed, created_at, updated_at)
+		VALUES (?, ?, ?, ?, 0, 0, 0, NOW(), NOW())
+		RETURNING id
+		`
+		if err := tx.Raw(query, adminID, action, domain.BulkJobStatusQueued, len(orderIDs)).Scan(&id).Error; err != nil {
+			return err
+		}
+
+		results := make([]domain.BulkOrderJobResult, 0, len(orderIDs))
+		for _, orderID := range orderIDs {
+			results = append(results, domain.BulkOrderJobResult{
+				BulkOrderJobID: uint(id),
+				OrderID:        uint(orderID),
+				Status:         domain.BulkResultPending,
+			})
+		}
+
+		return tx.CreateInBatches(results, 500).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// ClaimBulkJob marks a job as running. It reports false when the job is
+// finished or another worker is still making progress on it.
+func (b *bulkJobRepository) ClaimBulkJob(jobID int) (bool, error) {
+	query := `
+	UPDATE bulk_order_jobs SET status = ?, updated_at = NOW()
+	WHERE id = ? AND (status = ? OR (status = ? AND updated_at < NOW() - INTERVAL '` + bulkJobLease + `'))
+	`
+	result := b.DB.Exec(query, domain.BulkJobStatusRunning, jobID, domain.BulkJobStatusQueued, domain.BulkJobStatusRunning)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// GetStalledBulkJobs lists jobs that were never started or whose worker
+// stopped before finishing them, for example because the server restarted.
+func (b *bulkJobRepository) GetStalledBulkJobs() ([]int, error) {
+	var ids []int
+
+	query := `
+	SELECT id FROM bulk_order_jobs
+	WHERE status = ? OR (status = ? AND updated_at < NOW() - INTERVAL '` + bulkJobLease + `')
+	ORDER BY id
+	`
+	err := b.DB.Raw(query, domain.BulkJobStatusQueued, domain.BulkJobStatusRunning).Scan(&ids).Error
+	if err != nil {
+		return []int{}, err
+	}
+
+	return ids, nil
+}
+
+func (b *bulkJobRepository) GetPendingBulkOrders(jobID int) ([]int, error) {
+	var ids []int
+
+	err := b.DB.Raw("SELECT order_id FROM bulk_order_job_results WHERE bulk_order_job_id = ? AND status = ? ORDER BY order_id", jobID, domain.BulkResultPending).Scan(&ids).Error
+	if err != nil {
+		return []int{}, err
+	}
+
+	return ids, nil
+}
+
+// RecordBulkResult stores the outcome for one order and counts it towards the
+// job's progress. An order that already has an outcome is left alone.
+func (b *bulkJobRepository) RecordBulkResult(jobID, orderID int, status, message string) error {
+	result := b.DB.Exec("UPDATE bulk_order_job_results SET status = ?, message = ?, updated_at = NOW() WHERE bulk_order_job_id = ? AND order_id = ? AND status = ?",
+		status, message, jobID, orderID, domain.BulkResultPending)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil
+	}
+
+	succeeded, failed := 0, 0
+	if status == domain.BulkResultSucceeded {
+		succeeded = 1
+	} else {
+		failed = 1
+	}
+
+	query := `
+	UPDATE bulk_order_jobs
+	SET processed = processed + 1, succeeded = succeeded + ?, failed = failed + ?, updated_at = NOW()
+	WHERE id = ?
+	`
+	return b.DB.Exec(query, succeeded, failed, jobID).Error
+}
+
+func (b *bulkJobRepository) FinishBulkJob(jobID int) error {
+	return b.DB.Exec("UPDATE bulk_order_jobs SET status = ?, updated_at = NOW(), finished_at = NOW() WHERE id = ?", domain.BulkJobStatusCompleted, jobID).Error
+}
+
+func (b *bulkJobRepository) GetBulkJob(jobID int) (models.BulkJobDetails, error) {
+	var job models.BulkJobDetails
+
+	result := b.DB.Raw("SELECT * FROM bulk_order_jobs WHERE id = ?", jobID).Scan(&job)
+	if result.Error != nil {
+		return models.BulkJobDetails{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.BulkJobDetails{}, errors.New("no bulk job exists")
+	}
+
+	return job, nil
+}
+
+func (b *bulkJobRepository) GetBulkJobResults(jobID int) ([]models.BulkResultDetail, error) {
+	var results []models.BulkResultDetail
+
+	err := b.DB.Raw("SELECT order_id, status, message FROM bulk_order_job_results WHERE bulk_order_job_id = ? ORDER BY order_id", jobID).Scan(&results).Error
+	if err != nil {
+		return []models.BulkResultDetail{}, err
+	}
+
+	return results, nil
+}
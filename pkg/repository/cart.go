@@ -3,6 +3,7 @@ package repository
 import (
 	"errors"
 
+	"github.com/ahdaan98/pkg/domain"
 	interfaces "github.com/ahdaan98/pkg/repository/interface"
 	"github.com/ahdaan98/pkg/utils/models"
 	"gorm.io/gorm"
@@ -110,11 +111,37 @@ func (ad *cartRepository) CheckCart(userID int) (bool, error) {
 	return true, nil
 }
 
-func (ad *cartRepository) GetTotalPriceFromCart(userID int) (float64, error) {
-	var totalPrice float64
-	err := ad.DB.Raw("SELECT COALESCE(SUM(price), 0) FROM carts WHERE user_id = ?", userID).Scan(&totalPrice).Error
+func (ad *cartRepository) ClearCart(cartID int) error {
+	if err := ad.DB.Exec("DELETE FROM line_items WHERE cart_id = ?", cartID).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ad *cartRepository) GetCartItems(cartID int) ([]models.GetCart, error) {
+	var items []models.GetCart
+	err := ad.DB.Raw(`
+	SELECT inventories.id AS product_id, inventories.product_name,
+		inventories.brand_id, brands.brand_name AS brand,
+		inventories.category_id, categories.category_name AS category,
+		line_items.quantity, inventories.price,
+		line_items.quantity * inventories.price AS total, inventories.weight_grams
+	FROM line_items
+	JOIN inventories ON inventories.id = line_items.inventory_id
+	LEFT JOIN brands ON brands.id = inventories.brand_id
+	LEFT JOIN categories ON categories.id = inventories.category_id
+	WHERE line_items.cart_id = ?
+	ORDER BY line_items.id`, cartID).Scan(&items).Error
 	if err != nil {
-		return 0.0, err
+		return nil, err
 	}
-	return totalPrice, nil
-}
\ No newline at end of file
+	return items, nil
+}
+
+func (ad *cartRepository) GetAddress(userID, addressID int) (domain.Address, error) {
+	var address domain.Address
+	if err := ad.DB.Raw("SELECT * FROM addresses WHERE id = ? AND user_id = ?", addressID, userID).Scan(&address).Error; err != nil {
+		return domain.Address{}, err
+	}
+	return address, nil
+}
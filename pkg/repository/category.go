@@ -24,10 +24,10 @@ func (cat *CategoryRepository) AddCategory(category models.AddCategory) (domain.
 	var AddedCategory domain.Category
 
 	query := `
-	INSERT INTO categories (category_name)
-	VALUES (?) RETURNING *
+	INSERT INTO categories (category_name, hsn_code, gst_rate)
+	VALUES (?, ?, ?) RETURNING *
 	`
-	err := cat.DB.Raw(query, category.CategoryName).Scan(&AddedCategory).Error
+	err := cat.DB.Raw(query, category.CategoryName, category.HSNCode, category.GSTRate).Scan(&AddedCategory).Error
 	if err != nil {
 		return domain.Category{}, err
 	}
@@ -96,6 +96,23 @@ func (cat *CategoryRepository) EditCategory(EditCategory models.EditCategory, id
 	return Updatedcategory, nil
 }
 
+func (cat *CategoryRepository) SetCategoryTax(tax models.CategoryTax, id int) (domain.Category, error) {
+	var category domain.Category
+
+	query := `
+	UPDATE categories
+	SET hsn_code = ?, gst_rate = ?
+	WHERE id = ?
+	RETURNING *
+	`
+
+	if err := cat.DB.Raw(query, tax.HSNCode, tax.GSTRate, id).Scan(&category).Error; err != nil {
+		return domain.Category{}, err
+	}
+
+	return category, nil
+}
+
 func (cat *CategoryRepository) DeleteCategory(id int) error {
 	query := `
 	DELETE FROM categories
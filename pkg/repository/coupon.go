@@ -95,4 +95,25 @@ func (cp *couponRepository) GetCouponById(couponID int) (int, error) {
 		return 0, err
 	}
 	return rate, nil
-}
\ No newline at end of file
+}
+
+func (cp *couponRepository) RedeemCoupon(couponID, userID, orderID int, discount float64) error {
+	query := `
+	INSERT INTO coupon_redemptions (coupon_id, user_id, order_id, discount, created_at)
+	VALUES (?, ?, ?, ?, NOW())
+	`
+	if err := cp.DB.Exec(query, couponID, userID, orderID, discount).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReleaseCouponRedemption gives the coupon used on an order back to the
+// customer. It does nothing when the order used no coupon.
+func (cp *couponRepository) ReleaseCouponRedemption(orderID int) error {
+	err := cp.DB.Exec("UPDATE coupon_redemptions SET released_at = NOW() WHERE order_id = ? AND released_at IS NULL", orderID).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
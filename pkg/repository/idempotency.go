@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/ahdaan98/pkg/domain"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	"gorm.io/gorm"
+)
+
+type idempotencyRepository struct {
+	DB *gorm.DB
+}
+
+func NewIdempotencyRepository(DB *gorm.DB) interfaces.IdempotencyRepository {
+	return &idempotencyRepository{
+		DB: DB,
+	}
+}
+
+// ReserveKey stores key for a request that is about to be handled. It reports
+// false when the key is already taken by a request that has not expired; an
+// expired key is taken over.
+func (i *idempotencyRepository) ReserveKey(key domain.IdempotencyKey) (bool, error) {
+	query := `
+	INSERT INTO idempotency_keys (idempotency_key, user_id, method, path, request_hash, response_status, content_type, created_at, expires_at)
+	VALUES (?, ?, ?, ?, ?, 0, '', NOW(), ?)
+	ON CONFLICT (idempotency_key, user_id) DO UPDATE
+	SET method = EXCLUDED.method, path = EXCLUDED.path, request_hash = EXCLUDED.request_hash,
+		response_status = 0, response_body = NULL, content_type = '', created_at = NOW(), expires_at = EXCLUDED.expires_at
+	WHERE idempotency_keys.expires_at < NOW()
+	`
+	result := i.DB.Exec(query, key.Key, key.UserID, key.Method, key.Path, key.RequestHash, key.ExpiresAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (i *idempotencyRepository) GetKey(key string, userID int) (domain.IdempotencyKey, error) {
+	var stored domain.IdempotencyKey
+
+	result := i.DB.Raw("SELECT * FROM idempotency_keys WHERE idempotency_key = ? AND user_id = ?", key, userID).Scan(&stored)
+	if result.Error != nil {
+		return domain.IdempotencyKey{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.IdempotencyKey{}, errors.New("idempotency key does not exist")
+	}
+
+	return stored, nil
+}
+
+func (i *idempotencyRepository) SaveResponse(key string, userID, status int, contentType string, body []byte) error {
+	query := `
+	UPDATE idempotency_keys
+	SET response_status = ?, content_type = ?, response_body = ?
+	WHERE idempotency_key = ? AND user_id = ?
+	`
+	if err := i.DB.Exec(query, status, contentType, body, key, userID).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *idempotencyRepository) ReleaseKey(key string, userID int) error {
+	if err := i.DB.Exec("DELETE FROM idempotency_keys WHERE idempotency_key = ? AND user_id = ?", key, userID).Error; err != nil {
+		return err
+	}
+	return nil
+}
@@ -15,7 +15,7 @@ type AdminRepository interface {
 	GetAdminByEmail(email string) (models.AdminDetailsResponse,error)
 	CheckAdminExist(email string) (bool,error)
 
-	NewPaymentMethod(string) error
+	NewPaymentMethod(name, kind string) error
 	ListPaymentMethods() ([]domain.PaymentMethod, error)
 	GetPaymentMethod() ([]models.PaymentMethodResponse, error)
 	CheckIfPaymentMethodAlreadyExists(payment string) (bool, error)
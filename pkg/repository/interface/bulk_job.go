@@ -0,0 +1,15 @@
+package interfaces
+
+import "github.com/ahdaan98/pkg/utils/models"
+
+type BulkJobRepository interface {
+	CreateBulkJob(adminID int, action string, orderIDs []int) (int, error)
+	ClaimBulkJob(jobID int) (bool, error)
+	GetStalledBulkJobs() ([]int, error)
+	GetPendingBulkOrders(jobID int) ([]int, error)
+	RecordBulkResult(jobID, orderID int, status, message string) error
+	FinishBulkJob(jobID int) error
+
+	GetBulkJob(jobID int) (models.BulkJobDetails, error)
+	GetBulkJobResults(jobID int) ([]models.BulkResultDetail, error)
+}
@@ -1,6 +1,9 @@
 package interfaces
 
-import "github.com/ahdaan98/pkg/utils/models"
+import (
+	"github.com/ahdaan98/pkg/domain"
+	"github.com/ahdaan98/pkg/utils/models"
+)
 
 type CartRepository interface {
 	GetCart(id int) ([]models.GetCart, error)
@@ -11,5 +14,11 @@ type CartRepository interface {
 	AddLineItems(cart_id, inventory_id, qty int) error
 	CheckIfItemIsAlreadyAdded(cart_id, inventory_id int) (bool, error)
 	CheckCart(userID int) (bool, error)
-	GetTotalPriceFromCart(userID int) (float64, error)
+	ClearCart(cartID int) error
+
+	// GetCartItems returns the products in a cart with their current price.
+	GetCartItems(cartID int) ([]models.GetCart, error)
+	// GetAddress returns the user's address with the given id, or a zero
+	// address when the user has no such address.
+	GetAddress(userID, addressID int) (domain.Address, error)
 }
\ No newline at end of file
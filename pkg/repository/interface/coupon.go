@@ -9,4 +9,7 @@ type CouponRepository interface {
 	UpdateCoupon(CId int, CouponName string, CouponStatus bool, Discount int) (models.CouponResponse, error)
 	CheckCouponById(couponID int) (bool, error)
 	GetCouponById(couponID int) (int, error)
+
+	RedeemCoupon(couponID, userID, orderID int, discount float64) error
+	ReleaseCouponRedemption(orderID int) error
 }
\ No newline at end of file
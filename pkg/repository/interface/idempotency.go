@@ -0,0 +1,10 @@
+package interfaces
+
+import "github.com/ahdaan98/pkg/domain"
+
+type IdempotencyRepository interface {
+	ReserveKey(key domain.IdempotencyKey) (bool, error)
+	GetKey(key string, userID int) (domain.IdempotencyKey, error)
+	SaveResponse(key string, userID, status int, contentType string, body []byte) error
+	ReleaseKey(key string, userID int) error
+}
@@ -17,4 +17,11 @@ type InventoryRepository interface {
 	UploadImage(id int, image string) error
 	ListProductsWithImages(page, per_product int) ([]models.InventoryResponse, error)
 	GetImages(productID int) ([]string,error) 
+
+	LockStock(productID int) (models.CheckStockResponse, error)
+	ReduceStock(productID, quantity int) error
+	RestoreStock(inventoryID, quantity int) error
+	AddDamagedStock(inventoryID, quantity int) error
+
+	GetTaxClasses(inventoryIDs []int) ([]models.TaxClass, error)
 }
@@ -0,0 +1,19 @@
+package interfaces
+
+import "github.com/ahdaan98/pkg/utils/models"
+
+type InvoiceRepository interface {
+	// NextDocumentNumber takes the next number of a series for a financial
+	// year. The sequence row stays locked until the transaction ends.
+	NextDocumentNumber(series, financialYear string) (int, error)
+
+	CreateInvoice(invoice models.Invoice) (int, error)
+	GetInvoice(id int) (models.Invoice, error)
+	GetInvoiceByOrder(orderID int) (models.Invoice, error)
+	SetInvoiceFile(id int, path string) error
+
+	CreateCreditNote(note models.CreditNote) (int, error)
+	GetCreditNote(id int) (models.CreditNote, error)
+	GetCreditNotesByOrder(orderID int) ([]models.CreditNote, error)
+	SetCreditNoteFile(id int, path string) error
+}
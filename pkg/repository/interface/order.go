@@ -1,30 +1,25 @@
 package interfaces
 
 import (
+	"time"
+
 	"github.com/ahdaan98/pkg/domain"
 	"github.com/ahdaan98/pkg/utils/models"
 )
 
 type OrderRepository interface {
-	OrderItems(userid, addressid, paymentid int, total float64) (int, error)
-	AddOrderProducts(order_id int, cart []models.GetCart) error
+	OrderItems(userid, addressid, paymentid int, totals models.OrderTotals) (int, error)
+	AddOrderProducts(order_id int, lines []models.OrderItemLine) error
 	GetOrders(orderId int) (domain.OrderResponse, error)
 	CheckOrderStatusByID(id int) (string, error)
 	CheckPaymentStatus(orderID int) (string, error)
 	FindFinalPrice(orderID int) (int, error)
 	FindUserID(orderID int) (int, error)
-	UpdateOrder(orderID int) ([]models.CombinedOrderDetails, error)
-	UpdateReturnedOrder(orderID int) ([]models.CombinedOrderDetails, error)
-	CancelOrder(id int) error
 	GetAllOrders(userId, page, pageSize int) ([]models.OrderDetails, error)
-	GetOrderDetailsBrief(page int) ([]models.CombinedOrderDetails, error)
+	SearchOrders(query models.AdminOrderQuery) ([]models.AdminOrderSummary, int64, error)
+	SearchOrderIDs(query models.AdminOrderQuery, limit int) ([]int, error)
 	CheckOrdersStatusByID(id int) (string, error)
-	GetShipmentStatus(orderId int) (string, error)
-	ApproveOrder(orderId string) error
-	ChangeOrderStatus(orderID int, status string) error
 	GetShipmentsStatus(orderID int) (string, error)
-	ReturnOrder(shipmentStatus string, orderID int) error
-	ReduceInventoryQuantity(productName string, quantity int) error
 	GetOrderDetailsByOrderId(orderID string) (models.CombinedOrderDetails, error)
 	AddRazorPayDetails(orderID string, razorPayOrderID string) error
 	GetOrder(int) (domain.Order, error)
@@ -37,5 +32,22 @@ type OrderRepository interface {
 	OrderIdStatus(orderID int) (bool, error)
 	CartExist(UserId int) (bool, error)
 	GetItemsByOrderId(orderId int) ([]models.ItemDetails, error)
-	OrderItemsInv(productNames []string, categoryIds []int, prices, quantities []int, totalPrices []float64, userID int, orderID int) error
+	GetOrderItemTaxes(orderID int) ([]models.OrderItemTax, error)
+
+	LockOrderState(orderID int) (models.OrderState, error)
+	UpdateOrderState(orderID int, orderStatus, paymentStatus string) error
+	ReduceFinalPrice(orderID int, amount float64) error
+	AddStatusHistory(history domain.OrderStatusHistory) error
+	GetStatusHistory(orderID int) ([]domain.OrderStatusHistory, error)
+
+	LockOrderItems(orderID int) ([]models.OrderItemState, error)
+	GetOrderItemStates(orderID int) ([]models.OrderItemState, error)
+	UpdateOrderItem(item models.OrderItemState) error
+	SetOpenItemsStatus(orderID int, status string) error
+
+	GetPaymentMethodByID(paymentMethodID int) (models.PaymentMethodResponse, error)
+	SetPaymentExpiry(orderID int, expiresAt time.Time) error
+	GetExpiredUnpaidOrders(limit int) ([]int, error)
+
+	GetReorderItems(orderID int) ([]models.ReorderItem, error)
 }
\ No newline at end of file
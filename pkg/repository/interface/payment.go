@@ -1,8 +1,18 @@
 package interfaces
 
+import "github.com/ahdaan98/pkg/utils/models"
+
 type PaymentRepository interface {
-	AddRazorPayDetails(int, string) error
+	AddRazorPayDetails(orderId int, razorId string, amount int64) (int, error)
 	UpdatePaymentDetails(orderId string, paymentId string) error
 	GetPaymentStatus(orderId string) (bool, error)
-	UpdatePaymentStatus(status bool, orderId string) error
+
+	GetPayment(razorId string) (models.PaymentDetails, error)
+	LockPayment(razorId string) (models.PaymentDetails, error)
+	MarkPaymentCaptured(razorId, paymentId string) error
+	MarkPaymentFailed(razorId, paymentId, reason string) error
+	MarkPaymentRefunded(razorId, paymentId, reason string) error
+	GetCapturedPayment(orderId int) (models.PaymentDetails, error)
+	GetPaymentByPaymentID(paymentId string) (models.PaymentDetails, error)
+	GetPaymentAttempts(orderId int) ([]models.PaymentDetails, error)
 }
\ No newline at end of file
@@ -0,0 +1,10 @@
+package interfaces
+
+import "github.com/ahdaan98/pkg/utils/models"
+
+type PinCodeRepository interface {
+	SavePinCodes(pins []models.PinCode) error
+	GetPinCode(pin string) (models.PinCode, error)
+	GetPinCodes(page, count int) ([]models.PinCode, error)
+	DeletePinCode(pin string) error
+}
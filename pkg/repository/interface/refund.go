@@ -0,0 +1,17 @@
+package interfaces
+
+import "github.com/ahdaan98/pkg/utils/models"
+
+type RefundRepository interface {
+	AddRefund(refund models.RefundDetails) (int, error)
+	LockRefund(refundID int) (models.RefundDetails, error)
+	LockGatewayRefund(gatewayRefundID string) (models.RefundDetails, error)
+	ClaimRefund(refundID int) (models.RefundDetails, error)
+	SetGatewayRefund(refundID int, gatewayRefundID string) error
+	AddRefundAttempt(refundID int, reason string) error
+	UpdateRefundStatus(refundID int, status, reason string) error
+
+	CountPendingRefunds(orderID int) (int, error)
+	GetPendingGatewayRefunds(limit int) ([]int, error)
+	GetOrderRefunds(orderID int) ([]models.RefundDetails, error)
+}
@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/ahdaan98/pkg/utils/models"
+)
+
+type ReturnRepository interface {
+	CreateReturnRequest(orderID, userID int, reason, refundTo string) (int, error)
+	AddReturnItem(returnID, orderItemID, quantity, damagedQuantity int) error
+	AddReturnImage(returnID int, image string) error
+
+	LockReturnRequest(returnID int) (models.ReturnRequestDetails, error)
+	GetReturnRequest(returnID int) (models.ReturnRequestDetails, error)
+	GetReturnItems(returnID int) ([]models.ReturnItemDetails, error)
+	GetReturnImages(returnID int) ([]string, error)
+	GetUserReturnRequests(userID int) ([]models.ReturnRequestDetails, error)
+	GetReturnRequests(status string, page int) ([]models.ReturnRequestDetails, error)
+	PendingReturnQuantities(orderID int) ([]models.OrderItemQuantity, error)
+
+	UpdateReturnStatus(returnID int, status, note string) error
+	SchedulePickup(returnID int, pickupDate time.Time) error
+	SetReturnItemDamage(returnItemID, damagedQuantity int) error
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import "github.com/ahdaan98/pkg/utils/models"
+
+type ShipmentRepository interface {
+	CreateShipment(orderID int, shipment models.ShipmentRequest) (int, error)
+	GetShipmentByOrder(orderID int) (models.ShipmentDetails, error)
+	GetShipmentByTracking(carrier, trackingNumber string) (models.ShipmentDetails, error)
+	GetShipmentEvents(shipmentID int) ([]models.ShipmentEventState, error)
+
+	AddShipmentEvent(shipmentID int, event models.ShipmentEventState) (bool, error)
+	RefreshShipmentStatus(shipmentID int) (string, error)
+}
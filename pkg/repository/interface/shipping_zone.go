@@ -0,0 +1,13 @@
+package interfaces
+
+import "github.com/ahdaan98/pkg/utils/models"
+
+type ShippingZoneRepository interface {
+	CreateShippingZone(zone models.ShippingZoneRequest) (int, error)
+	UpdateShippingZone(id int, zone models.ShippingZoneRequest) error
+	SetShippingZoneRules(id int, areas []models.ShippingArea, rates []models.ShippingRate) error
+	DeleteShippingZone(id int) error
+	GetShippingZones() ([]models.ShippingZone, error)
+	GetShippingZone(id int) (models.ShippingZone, error)
+	FindShippingZone(state string, pin int) (models.ShippingZone, error)
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+// TxRepositories are repositories bound to a single database transaction.
+type TxRepositories struct {
+	Order        OrderRepository
+	Cart         CartRepository
+	Inventory    InventoryRepository
+	Wallet       WalletRepository
+	Payment      PaymentRepository
+	Return       ReturnRepository
+	Shipment     ShipmentRepository
+	Coupon       CouponRepository
+	BulkJob      BulkJobRepository
+	ShippingZone ShippingZoneRepository
+	Invoice      InvoiceRepository
+	Refund       RefundRepository
+}
+
+type TransactionRepository interface {
+	// WithTransaction runs fn inside a database transaction. The transaction is
+	// committed when fn returns nil and rolled back otherwise.
+	WithTransaction(fn func(repos TxRepositories) error) error
+}
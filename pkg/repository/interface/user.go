@@ -25,7 +25,7 @@ type UserRepository interface {
 	ChangePassword(id int, password string) error
 
 	GetCartID(id int) (int, error)
-	GetProductsInCart(cart_id int) ([]int, error)
+	GetProductsInCart(cart_id int) ([]models.CartProduct, error)
 	FindProductNames(inventory_id int) (string, error)
 	FindCartQuantity(cart_id, inventory_id int) (int, error)
 	FindPrice(inventory_id int) (float64, error)
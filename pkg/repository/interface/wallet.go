@@ -5,5 +5,5 @@ import "github.com/ahdaan98/pkg/utils/models"
 type WalletRepository interface {
 	GetWallet(userID int) (models.WalletAmount, error)
 	//GetsWallet(orderId int) (models.WalletAmount, error)
-	AddToWallet(Price, UserId int) (models.WalletAmount, error)
+	AddToWallet(Price float64, UserId int) (models.WalletAmount, error)
 }
\ No newline at end of file
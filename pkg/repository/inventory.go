@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
 
 	interfaces "github.com/ahdaan98/pkg/repository/interface"
@@ -29,10 +30,10 @@ func (inv *InventoryRepostiory) UploadImage(id int, image string) error {
 func (inv *InventoryRepostiory) AddInventory(inventory models.AddInventory) (models.InventoryResponse, error) {
 	var ReturningInventories models.InventoryResponse
 	query := `
-	INSERT INTO inventories (product_name,brand_id,category_id,stock,price)
-	VALUES (?,?,?,?,?)
+	INSERT INTO inventories (product_name,sku,brand_id,category_id,stock,price,hsn_code,gst_rate,weight_grams)
+	VALUES (?,?,?,?,?,?,?,?,?)
 	`
-	err := inv.DB.Exec(query, inventory.ProductName, inventory.BrandID, inventory.CategoryID, inventory.Stock, inventory.Price).Error
+	err := inv.DB.Exec(query, inventory.ProductName, inventory.SKU, inventory.BrandID, inventory.CategoryID, inventory.Stock, inventory.Price, inventory.HSNCode, inventory.GSTRate, inventory.WeightGrams).Error
 	if err != nil {
 		return models.InventoryResponse{}, err
 	}
@@ -104,11 +105,11 @@ func (inv *InventoryRepostiory) EditInventory(inventory models.EditInventory, id
 
 	query := `
 	UPDATE inventories
-	SET product_name = ?, brand_id = ?, category_id = ?, price = ?
+	SET product_name = ?, sku = ?, brand_id = ?, category_id = ?, price = ?, hsn_code = ?, gst_rate = ?, weight_grams = ?
 	WHERE id = ?
 	`
 
-	if err := inv.DB.Exec(query, inventory.ProductName, inventory.BrandID, inventory.CategoryID, inventory.Price, id).Error; err != nil {
+	if err := inv.DB.Exec(query, inventory.ProductName, inventory.SKU, inventory.BrandID, inventory.CategoryID, inventory.Price, inventory.HSNCode, inventory.GSTRate, inventory.WeightGrams, id).Error; err != nil {
 		return models.InventoryResponse{}, err
 	}
 
@@ -212,3 +213,83 @@ func (inv *InventoryRepostiory) GetImages(productID int) ([]string,error) {
 
 	return images,nil
 }
+
+// LockStock reads the stock of a product and holds a row lock on it until the
+// surrounding transaction ends.
+func (inv *InventoryRepostiory) LockStock(productID int) (models.CheckStockResponse, error) {
+	var stockResponse models.CheckStockResponse
+
+	query := `
+	SELECT product_name,stock
+	FROM inventories
+	WHERE id = ?
+	FOR UPDATE
+	`
+	result := inv.DB.Raw(query, productID).Scan(&stockResponse)
+	if result.Error != nil {
+		return models.CheckStockResponse{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.CheckStockResponse{}, errors.New("product does not exist")
+	}
+
+	return stockResponse, nil
+}
+
+func (inv *InventoryRepostiory) ReduceStock(productID, quantity int) error {
+	query := `
+	UPDATE inventories
+	SET stock = stock - ?
+	WHERE id = ?
+	`
+	if err := inv.DB.Exec(query, quantity, productID).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (inv *InventoryRepostiory) RestoreStock(inventoryID, quantity int) error {
+	query := `
+	UPDATE inventories
+	SET stock = stock + ?
+	WHERE id = ?
+	`
+	if err := inv.DB.Exec(query, quantity, inventoryID).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (inv *InventoryRepostiory) AddDamagedStock(inventoryID, quantity int) error {
+	query := `
+	UPDATE inventories
+	SET damaged_stock = damaged_stock + ?
+	WHERE id = ?
+	`
+	if err := inv.DB.Exec(query, quantity, inventoryID).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetTaxClasses reads the HSN code and GST rate of products. A product that
+// sets neither is taxed as its category.
+func (inv *InventoryRepostiory) GetTaxClasses(inventoryIDs []int) ([]models.TaxClass, error) {
+	var classes []models.TaxClass
+
+	query := `
+	SELECT i.id AS inventory_id, COALESCE(NULLIF(i.hsn_code, ''), c.hsn_code, '') AS hsn_code,
+	COALESCE(i.gst_rate, c.gst_rate, 0) AS gst_rate
+	FROM inventories i
+	LEFT JOIN categories c ON c.id = i.category_id
+	WHERE i.id IN ?
+	`
+	if err := inv.DB.Raw(query, inventoryIDs).Scan(&classes).Error; err != nil {
+		return nil, err
+	}
+
+	return classes, nil
+}
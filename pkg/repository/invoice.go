@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"errors"
+
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+	"gorm.io/gorm"
+)
+
+type invoiceRepository struct {
+	DB *gorm.DB
+}
+
+func NewInvoiceRepository(DB *gorm.DB) interfaces.InvoiceRepository {
+	return &invoiceRepository{
+		DB: DB,
+	}
+}
+
+func (i *invoiceRepository) NextDocumentNumber(series, financialYear string) (int, error) {
+	var number int
+
+	query := `
+	INSERT INTO document_sequences (series, financial_year, last_number)
+	VALUES (?, ?, 1)
+	ON CONFLICT (series, financial_year) DO UPDATE SET last_number = document_sequences.last_number + 1
+	RETURNING last_number
+	`
+	if err := i.DB.Raw(query, series, financialYear).Scan(&number).Error; err != nil {
+		return 0, err
+	}
+
+	return number, nil
+}
+
+func (i *invoiceRepository) CreateInvoice(invoice models.Invoice) (int, error) {
+	var id int
+
+	buyer := invoice.Buyer
+	query := `
+	INSERT INTO invoices (order_id, number, financial_year, issued_at,
+		buyer_name, buyer_email, buyer_phone, buyer_house_name, buyer_street, buyer_city, buyer_state, buyer_pin)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	RETURNING id
+	`
+	err := i.DB.Raw(query, invoice.OrderID, invoice.Number, invoice.FinancialYear, invoice.IssuedAt,
+		buyer.Name, buyer.Email, buyer.Phone, buyer.HouseName, buyer.Street, buyer.City, buyer.State, buyer.Pin).Scan(&id).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+const invoiceColumns = `id, order_id, number, financial_year, file_path, issued_at,
+	buyer_name, buyer_email, buyer_phone, buyer_house_name, buyer_street, buyer_city, buyer_state, buyer_pin`
+
+func (i *invoiceRepository) GetInvoice(id int) (models.Invoice, error) {
+	var invoice models.Invoice
+
+	err := i.DB.Raw("SELECT "+invoiceColumns+" FROM invoices WHERE id = ?", id).Scan(&invoice).Error
+	if err != nil {
+		return models.Invoice{}, err
+	}
+	if invoice.ID == 0 {
+		return models.Invoice{}, errors.New("invoice does not exist")
+	}
+
+	return invoice, nil
+}
+
+// GetInvoiceByOrder returns an empty invoice when the order has none yet.
+func (i *invoiceRepository) GetInvoiceByOrder(orderID int) (models.Invoice, error) {
+	var invoice models.Invoice
+
+	err := i.DB.Raw("SELECT "+invoiceColumns+" FROM invoices WHERE order_id = ?", orderID).Scan(&invoice).Error
+	if err != nil {
+		return models.Invoice{}, err
+	}
+
+	return invoice, nil
+}
+
+func (i *invoiceRepository) SetInvoiceFile(id int, path string) error {
+	return i.DB.Exec("UPDATE invoices SET file_path = ? WHERE id = ?", path, id).Error
+}
+
+func (i *invoiceRepository) CreateCreditNote(note models.CreditNote) (int, error) {
+	var id int
+
+	query := `
+	INSERT INTO credit_notes (invoice_id, order_id, number, financial_year, amount, reason, issued_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	RETURNING id
+	`
+	err := i.DB.Raw(query, note.InvoiceID, note.OrderID, note.Number, note.FinancialYear, note.Amount, note.Reason, note.IssuedAt).Scan(&id).Error
+	if err != nil {
+		return 0, err
+	}
+
+	for _, item := range note.Items {
+		err := i.DB.Exec("INSERT INTO credit_note_items (credit_note_id, order_item_id, product_name, quantity, amount) VALUES (?, ?, ?, ?, ?)",
+			id, item.OrderItemID, item.ProductName, item.Quantity, item.Amount).Error
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return id, nil
+}
+
+const creditNoteColumns = `
+	SELECT cn.id, cn.invoice_id, inv.number AS invoice_number, cn.order_id, cn.number, cn.financial_year,
+		cn.amount, cn.reason, cn.file_path, cn.issued_at
+	FROM credit_notes cn
+	JOIN invoices inv ON inv.id = cn.invoice_id
+	`
+
+func (i *invoiceRepository) GetCreditNote(id int) (models.CreditNote, error) {
+	var note models.CreditNote
+
+	if err := i.DB.Raw(creditNoteColumns+"WHERE cn.id = ?", id).Scan(&note).Error; err != nil {
+		return models.CreditNote{}, err
+	}
+	if note.ID == 0 {
+		return models.CreditNote{}, errors.New("credit note does not exist")
+	}
+
+	err := i.DB.Raw("SELECT order_item_id, product_name, quantity, amount FROM credit_note_items WHERE credit_note_id = ? ORDER BY id", id).Scan(&note.Items).Error
+	if err != nil {
+		return models.CreditNote{}, err
+	}
+
+	return note, nil
+}
+
+func (i *invoiceRepository) GetCreditNotesByOrder(orderID int) ([]models.CreditNote, error) {
+	var notes []models.CreditNote
+
+	if err := i.DB.Raw(creditNoteColumns+"WHERE cn.order_id = ? ORDER BY cn.id", orderID).Scan(&notes).Error; err != nil {
+		return nil, err
+	}
+
+	return notes, nil
+}
+
+func (i *invoiceRepository) SetCreditNoteFile(id int, path string) error {
+	return i.DB.Exec("UPDATE credit_notes SET file_path = ? WHERE id = ?", path, id).Error
+}
@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repository/interface/bulk_job.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/ahdaan98/pkg/utils/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBulkJobRepository is a mock of BulkJobRepository interface.
+type MockBulkJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBulkJobRepositoryMockRecorder
+}
+
+// MockBulkJobRepositoryMockRecorder is the mock recorder for MockBulkJobRepository.
+type MockBulkJobRepositoryMockRecorder struct {
+	mock *MockBulkJobRepository
+}
+
+// NewMockBulkJobRepository creates a new mock instance.
+func NewMockBulkJobRepository(ctrl *gomock.Controller) *MockBulkJobRepository {
+	mock := &MockBulkJobRepository{ctrl: ctrl}
+	mock.recorder = &MockBulkJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBulkJobRepository) EXPECT() *MockBulkJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimBulkJob mocks base method.
+func (m *MockBulkJobRepository) ClaimBulkJob(jobID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimBulkJob", jobID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimBulkJob indicates an expected call of ClaimBulkJob.
+func (mr *MockBulkJobRepositoryMockRecorder) ClaimBulkJob(jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimBulkJob", reflect.TypeOf((*MockBulkJobRepository)(nil).ClaimBulkJob), jobID)
+}
+
+// CreateBulkJob mocks base method.
+func (m *MockBulkJobRepository) CreateBulkJob(adminID int, action string, orderIDs []int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBulkJob", adminID, action, orderIDs)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBulkJob indicates an expected call of CreateBulkJob.
+func (mr *MockBulkJobRepositoryMockRecorder) CreateBulkJob(adminID, action, orderIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBulkJob", reflect.TypeOf((*MockBulkJobRepository)(nil).CreateBulkJob), adminID, action, orderIDs)
+}
+
+// FinishBulkJob mocks base method.
+func (m *MockBulkJobRepository) FinishBulkJob(jobID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishBulkJob", jobID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishBulkJob indicates an expected call of FinishBulkJob.
+func (mr *MockBulkJobRepositoryMockRecorder) FinishBulkJob(jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishBulkJob", reflect.TypeOf((*MockBulkJobRepository)(nil).FinishBulkJob), jobID)
+}
+
+// GetBulkJob mocks base method.
+func (m *MockBulkJobRepository) GetBulkJob(jobID int) (models.BulkJobDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBulkJob", jobID)
+	ret0, _ := ret[0].(models.BulkJobDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBulkJob indicates an expected call of GetBulkJob.
+func (mr *MockBulkJobRepositoryMockRecorder) GetBulkJob(jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBulkJob", reflect.TypeOf((*MockBulkJobRepository)(nil).GetBulkJob), jobID)
+}
+
+// GetBulkJobResults mocks base method.
+func (m *MockBulkJobRepository) GetBulkJobResults(jobID int) ([]models.BulkResultDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBulkJobResults", jobID)
+	ret0, _ := ret[0].([]models.BulkResultDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBulkJobResults indicates an expected call of GetBulkJobResults.
+func (mr *MockBulkJobRepositoryMockRecorder) GetBulkJobResults(jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBulkJobResults", reflect.TypeOf((*MockBulkJobRepository)(nil).GetBulkJobResults), jobID)
+}
+
+// GetPendingBulkOrders mocks base method.
+func (m *MockBulkJobRepository) GetPendingBulkOrders(jobID int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingBulkOrders", jobID)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingBulkOrders indicates an expected call of GetPendingBulkOrders.
+func (mr *MockBulkJobRepositoryMockRecorder) GetPendingBulkOrders(jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingBulkOrders", reflect.TypeOf((*MockBulkJobRepository)(nil).GetPendingBulkOrders), jobID)
+}
+
+// GetStalledBulkJobs mocks base method.
+func (m *MockBulkJobRepository) GetStalledBulkJobs() ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStalledBulkJobs")
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStalledBulkJobs indicates an expected call of GetStalledBulkJobs.
+func (mr *MockBulkJobRepositoryMockRecorder) GetStalledBulkJobs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStalledBulkJobs", reflect.TypeOf((*MockBulkJobRepository)(nil).GetStalledBulkJobs))
+}
+
+// RecordBulkResult mocks base method.
+func (m *MockBulkJobRepository) RecordBulkResult(jobID, orderID int, status, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordBulkResult", jobID, orderID, status, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordBulkResult indicates an expected call of RecordBulkResult.
+func (mr *MockBulkJobRepositoryMockRecorder) RecordBulkResult(jobID, orderID, status, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordBulkResult", reflect.TypeOf((*MockBulkJobRepository)(nil).RecordBulkResult), jobID, orderID, status, message)
+}
@@ -0,0 +1,184 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repository/interface/cart.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/ahdaan98/pkg/domain"
+	models "github.com/ahdaan98/pkg/utils/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCartRepository is a mock of CartRepository interface.
+type MockCartRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCartRepositoryMockRecorder
+}
+
+// MockCartRepositoryMockRecorder is the mock recorder for MockCartRepository.
+type MockCartRepositoryMockRecorder struct {
+	mock *MockCartRepository
+}
+
+// NewMockCartRepository creates a new mock instance.
+func NewMockCartRepository(ctrl *gomock.Controller) *MockCartRepository {
+	mock := &MockCartRepository{ctrl: ctrl}
+	mock.recorder = &MockCartRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCartRepository) EXPECT() *MockCartRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddLineItems mocks base method.
+func (m *MockCartRepository) AddLineItems(cart_id, inventory_id, qty int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLineItems", cart_id, inventory_id, qty)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLineItems indicates an expected call of AddLineItems.
+func (mr *MockCartRepositoryMockRecorder) AddLineItems(cart_id, inventory_id, qty interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLineItems", reflect.TypeOf((*MockCartRepository)(nil).AddLineItems), cart_id, inventory_id, qty)
+}
+
+// CheckCart mocks base method.
+func (m *MockCartRepository) CheckCart(userID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCart", userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckCart indicates an expected call of CheckCart.
+func (mr *MockCartRepositoryMockRecorder) CheckCart(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCart", reflect.TypeOf((*MockCartRepository)(nil).CheckCart), userID)
+}
+
+// CheckIfItemIsAlreadyAdded mocks base method.
+func (m *MockCartRepository) CheckIfItemIsAlreadyAdded(cart_id, inventory_id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckIfItemIsAlreadyAdded", cart_id, inventory_id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckIfItemIsAlreadyAdded indicates an expected call of CheckIfItemIsAlreadyAdded.
+func (mr *MockCartRepositoryMockRecorder) CheckIfItemIsAlreadyAdded(cart_id, inventory_id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIfItemIsAlreadyAdded", reflect.TypeOf((*MockCartRepository)(nil).CheckIfItemIsAlreadyAdded), cart_id, inventory_id)
+}
+
+// ClearCart mocks base method.
+func (m *MockCartRepository) ClearCart(cartID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearCart", cartID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearCart indicates an expected call of ClearCart.
+func (mr *MockCartRepositoryMockRecorder) ClearCart(cartID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCart", reflect.TypeOf((*MockCartRepository)(nil).ClearCart), cartID)
+}
+
+// CreateNewCart mocks base method.
+func (m *MockCartRepository) CreateNewCart(user_id int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNewCart", user_id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNewCart indicates an expected call of CreateNewCart.
+func (mr *MockCartRepositoryMockRecorder) CreateNewCart(user_id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNewCart", reflect.TypeOf((*MockCartRepository)(nil).CreateNewCart), user_id)
+}
+
+// GetAddress mocks base method.
+func (m *MockCartRepository) GetAddress(userID, addressID int) (domain.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddress", userID, addressID)
+	ret0, _ := ret[0].(domain.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddress indicates an expected call of GetAddress.
+func (mr *MockCartRepositoryMockRecorder) GetAddress(userID, addressID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddress", reflect.TypeOf((*MockCartRepository)(nil).GetAddress), userID, addressID)
+}
+
+// GetAddresses mocks base method.
+func (m *MockCartRepository) GetAddresses(id int) ([]models.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddresses", id)
+	ret0, _ := ret[0].([]models.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddresses indicates an expected call of GetAddresses.
+func (mr *MockCartRepositoryMockRecorder) GetAddresses(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddresses", reflect.TypeOf((*MockCartRepository)(nil).GetAddresses), id)
+}
+
+// GetCart mocks base method.
+func (m *MockCartRepository) GetCart(id int) ([]models.GetCart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCart", id)
+	ret0, _ := ret[0].([]models.GetCart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCart indicates an expected call of GetCart.
+func (mr *MockCartRepositoryMockRecorder) GetCart(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCart", reflect.TypeOf((*MockCartRepository)(nil).GetCart), id)
+}
+
+// GetCartId mocks base method.
+func (m *MockCartRepository) GetCartId(user_id int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCartId", user_id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCartId indicates an expected call of GetCartId.
+func (mr *MockCartRepositoryMockRecorder) GetCartId(user_id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartId", reflect.TypeOf((*MockCartRepository)(nil).GetCartId), user_id)
+}
+
+// GetCartItems mocks base method.
+func (m *MockCartRepository) GetCartItems(cartID int) ([]models.GetCart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCartItems", cartID)
+	ret0, _ := ret[0].([]models.GetCart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCartItems indicates an expected call of GetCartItems.
+func (mr *MockCartRepositoryMockRecorder) GetCartItems(cartID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartItems", reflect.TypeOf((*MockCartRepository)(nil).GetCartItems), cartID)
+}
@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repository/interface/coupon.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/ahdaan98/pkg/utils/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCouponRepository is a mock of CouponRepository interface.
+type MockCouponRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCouponRepositoryMockRecorder
+}
+
+// MockCouponRepositoryMockRecorder is the mock recorder for MockCouponRepository.
+type MockCouponRepositoryMockRecorder struct {
+	mock *MockCouponRepository
+}
+
+// NewMockCouponRepository creates a new mock instance.
+func NewMockCouponRepository(ctrl *gomock.Controller) *MockCouponRepository {
+	mock := &MockCouponRepository{ctrl: ctrl}
+	mock.recorder = &MockCouponRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCouponRepository) EXPECT() *MockCouponRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddCoupon mocks base method.
+func (m *MockCouponRepository) AddCoupon(CouponName string, CouponStatus bool, Discount int) (models.CouponResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCoupon", CouponName, CouponStatus, Discount)
+	ret0, _ := ret[0].(models.CouponResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCoupon indicates an expected call of AddCoupon.
+func (mr *MockCouponRepositoryMockRecorder) AddCoupon(CouponName, CouponStatus, Discount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCoupon", reflect.TypeOf((*MockCouponRepository)(nil).AddCoupon), CouponName, CouponStatus, Discount)
+}
+
+// CheckCoupon mocks base method.
+func (m *MockCouponRepository) CheckCoupon(coupon string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCoupon", coupon)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckCoupon indicates an expected call of CheckCoupon.
+func (mr *MockCouponRepositoryMockRecorder) CheckCoupon(coupon interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCoupon", reflect.TypeOf((*MockCouponRepository)(nil).CheckCoupon), coupon)
+}
+
+// CheckCouponById mocks base method.
+func (m *MockCouponRepository) CheckCouponById(couponID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCouponById", couponID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckCouponById indicates an expected call of CheckCouponById.
+func (mr *MockCouponRepositoryMockRecorder) CheckCouponById(couponID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCouponById", reflect.TypeOf((*MockCouponRepository)(nil).CheckCouponById), couponID)
+}
+
+// GetCopupon mocks base method.
+func (m *MockCouponRepository) GetCopupon() ([]models.CouponResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCopupon")
+	ret0, _ := ret[0].([]models.CouponResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCopupon indicates an expected call of GetCopupon.
+func (mr *MockCouponRepositoryMockRecorder) GetCopupon() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCopupon", reflect.TypeOf((*MockCouponRepository)(nil).GetCopupon))
+}
+
+// GetCouponById mocks base method.
+func (m *MockCouponRepository) GetCouponById(couponID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCouponById", couponID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCouponById indicates an expected call of GetCouponById.
+func (mr *MockCouponRepositoryMockRecorder) GetCouponById(couponID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCouponById", reflect.TypeOf((*MockCouponRepository)(nil).GetCouponById), couponID)
+}
+
+// RedeemCoupon mocks base method.
+func (m *MockCouponRepository) RedeemCoupon(couponID, userID, orderID int, discount float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemCoupon", couponID, userID, orderID, discount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RedeemCoupon indicates an expected call of RedeemCoupon.
+func (mr *MockCouponRepositoryMockRecorder) RedeemCoupon(couponID, userID, orderID, discount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemCoupon", reflect.TypeOf((*MockCouponRepository)(nil).RedeemCoupon), couponID, userID, orderID, discount)
+}
+
+// ReleaseCouponRedemption mocks base method.
+func (m *MockCouponRepository) ReleaseCouponRedemption(orderID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseCouponRedemption", orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseCouponRedemption indicates an expected call of ReleaseCouponRedemption.
+func (mr *MockCouponRepositoryMockRecorder) ReleaseCouponRedemption(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseCouponRedemption", reflect.TypeOf((*MockCouponRepository)(nil).ReleaseCouponRedemption), orderID)
+}
+
+// UpdateCoupon mocks base method.
+func (m *MockCouponRepository) UpdateCoupon(CId int, CouponName string, CouponStatus bool, Discount int) (models.CouponResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCoupon", CId, CouponName, CouponStatus, Discount)
+	ret0, _ := ret[0].(models.CouponResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCoupon indicates an expected call of UpdateCoupon.
+func (mr *MockCouponRepositoryMockRecorder) UpdateCoupon(CId, CouponName, CouponStatus, Discount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCoupon", reflect.TypeOf((*MockCouponRepository)(nil).UpdateCoupon), CId, CouponName, CouponStatus, Discount)
+}
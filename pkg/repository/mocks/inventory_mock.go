@@ -1,7 +1,7 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: C:\Users\ahdaa\GOLANG\Leuse_Ecommerce\pkg\repository\interface\inventory.go
+// Source: pkg/repository/interface/inventory.go
 
-// Package mock_interfaces is a generated GoMock package.
+// Package mocks is a generated GoMock package.
 package mocks
 
 import (
@@ -34,6 +34,20 @@ func (m *MockInventoryRepository) EXPECT() *MockInventoryRepositoryMockRecorder
 	return m.recorder
 }
 
+// AddDamagedStock mocks base method.
+func (m *MockInventoryRepository) AddDamagedStock(inventoryID, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDamagedStock", inventoryID, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDamagedStock indicates an expected call of AddDamagedStock.
+func (mr *MockInventoryRepositoryMockRecorder) AddDamagedStock(inventoryID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDamagedStock", reflect.TypeOf((*MockInventoryRepository)(nil).AddDamagedStock), inventoryID, quantity)
+}
+
 // AddInventory mocks base method.
 func (m *MockInventoryRepository) AddInventory(inventory models.AddInventory) (models.InventoryResponse, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +138,21 @@ func (mr *MockInventoryRepositoryMockRecorder) GetImages(productID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImages", reflect.TypeOf((*MockInventoryRepository)(nil).GetImages), productID)
 }
 
+// GetTaxClasses mocks base method.
+func (m *MockInventoryRepository) GetTaxClasses(inventoryIDs []int) ([]models.TaxClass, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxClasses", inventoryIDs)
+	ret0, _ := ret[0].([]models.TaxClass)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxClasses indicates an expected call of GetTaxClasses.
+func (mr *MockInventoryRepositoryMockRecorder) GetTaxClasses(inventoryIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxClasses", reflect.TypeOf((*MockInventoryRepository)(nil).GetTaxClasses), inventoryIDs)
+}
+
 // ListProducts mocks base method.
 func (m *MockInventoryRepository) ListProducts(page, per_product int) ([]models.InventoryResponse, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +183,49 @@ func (mr *MockInventoryRepositoryMockRecorder) ListProductsWithImages(page, per_
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsWithImages", reflect.TypeOf((*MockInventoryRepository)(nil).ListProductsWithImages), page, per_product)
 }
 
+// LockStock mocks base method.
+func (m *MockInventoryRepository) LockStock(productID int) (models.CheckStockResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockStock", productID)
+	ret0, _ := ret[0].(models.CheckStockResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockStock indicates an expected call of LockStock.
+func (mr *MockInventoryRepositoryMockRecorder) LockStock(productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockStock", reflect.TypeOf((*MockInventoryRepository)(nil).LockStock), productID)
+}
+
+// ReduceStock mocks base method.
+func (m *MockInventoryRepository) ReduceStock(productID, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReduceStock", productID, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReduceStock indicates an expected call of ReduceStock.
+func (mr *MockInventoryRepositoryMockRecorder) ReduceStock(productID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReduceStock", reflect.TypeOf((*MockInventoryRepository)(nil).ReduceStock), productID, quantity)
+}
+
+// RestoreStock mocks base method.
+func (m *MockInventoryRepository) RestoreStock(inventoryID, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreStock", inventoryID, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreStock indicates an expected call of RestoreStock.
+func (mr *MockInventoryRepositoryMockRecorder) RestoreStock(inventoryID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreStock", reflect.TypeOf((*MockInventoryRepository)(nil).RestoreStock), inventoryID, quantity)
+}
+
 // ShowIndividualProduct mocks base method.
 func (m *MockInventoryRepository) ShowIndividualProduct(productID int) (models.InventoryResponse, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,168 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repository/interface/invoice.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/ahdaan98/pkg/utils/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockInvoiceRepository is a mock of InvoiceRepository interface.
+type MockInvoiceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceRepositoryMockRecorder
+}
+
+// MockInvoiceRepositoryMockRecorder is the mock recorder for MockInvoiceRepository.
+type MockInvoiceRepositoryMockRecorder struct {
+	mock *MockInvoiceRepository
+}
+
+// NewMockInvoiceRepository creates a new mock instance.
+func NewMockInvoiceRepository(ctrl *gomock.Controller) *MockInvoiceRepository {
+	mock := &MockInvoiceRepository{ctrl: ctrl}
+	mock.recorder = &MockInvoiceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoiceRepository) EXPECT() *MockInvoiceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateCreditNote mocks base method.
+func (m *MockInvoiceRepository) CreateCreditNote(note models.CreditNote) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCreditNote", note)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCreditNote indicates an expected call of CreateCreditNote.
+func (mr *MockInvoiceRepositoryMockRecorder) CreateCreditNote(note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCreditNote", reflect.TypeOf((*MockInvoiceRepository)(nil).CreateCreditNote), note)
+}
+
+// CreateInvoice mocks base method.
+func (m *MockInvoiceRepository) CreateInvoice(invoice models.Invoice) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvoice", invoice)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvoice indicates an expected call of CreateInvoice.
+func (mr *MockInvoiceRepositoryMockRecorder) CreateInvoice(invoice interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).CreateInvoice), invoice)
+}
+
+// GetCreditNote mocks base method.
+func (m *MockInvoiceRepository) GetCreditNote(id int) (models.CreditNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCreditNote", id)
+	ret0, _ := ret[0].(models.CreditNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCreditNote indicates an expected call of GetCreditNote.
+func (mr *MockInvoiceRepositoryMockRecorder) GetCreditNote(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreditNote", reflect.TypeOf((*MockInvoiceRepository)(nil).GetCreditNote), id)
+}
+
+// GetCreditNotesByOrder mocks base method.
+func (m *MockInvoiceRepository) GetCreditNotesByOrder(orderID int) ([]models.CreditNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCreditNotesByOrder", orderID)
+	ret0, _ := ret[0].([]models.CreditNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCreditNotesByOrder indicates an expected call of GetCreditNotesByOrder.
+func (mr *MockInvoiceRepositoryMockRecorder) GetCreditNotesByOrder(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreditNotesByOrder", reflect.TypeOf((*MockInvoiceRepository)(nil).GetCreditNotesByOrder), orderID)
+}
+
+// GetInvoice mocks base method.
+func (m *MockInvoiceRepository) GetInvoice(id int) (models.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoice", id)
+	ret0, _ := ret[0].(models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoice indicates an expected call of GetInvoice.
+func (mr *MockInvoiceRepositoryMockRecorder) GetInvoice(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).GetInvoice), id)
+}
+
+// GetInvoiceByOrder mocks base method.
+func (m *MockInvoiceRepository) GetInvoiceByOrder(orderID int) (models.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceByOrder", orderID)
+	ret0, _ := ret[0].(models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceByOrder indicates an expected call of GetInvoiceByOrder.
+func (mr *MockInvoiceRepositoryMockRecorder) GetInvoiceByOrder(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceByOrder", reflect.TypeOf((*MockInvoiceRepository)(nil).GetInvoiceByOrder), orderID)
+}
+
+// NextDocumentNumber mocks base method.
+func (m *MockInvoiceRepository) NextDocumentNumber(series, financialYear string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextDocumentNumber", series, financialYear)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextDocumentNumber indicates an expected call of NextDocumentNumber.
+func (mr *MockInvoiceRepositoryMockRecorder) NextDocumentNumber(series, financialYear interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextDocumentNumber", reflect.TypeOf((*MockInvoiceRepository)(nil).NextDocumentNumber), series, financialYear)
+}
+
+// SetCreditNoteFile mocks base method.
+func (m *MockInvoiceRepository) SetCreditNoteFile(id int, path string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCreditNoteFile", id, path)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCreditNoteFile indicates an expected call of SetCreditNoteFile.
+func (mr *MockInvoiceRepositoryMockRecorder) SetCreditNoteFile(id, path interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCreditNoteFile", reflect.TypeOf((*MockInvoiceRepository)(nil).SetCreditNoteFile), id, path)
+}
+
+// SetInvoiceFile mocks base method.
+func (m *MockInvoiceRepository) SetInvoiceFile(id int, path string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetInvoiceFile", id, path)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetInvoiceFile indicates an expected call of SetInvoiceFile.
+func (mr *MockInvoiceRepositoryMockRecorder) SetInvoiceFile(id, path interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInvoiceFile", reflect.TypeOf((*MockInvoiceRepository)(nil).SetInvoiceFile), id, path)
+}
@@ -0,0 +1,600 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repository/interface/order.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/ahdaan98/pkg/domain"
+	models "github.com/ahdaan98/pkg/utils/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOrderRepository is a mock of OrderRepository interface.
+type MockOrderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderRepositoryMockRecorder
+}
+
+// MockOrderRepositoryMockRecorder is the mock recorder for MockOrderRepository.
+type MockOrderRepositoryMockRecorder struct {
+	mock *MockOrderRepository
+}
+
+// NewMockOrderRepository creates a new mock instance.
+func NewMockOrderRepository(ctrl *gomock.Controller) *MockOrderRepository {
+	mock := &MockOrderRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderRepository) EXPECT() *MockOrderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddOrderProducts mocks base method.
+func (m *MockOrderRepository) AddOrderProducts(order_id int, lines []models.OrderItemLine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOrderProducts", order_id, lines)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddOrderProducts indicates an expected call of AddOrderProducts.
+func (mr *MockOrderRepositoryMockRecorder) AddOrderProducts(order_id, lines interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrderProducts", reflect.TypeOf((*MockOrderRepository)(nil).AddOrderProducts), order_id, lines)
+}
+
+// AddRazorPayDetails mocks base method.
+func (m *MockOrderRepository) AddRazorPayDetails(orderID, razorPayOrderID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRazorPayDetails", orderID, razorPayOrderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRazorPayDetails indicates an expected call of AddRazorPayDetails.
+func (mr *MockOrderRepositoryMockRecorder) AddRazorPayDetails(orderID, razorPayOrderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRazorPayDetails", reflect.TypeOf((*MockOrderRepository)(nil).AddRazorPayDetails), orderID, razorPayOrderID)
+}
+
+// AddStatusHistory mocks base method.
+func (m *MockOrderRepository) AddStatusHistory(history domain.OrderStatusHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStatusHistory", history)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddStatusHistory indicates an expected call of AddStatusHistory.
+func (mr *MockOrderRepositoryMockRecorder) AddStatusHistory(history interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStatusHistory", reflect.TypeOf((*MockOrderRepository)(nil).AddStatusHistory), history)
+}
+
+// CartExist mocks base method.
+func (m *MockOrderRepository) CartExist(UserId int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CartExist", UserId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CartExist indicates an expected call of CartExist.
+func (mr *MockOrderRepositoryMockRecorder) CartExist(UserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CartExist", reflect.TypeOf((*MockOrderRepository)(nil).CartExist), UserId)
+}
+
+// CheckOrderStatusByID mocks base method.
+func (m *MockOrderRepository) CheckOrderStatusByID(id int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckOrderStatusByID", id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckOrderStatusByID indicates an expected call of CheckOrderStatusByID.
+func (mr *MockOrderRepositoryMockRecorder) CheckOrderStatusByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckOrderStatusByID", reflect.TypeOf((*MockOrderRepository)(nil).CheckOrderStatusByID), id)
+}
+
+// CheckOrderStatusByOrderId mocks base method.
+func (m *MockOrderRepository) CheckOrderStatusByOrderId(orderID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckOrderStatusByOrderId", orderID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckOrderStatusByOrderId indicates an expected call of CheckOrderStatusByOrderId.
+func (mr *MockOrderRepositoryMockRecorder) CheckOrderStatusByOrderId(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckOrderStatusByOrderId", reflect.TypeOf((*MockOrderRepository)(nil).CheckOrderStatusByOrderId), orderID)
+}
+
+// CheckOrdersStatusByID mocks base method.
+func (m *MockOrderRepository) CheckOrdersStatusByID(id int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckOrdersStatusByID", id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckOrdersStatusByID indicates an expected call of CheckOrdersStatusByID.
+func (mr *MockOrderRepositoryMockRecorder) CheckOrdersStatusByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckOrdersStatusByID", reflect.TypeOf((*MockOrderRepository)(nil).CheckOrdersStatusByID), id)
+}
+
+// CheckPaymentStatus mocks base method.
+func (m *MockOrderRepository) CheckPaymentStatus(orderID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPaymentStatus", orderID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckPaymentStatus indicates an expected call of CheckPaymentStatus.
+func (mr *MockOrderRepositoryMockRecorder) CheckPaymentStatus(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPaymentStatus", reflect.TypeOf((*MockOrderRepository)(nil).CheckPaymentStatus), orderID)
+}
+
+// FindFinalPrice mocks base method.
+func (m *MockOrderRepository) FindFinalPrice(orderID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFinalPrice", orderID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFinalPrice indicates an expected call of FindFinalPrice.
+func (mr *MockOrderRepositoryMockRecorder) FindFinalPrice(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFinalPrice", reflect.TypeOf((*MockOrderRepository)(nil).FindFinalPrice), orderID)
+}
+
+// FindUserID mocks base method.
+func (m *MockOrderRepository) FindUserID(orderID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserID", orderID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserID indicates an expected call of FindUserID.
+func (mr *MockOrderRepositoryMockRecorder) FindUserID(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserID", reflect.TypeOf((*MockOrderRepository)(nil).FindUserID), orderID)
+}
+
+// GetAllOrders mocks base method.
+func (m *MockOrderRepository) GetAllOrders(userId, page, pageSize int) ([]models.OrderDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllOrders", userId, page, pageSize)
+	ret0, _ := ret[0].([]models.OrderDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllOrders indicates an expected call of GetAllOrders.
+func (mr *MockOrderRepositoryMockRecorder) GetAllOrders(userId, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrders", reflect.TypeOf((*MockOrderRepository)(nil).GetAllOrders), userId, page, pageSize)
+}
+
+// GetDetailedOrderThroughId mocks base method.
+func (m *MockOrderRepository) GetDetailedOrderThroughId(orderId int) (models.CombinedOrderDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetailedOrderThroughId", orderId)
+	ret0, _ := ret[0].(models.CombinedOrderDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetailedOrderThroughId indicates an expected call of GetDetailedOrderThroughId.
+func (mr *MockOrderRepositoryMockRecorder) GetDetailedOrderThroughId(orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetailedOrderThroughId", reflect.TypeOf((*MockOrderRepository)(nil).GetDetailedOrderThroughId), orderId)
+}
+
+// GetExpiredUnpaidOrders mocks base method.
+func (m *MockOrderRepository) GetExpiredUnpaidOrders(limit int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredUnpaidOrders", limit)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredUnpaidOrders indicates an expected call of GetExpiredUnpaidOrders.
+func (mr *MockOrderRepositoryMockRecorder) GetExpiredUnpaidOrders(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredUnpaidOrders", reflect.TypeOf((*MockOrderRepository)(nil).GetExpiredUnpaidOrders), limit)
+}
+
+// GetItemsByOrderId mocks base method.
+func (m *MockOrderRepository) GetItemsByOrderId(orderId int) ([]models.ItemDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemsByOrderId", orderId)
+	ret0, _ := ret[0].([]models.ItemDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemsByOrderId indicates an expected call of GetItemsByOrderId.
+func (mr *MockOrderRepositoryMockRecorder) GetItemsByOrderId(orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsByOrderId", reflect.TypeOf((*MockOrderRepository)(nil).GetItemsByOrderId), orderId)
+}
+
+// GetOrder mocks base method.
+func (m *MockOrderRepository) GetOrder(arg0 int) (domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", arg0)
+	ret0, _ := ret[0].(domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockOrderRepositoryMockRecorder) GetOrder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderRepository)(nil).GetOrder), arg0)
+}
+
+// GetOrderDetailsByOrderId mocks base method.
+func (m *MockOrderRepository) GetOrderDetailsByOrderId(orderID string) (models.CombinedOrderDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderDetailsByOrderId", orderID)
+	ret0, _ := ret[0].(models.CombinedOrderDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderDetailsByOrderId indicates an expected call of GetOrderDetailsByOrderId.
+func (mr *MockOrderRepositoryMockRecorder) GetOrderDetailsByOrderId(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderDetailsByOrderId", reflect.TypeOf((*MockOrderRepository)(nil).GetOrderDetailsByOrderId), orderID)
+}
+
+// GetOrderItemStates mocks base method.
+func (m *MockOrderRepository) GetOrderItemStates(orderID int) ([]models.OrderItemState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderItemStates", orderID)
+	ret0, _ := ret[0].([]models.OrderItemState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderItemStates indicates an expected call of GetOrderItemStates.
+func (mr *MockOrderRepositoryMockRecorder) GetOrderItemStates(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItemStates", reflect.TypeOf((*MockOrderRepository)(nil).GetOrderItemStates), orderID)
+}
+
+// GetOrderItemTaxes mocks base method.
+func (m *MockOrderRepository) GetOrderItemTaxes(orderID int) ([]models.OrderItemTax, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderItemTaxes", orderID)
+	ret0, _ := ret[0].([]models.OrderItemTax)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderItemTaxes indicates an expected call of GetOrderItemTaxes.
+func (mr *MockOrderRepositoryMockRecorder) GetOrderItemTaxes(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItemTaxes", reflect.TypeOf((*MockOrderRepository)(nil).GetOrderItemTaxes), orderID)
+}
+
+// GetOrderStatus mocks base method.
+func (m *MockOrderRepository) GetOrderStatus(orderID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderStatus", orderID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderStatus indicates an expected call of GetOrderStatus.
+func (mr *MockOrderRepositoryMockRecorder) GetOrderStatus(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderStatus", reflect.TypeOf((*MockOrderRepository)(nil).GetOrderStatus), orderID)
+}
+
+// GetOrders mocks base method.
+func (m *MockOrderRepository) GetOrders(orderId int) (domain.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrders", orderId)
+	ret0, _ := ret[0].(domain.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrders indicates an expected call of GetOrders.
+func (mr *MockOrderRepositoryMockRecorder) GetOrders(orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockOrderRepository)(nil).GetOrders), orderId)
+}
+
+// GetOrdersDetailsByOrderId mocks base method.
+func (m *MockOrderRepository) GetOrdersDetailsByOrderId(orderID int) (models.CombinedOrderDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersDetailsByOrderId", orderID)
+	ret0, _ := ret[0].(models.CombinedOrderDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersDetailsByOrderId indicates an expected call of GetOrdersDetailsByOrderId.
+func (mr *MockOrderRepositoryMockRecorder) GetOrdersDetailsByOrderId(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersDetailsByOrderId", reflect.TypeOf((*MockOrderRepository)(nil).GetOrdersDetailsByOrderId), orderID)
+}
+
+// GetPaymentMethodByID mocks base method.
+func (m *MockOrderRepository) GetPaymentMethodByID(paymentMethodID int) (models.PaymentMethodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentMethodByID", paymentMethodID)
+	ret0, _ := ret[0].(models.PaymentMethodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentMethodByID indicates an expected call of GetPaymentMethodByID.
+func (mr *MockOrderRepositoryMockRecorder) GetPaymentMethodByID(paymentMethodID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentMethodByID", reflect.TypeOf((*MockOrderRepository)(nil).GetPaymentMethodByID), paymentMethodID)
+}
+
+// GetReorderItems mocks base method.
+func (m *MockOrderRepository) GetReorderItems(orderID int) ([]models.ReorderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReorderItems", orderID)
+	ret0, _ := ret[0].([]models.ReorderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReorderItems indicates an expected call of GetReorderItems.
+func (mr *MockOrderRepositoryMockRecorder) GetReorderItems(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReorderItems", reflect.TypeOf((*MockOrderRepository)(nil).GetReorderItems), orderID)
+}
+
+// GetShipmentsStatus mocks base method.
+func (m *MockOrderRepository) GetShipmentsStatus(orderID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShipmentsStatus", orderID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShipmentsStatus indicates an expected call of GetShipmentsStatus.
+func (mr *MockOrderRepositoryMockRecorder) GetShipmentsStatus(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShipmentsStatus", reflect.TypeOf((*MockOrderRepository)(nil).GetShipmentsStatus), orderID)
+}
+
+// GetStatusHistory mocks base method.
+func (m *MockOrderRepository) GetStatusHistory(orderID int) ([]domain.OrderStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusHistory", orderID)
+	ret0, _ := ret[0].([]domain.OrderStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatusHistory indicates an expected call of GetStatusHistory.
+func (mr *MockOrderRepositoryMockRecorder) GetStatusHistory(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusHistory", reflect.TypeOf((*MockOrderRepository)(nil).GetStatusHistory), orderID)
+}
+
+// LockOrderItems mocks base method.
+func (m *MockOrderRepository) LockOrderItems(orderID int) ([]models.OrderItemState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockOrderItems", orderID)
+	ret0, _ := ret[0].([]models.OrderItemState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockOrderItems indicates an expected call of LockOrderItems.
+func (mr *MockOrderRepositoryMockRecorder) LockOrderItems(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockOrderItems", reflect.TypeOf((*MockOrderRepository)(nil).LockOrderItems), orderID)
+}
+
+// LockOrderState mocks base method.
+func (m *MockOrderRepository) LockOrderState(orderID int) (models.OrderState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockOrderState", orderID)
+	ret0, _ := ret[0].(models.OrderState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockOrderState indicates an expected call of LockOrderState.
+func (mr *MockOrderRepositoryMockRecorder) LockOrderState(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockOrderState", reflect.TypeOf((*MockOrderRepository)(nil).LockOrderState), orderID)
+}
+
+// OrderIdStatus mocks base method.
+func (m *MockOrderRepository) OrderIdStatus(orderID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderIdStatus", orderID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrderIdStatus indicates an expected call of OrderIdStatus.
+func (mr *MockOrderRepositoryMockRecorder) OrderIdStatus(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderIdStatus", reflect.TypeOf((*MockOrderRepository)(nil).OrderIdStatus), orderID)
+}
+
+// OrderItems mocks base method.
+func (m *MockOrderRepository) OrderItems(userid, addressid, paymentid int, totals models.OrderTotals) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderItems", userid, addressid, paymentid, totals)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrderItems indicates an expected call of OrderItems.
+func (mr *MockOrderRepositoryMockRecorder) OrderItems(userid, addressid, paymentid, totals interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderItems", reflect.TypeOf((*MockOrderRepository)(nil).OrderItems), userid, addressid, paymentid, totals)
+}
+
+// PaymentAlreadyPaid mocks base method.
+func (m *MockOrderRepository) PaymentAlreadyPaid(orderID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaymentAlreadyPaid", orderID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PaymentAlreadyPaid indicates an expected call of PaymentAlreadyPaid.
+func (mr *MockOrderRepositoryMockRecorder) PaymentAlreadyPaid(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaymentAlreadyPaid", reflect.TypeOf((*MockOrderRepository)(nil).PaymentAlreadyPaid), orderID)
+}
+
+// PaymentMethodID mocks base method.
+func (m *MockOrderRepository) PaymentMethodID(orderID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaymentMethodID", orderID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PaymentMethodID indicates an expected call of PaymentMethodID.
+func (mr *MockOrderRepositoryMockRecorder) PaymentMethodID(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaymentMethodID", reflect.TypeOf((*MockOrderRepository)(nil).PaymentMethodID), orderID)
+}
+
+// ReduceFinalPrice mocks base method.
+func (m *MockOrderRepository) ReduceFinalPrice(orderID int, amount float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReduceFinalPrice", orderID, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReduceFinalPrice indicates an expected call of ReduceFinalPrice.
+func (mr *MockOrderRepositoryMockRecorder) ReduceFinalPrice(orderID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReduceFinalPrice", reflect.TypeOf((*MockOrderRepository)(nil).ReduceFinalPrice), orderID, amount)
+}
+
+// SearchOrderIDs mocks base method.
+func (m *MockOrderRepository) SearchOrderIDs(query models.AdminOrderQuery, limit int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchOrderIDs", query, limit)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchOrderIDs indicates an expected call of SearchOrderIDs.
+func (mr *MockOrderRepositoryMockRecorder) SearchOrderIDs(query, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchOrderIDs", reflect.TypeOf((*MockOrderRepository)(nil).SearchOrderIDs), query, limit)
+}
+
+// SearchOrders mocks base method.
+func (m *MockOrderRepository) SearchOrders(query models.AdminOrderQuery) ([]models.AdminOrderSummary, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchOrders", query)
+	ret0, _ := ret[0].([]models.AdminOrderSummary)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchOrders indicates an expected call of SearchOrders.
+func (mr *MockOrderRepositoryMockRecorder) SearchOrders(query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchOrders", reflect.TypeOf((*MockOrderRepository)(nil).SearchOrders), query)
+}
+
+// SetOpenItemsStatus mocks base method.
+func (m *MockOrderRepository) SetOpenItemsStatus(orderID int, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOpenItemsStatus", orderID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOpenItemsStatus indicates an expected call of SetOpenItemsStatus.
+func (mr *MockOrderRepositoryMockRecorder) SetOpenItemsStatus(orderID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOpenItemsStatus", reflect.TypeOf((*MockOrderRepository)(nil).SetOpenItemsStatus), orderID, status)
+}
+
+// SetPaymentExpiry mocks base method.
+func (m *MockOrderRepository) SetPaymentExpiry(orderID int, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPaymentExpiry", orderID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPaymentExpiry indicates an expected call of SetPaymentExpiry.
+func (mr *MockOrderRepositoryMockRecorder) SetPaymentExpiry(orderID, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPaymentExpiry", reflect.TypeOf((*MockOrderRepository)(nil).SetPaymentExpiry), orderID, expiresAt)
+}
+
+// UpdateOrderItem mocks base method.
+func (m *MockOrderRepository) UpdateOrderItem(item models.OrderItemState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderItem", item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrderItem indicates an expected call of UpdateOrderItem.
+func (mr *MockOrderRepositoryMockRecorder) UpdateOrderItem(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderItem", reflect.TypeOf((*MockOrderRepository)(nil).UpdateOrderItem), item)
+}
+
+// UpdateOrderState mocks base method.
+func (m *MockOrderRepository) UpdateOrderState(orderID int, orderStatus, paymentStatus string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderState", orderID, orderStatus, paymentStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrderState indicates an expected call of UpdateOrderState.
+func (mr *MockOrderRepositoryMockRecorder) UpdateOrderState(orderID, orderStatus, paymentStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderState", reflect.TypeOf((*MockOrderRepository)(nil).UpdateOrderState), orderID, orderStatus, paymentStatus)
+}
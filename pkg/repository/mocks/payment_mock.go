@@ -0,0 +1,196 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repository/interface/payment.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/ahdaan98/pkg/utils/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPaymentRepository is a mock of PaymentRepository interface.
+type MockPaymentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRepositoryMockRecorder
+}
+
+// MockPaymentRepositoryMockRecorder is the mock recorder for MockPaymentRepository.
+type MockPaymentRepositoryMockRecorder struct {
+	mock *MockPaymentRepository
+}
+
+// NewMockPaymentRepository creates a new mock instance.
+func NewMockPaymentRepository(ctrl *gomock.Controller) *MockPaymentRepository {
+	mock := &MockPaymentRepository{ctrl: ctrl}
+	mock.recorder = &MockPaymentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRepository) EXPECT() *MockPaymentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddRazorPayDetails mocks base method.
+func (m *MockPaymentRepository) AddRazorPayDetails(orderId int, razorId string, amount int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRazorPayDetails", orderId, razorId, amount)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddRazorPayDetails indicates an expected call of AddRazorPayDetails.
+func (mr *MockPaymentRepositoryMockRecorder) AddRazorPayDetails(orderId, razorId, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRazorPayDetails", reflect.TypeOf((*MockPaymentRepository)(nil).AddRazorPayDetails), orderId, razorId, amount)
+}
+
+// GetCapturedPayment mocks base method.
+func (m *MockPaymentRepository) GetCapturedPayment(orderId int) (models.PaymentDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCapturedPayment", orderId)
+	ret0, _ := ret[0].(models.PaymentDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCapturedPayment indicates an expected call of GetCapturedPayment.
+func (mr *MockPaymentRepositoryMockRecorder) GetCapturedPayment(orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCapturedPayment", reflect.TypeOf((*MockPaymentRepository)(nil).GetCapturedPayment), orderId)
+}
+
+// GetPayment mocks base method.
+func (m *MockPaymentRepository) GetPayment(razorId string) (models.PaymentDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayment", razorId)
+	ret0, _ := ret[0].(models.PaymentDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayment indicates an expected call of GetPayment.
+func (mr *MockPaymentRepositoryMockRecorder) GetPayment(razorId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayment", reflect.TypeOf((*MockPaymentRepository)(nil).GetPayment), razorId)
+}
+
+// GetPaymentAttempts mocks base method.
+func (m *MockPaymentRepository) GetPaymentAttempts(orderId int) ([]models.PaymentDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentAttempts", orderId)
+	ret0, _ := ret[0].([]models.PaymentDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentAttempts indicates an expected call of GetPaymentAttempts.
+func (mr *MockPaymentRepositoryMockRecorder) GetPaymentAttempts(orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentAttempts", reflect.TypeOf((*MockPaymentRepository)(nil).GetPaymentAttempts), orderId)
+}
+
+// GetPaymentByPaymentID mocks base method.
+func (m *MockPaymentRepository) GetPaymentByPaymentID(paymentId string) (models.PaymentDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentByPaymentID", paymentId)
+	ret0, _ := ret[0].(models.PaymentDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentByPaymentID indicates an expected call of GetPaymentByPaymentID.
+func (mr *MockPaymentRepositoryMockRecorder) GetPaymentByPaymentID(paymentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentByPaymentID", reflect.TypeOf((*MockPaymentRepository)(nil).GetPaymentByPaymentID), paymentId)
+}
+
+// GetPaymentStatus mocks base method.
+func (m *MockPaymentRepository) GetPaymentStatus(orderId string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentStatus", orderId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentStatus indicates an expected call of GetPaymentStatus.
+func (mr *MockPaymentRepositoryMockRecorder) GetPaymentStatus(orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentStatus", reflect.TypeOf((*MockPaymentRepository)(nil).GetPaymentStatus), orderId)
+}
+
+// LockPayment mocks base method.
+func (m *MockPaymentRepository) LockPayment(razorId string) (models.PaymentDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockPayment", razorId)
+	ret0, _ := ret[0].(models.PaymentDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockPayment indicates an expected call of LockPayment.
+func (mr *MockPaymentRepositoryMockRecorder) LockPayment(razorId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockPayment", reflect.TypeOf((*MockPaymentRepository)(nil).LockPayment), razorId)
+}
+
+// MarkPaymentCaptured mocks base method.
+func (m *MockPaymentRepository) MarkPaymentCaptured(razorId, paymentId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPaymentCaptured", razorId, paymentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPaymentCaptured indicates an expected call of MarkPaymentCaptured.
+func (mr *MockPaymentRepositoryMockRecorder) MarkPaymentCaptured(razorId, paymentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPaymentCaptured", reflect.TypeOf((*MockPaymentRepository)(nil).MarkPaymentCaptured), razorId, paymentId)
+}
+
+// MarkPaymentFailed mocks base method.
+func (m *MockPaymentRepository) MarkPaymentFailed(razorId, paymentId, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPaymentFailed", razorId, paymentId, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPaymentFailed indicates an expected call of MarkPaymentFailed.
+func (mr *MockPaymentRepositoryMockRecorder) MarkPaymentFailed(razorId, paymentId, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPaymentFailed", reflect.TypeOf((*MockPaymentRepository)(nil).MarkPaymentFailed), razorId, paymentId, reason)
+}
+
+// MarkPaymentRefunded mocks base method.
+func (m *MockPaymentRepository) MarkPaymentRefunded(razorId, paymentId, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPaymentRefunded", razorId, paymentId, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPaymentRefunded indicates an expected call of MarkPaymentRefunded.
+func (mr *MockPaymentRepositoryMockRecorder) MarkPaymentRefunded(razorId, paymentId, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPaymentRefunded", reflect.TypeOf((*MockPaymentRepository)(nil).MarkPaymentRefunded), razorId, paymentId, reason)
+}
+
+// UpdatePaymentDetails mocks base method.
+func (m *MockPaymentRepository) UpdatePaymentDetails(orderId, paymentId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentDetails", orderId, paymentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePaymentDetails indicates an expected call of UpdatePaymentDetails.
+func (mr *MockPaymentRepositoryMockRecorder) UpdatePaymentDetails(orderId, paymentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentDetails", reflect.TypeOf((*MockPaymentRepository)(nil).UpdatePaymentDetails), orderId, paymentId)
+}
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repository/interface/pin_code.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/ahdaan98/pkg/utils/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPinCodeRepository is a mock of PinCodeRepository interface.
+type MockPinCodeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPinCodeRepositoryMockRecorder
+}
+
+// MockPinCodeRepositoryMockRecorder is the mock recorder for MockPinCodeRepository.
+type MockPinCodeRepositoryMockRecorder struct {
+	mock *MockPinCodeRepository
+}
+
+// NewMockPinCodeRepository creates a new mock instance.
+func NewMockPinCodeRepository(ctrl *gomock.Controller) *MockPinCodeRepository {
+	mock := &MockPinCodeRepository{ctrl: ctrl}
+	mock.recorder = &MockPinCodeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPinCodeRepository) EXPECT() *MockPinCodeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeletePinCode mocks base method.
+func (m *MockPinCodeRepository) DeletePinCode(pin string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePinCode", pin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePinCode indicates an expected call of DeletePinCode.
+func (mr *MockPinCodeRepositoryMockRecorder) DeletePinCode(pin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePinCode", reflect.TypeOf((*MockPinCodeRepository)(nil).DeletePinCode), pin)
+}
+
+// GetPinCode mocks base method.
+func (m *MockPinCodeRepository) GetPinCode(pin string) (models.PinCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPinCode", pin)
+	ret0, _ := ret[0].(models.PinCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPinCode indicates an expected call of GetPinCode.
+func (mr *MockPinCodeRepositoryMockRecorder) GetPinCode(pin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPinCode", reflect.TypeOf((*MockPinCodeRepository)(nil).GetPinCode), pin)
+}
+
+// GetPinCodes mocks base method.
+func (m *MockPinCodeRepository) GetPinCodes(page, count int) ([]models.PinCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPinCodes", page, count)
+	ret0, _ := ret[0].([]models.PinCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPinCodes indicates an expected call of GetPinCodes.
+func (mr *MockPinCodeRepositoryMockRecorder) GetPinCodes(page, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPinCodes", reflect.TypeOf((*MockPinCodeRepository)(nil).GetPinCodes), page, count)
+}
+
+// SavePinCodes mocks base method.
+func (m *MockPinCodeRepository) SavePinCodes(pins []models.PinCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePinCodes", pins)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePinCodes indicates an expected call of SavePinCodes.
+func (mr *MockPinCodeRepositoryMockRecorder) SavePinCodes(pins interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePinCodes", reflect.TypeOf((*MockPinCodeRepository)(nil).SavePinCodes), pins)
+}
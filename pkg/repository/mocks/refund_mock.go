@@ -0,0 +1,182 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repository/interface/refund.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/ahdaan98/pkg/utils/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRefundRepository is a mock of RefundRepository interface.
+type MockRefundRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefundRepositoryMockRecorder
+}
+
+// MockRefundRepositoryMockRecorder is the mock recorder for MockRefundRepository.
+type MockRefundRepositoryMockRecorder struct {
+	mock *MockRefundRepository
+}
+
+// NewMockRefundRepository creates a new mock instance.
+func NewMockRefundRepository(ctrl *gomock.Controller) *MockRefundRepository {
+	mock := &MockRefundRepository{ctrl: ctrl}
+	mock.recorder = &MockRefundRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefundRepository) EXPECT() *MockRefundRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddRefund mocks base method.
+func (m *MockRefundRepository) AddRefund(refund models.RefundDetails) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRefund", refund)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddRefund indicates an expected call of AddRefund.
+func (mr *MockRefundRepositoryMockRecorder) AddRefund(refund interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRefund", reflect.TypeOf((*MockRefundRepository)(nil).AddRefund), refund)
+}
+
+// AddRefundAttempt mocks base method.
+func (m *MockRefundRepository) AddRefundAttempt(refundID int, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRefundAttempt", refundID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRefundAttempt indicates an expected call of AddRefundAttempt.
+func (mr *MockRefundRepositoryMockRecorder) AddRefundAttempt(refundID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRefundAttempt", reflect.TypeOf((*MockRefundRepository)(nil).AddRefundAttempt), refundID, reason)
+}
+
+// ClaimRefund mocks base method.
+func (m *MockRefundRepository) ClaimRefund(refundID int) (models.RefundDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimRefund", refundID)
+	ret0, _ := ret[0].(models.RefundDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimRefund indicates an expected call of ClaimRefund.
+func (mr *MockRefundRepositoryMockRecorder) ClaimRefund(refundID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimRefund", reflect.TypeOf((*MockRefundRepository)(nil).ClaimRefund), refundID)
+}
+
+// CountPendingRefunds mocks base method.
+func (m *MockRefundRepository) CountPendingRefunds(orderID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPendingRefunds", orderID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPendingRefunds indicates an expected call of CountPendingRefunds.
+func (mr *MockRefundRepositoryMockRecorder) CountPendingRefunds(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPendingRefunds", reflect.TypeOf((*MockRefundRepository)(nil).CountPendingRefunds), orderID)
+}
+
+// GetOrderRefunds mocks base method.
+func (m *MockRefundRepository) GetOrderRefunds(orderID int) ([]models.RefundDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderRefunds", orderID)
+	ret0, _ := ret[0].([]models.RefundDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderRefunds indicates an expected call of GetOrderRefunds.
+func (mr *MockRefundRepositoryMockRecorder) GetOrderRefunds(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderRefunds", reflect.TypeOf((*MockRefundRepository)(nil).GetOrderRefunds), orderID)
+}
+
+// GetPendingGatewayRefunds mocks base method.
+func (m *MockRefundRepository) GetPendingGatewayRefunds(limit int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingGatewayRefunds", limit)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingGatewayRefunds indicates an expected call of GetPendingGatewayRefunds.
+func (mr *MockRefundRepositoryMockRecorder) GetPendingGatewayRefunds(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingGatewayRefunds", reflect.TypeOf((*MockRefundRepository)(nil).GetPendingGatewayRefunds), limit)
+}
+
+// LockGatewayRefund mocks base method.
+func (m *MockRefundRepository) LockGatewayRefund(gatewayRefundID string) (models.RefundDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockGatewayRefund", gatewayRefundID)
+	ret0, _ := ret[0].(models.RefundDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockGatewayRefund indicates an expected call of LockGatewayRefund.
+func (mr *MockRefundRepositoryMockRecorder) LockGatewayRefund(gatewayRefundID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockGatewayRefund", reflect.TypeOf((*MockRefundRepository)(nil).LockGatewayRefund), gatewayRefundID)
+}
+
+// LockRefund mocks base method.
+func (m *MockRefundRepository) LockRefund(refundID int) (models.RefundDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockRefund", refundID)
+	ret0, _ := ret[0].(models.RefundDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockRefund indicates an expected call of LockRefund.
+func (mr *MockRefundRepositoryMockRecorder) LockRefund(refundID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockRefund", reflect.TypeOf((*MockRefundRepository)(nil).LockRefund), refundID)
+}
+
+// SetGatewayRefund mocks base method.
+func (m *MockRefundRepository) SetGatewayRefund(refundID int, gatewayRefundID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGatewayRefund", refundID, gatewayRefundID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetGatewayRefund indicates an expected call of SetGatewayRefund.
+func (mr *MockRefundRepositoryMockRecorder) SetGatewayRefund(refundID, gatewayRefundID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGatewayRefund", reflect.TypeOf((*MockRefundRepository)(nil).SetGatewayRefund), refundID, gatewayRefundID)
+}
+
+// UpdateRefundStatus mocks base method.
+func (m *MockRefundRepository) UpdateRefundStatus(refundID int, status, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRefundStatus", refundID, status, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRefundStatus indicates an expected call of UpdateRefundStatus.
+func (mr *MockRefundRepositoryMockRecorder) UpdateRefundStatus(refundID, status, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRefundStatus", reflect.TypeOf((*MockRefundRepository)(nil).UpdateRefundStatus), refundID, status, reason)
+}
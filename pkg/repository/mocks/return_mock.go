@@ -0,0 +1,226 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repository/interface/return.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/ahdaan98/pkg/utils/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReturnRepository is a mock of ReturnRepository interface.
+type MockReturnRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnRepositoryMockRecorder
+}
+
+// MockReturnRepositoryMockRecorder is the mock recorder for MockReturnRepository.
+type MockReturnRepositoryMockRecorder struct {
+	mock *MockReturnRepository
+}
+
+// NewMockReturnRepository creates a new mock instance.
+func NewMockReturnRepository(ctrl *gomock.Controller) *MockReturnRepository {
+	mock := &MockReturnRepository{ctrl: ctrl}
+	mock.recorder = &MockReturnRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnRepository) EXPECT() *MockReturnRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddReturnImage mocks base method.
+func (m *MockReturnRepository) AddReturnImage(returnID int, image string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReturnImage", returnID, image)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReturnImage indicates an expected call of AddReturnImage.
+func (mr *MockReturnRepositoryMockRecorder) AddReturnImage(returnID, image interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReturnImage", reflect.TypeOf((*MockReturnRepository)(nil).AddReturnImage), returnID, image)
+}
+
+// AddReturnItem mocks base method.
+func (m *MockReturnRepository) AddReturnItem(returnID, orderItemID, quantity, damagedQuantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReturnItem", returnID, orderItemID, quantity, damagedQuantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReturnItem indicates an expected call of AddReturnItem.
+func (mr *MockReturnRepositoryMockRecorder) AddReturnItem(returnID, orderItemID, quantity, damagedQuantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReturnItem", reflect.TypeOf((*MockReturnRepository)(nil).AddReturnItem), returnID, orderItemID, quantity, damagedQuantity)
+}
+
+// CreateReturnRequest mocks base method.
+func (m *MockReturnRepository) CreateReturnRequest(orderID, userID int, reason, refundTo string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReturnRequest", orderID, userID, reason, refundTo)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReturnRequest indicates an expected call of CreateReturnRequest.
+func (mr *MockReturnRepositoryMockRecorder) CreateReturnRequest(orderID, userID, reason, refundTo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReturnRequest", reflect.TypeOf((*MockReturnRepository)(nil).CreateReturnRequest), orderID, userID, reason, refundTo)
+}
+
+// GetReturnImages mocks base method.
+func (m *MockReturnRepository) GetReturnImages(returnID int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturnImages", returnID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturnImages indicates an expected call of GetReturnImages.
+func (mr *MockReturnRepositoryMockRecorder) GetReturnImages(returnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturnImages", reflect.TypeOf((*MockReturnRepository)(nil).GetReturnImages), returnID)
+}
+
+// GetReturnItems mocks base method.
+func (m *MockReturnRepository) GetReturnItems(returnID int) ([]models.ReturnItemDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturnItems", returnID)
+	ret0, _ := ret[0].([]models.ReturnItemDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturnItems indicates an expected call of GetReturnItems.
+func (mr *MockReturnRepositoryMockRecorder) GetReturnItems(returnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturnItems", reflect.TypeOf((*MockReturnRepository)(nil).GetReturnItems), returnID)
+}
+
+// GetReturnRequest mocks base method.
+func (m *MockReturnRepository) GetReturnRequest(returnID int) (models.ReturnRequestDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturnRequest", returnID)
+	ret0, _ := ret[0].(models.ReturnRequestDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturnRequest indicates an expected call of GetReturnRequest.
+func (mr *MockReturnRepositoryMockRecorder) GetReturnRequest(returnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturnRequest", reflect.TypeOf((*MockReturnRepository)(nil).GetReturnRequest), returnID)
+}
+
+// GetReturnRequests mocks base method.
+func (m *MockReturnRepository) GetReturnRequests(status string, page int) ([]models.ReturnRequestDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturnRequests", status, page)
+	ret0, _ := ret[0].([]models.ReturnRequestDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturnRequests indicates an expected call of GetReturnRequests.
+func (mr *MockReturnRepositoryMockRecorder) GetReturnRequests(status, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturnRequests", reflect.TypeOf((*MockReturnRepository)(nil).GetReturnRequests), status, page)
+}
+
+// GetUserReturnRequests mocks base method.
+func (m *MockReturnRepository) GetUserReturnRequests(userID int) ([]models.ReturnRequestDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserReturnRequests", userID)
+	ret0, _ := ret[0].([]models.ReturnRequestDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserReturnRequests indicates an expected call of GetUserReturnRequests.
+func (mr *MockReturnRepositoryMockRecorder) GetUserReturnRequests(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserReturnRequests", reflect.TypeOf((*MockReturnRepository)(nil).GetUserReturnRequests), userID)
+}
+
+// LockReturnRequest mocks base method.
+func (m *MockReturnRepository) LockReturnRequest(returnID int) (models.ReturnRequestDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockReturnRequest", returnID)
+	ret0, _ := ret[0].(models.ReturnRequestDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockReturnRequest indicates an expected call of LockReturnRequest.
+func (mr *MockReturnRepositoryMockRecorder) LockReturnRequest(returnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockReturnRequest", reflect.TypeOf((*MockReturnRepository)(nil).LockReturnRequest), returnID)
+}
+
+// PendingReturnQuantities mocks base method.
+func (m *MockReturnRepository) PendingReturnQuantities(orderID int) ([]models.OrderItemQuantity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingReturnQuantities", orderID)
+	ret0, _ := ret[0].([]models.OrderItemQuantity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingReturnQuantities indicates an expected call of PendingReturnQuantities.
+func (mr *MockReturnRepositoryMockRecorder) PendingReturnQuantities(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingReturnQuantities", reflect.TypeOf((*MockReturnRepository)(nil).PendingReturnQuantities), orderID)
+}
+
+// SchedulePickup mocks base method.
+func (m *MockReturnRepository) SchedulePickup(returnID int, pickupDate time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulePickup", returnID, pickupDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SchedulePickup indicates an expected call of SchedulePickup.
+func (mr *MockReturnRepositoryMockRecorder) SchedulePickup(returnID, pickupDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePickup", reflect.TypeOf((*MockReturnRepository)(nil).SchedulePickup), returnID, pickupDate)
+}
+
+// SetReturnItemDamage mocks base method.
+func (m *MockReturnRepository) SetReturnItemDamage(returnItemID, damagedQuantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReturnItemDamage", returnItemID, damagedQuantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReturnItemDamage indicates an expected call of SetReturnItemDamage.
+func (mr *MockReturnRepositoryMockRecorder) SetReturnItemDamage(returnItemID, damagedQuantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReturnItemDamage", reflect.TypeOf((*MockReturnRepository)(nil).SetReturnItemDamage), returnItemID, damagedQuantity)
+}
+
+// UpdateReturnStatus mocks base method.
+func (m *MockReturnRepository) UpdateReturnStatus(returnID int, status, note string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReturnStatus", returnID, status, note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReturnStatus indicates an expected call of UpdateReturnStatus.
+func (mr *MockReturnRepositoryMockRecorder) UpdateReturnStatus(returnID, status, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReturnStatus", reflect.TypeOf((*MockReturnRepository)(nil).UpdateReturnStatus), returnID, status, note)
+}
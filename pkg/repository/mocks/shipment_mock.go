@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repository/interface/shipment.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/ahdaan98/pkg/utils/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockShipmentRepository is a mock of ShipmentRepository interface.
+type MockShipmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShipmentRepositoryMockRecorder
+}
+
+// MockShipmentRepositoryMockRecorder is the mock recorder for MockShipmentRepository.
+type MockShipmentRepositoryMockRecorder struct {
+	mock *MockShipmentRepository
+}
+
+// NewMockShipmentRepository creates a new mock instance.
+func NewMockShipmentRepository(ctrl *gomock.Controller) *MockShipmentRepository {
+	mock := &MockShipmentRepository{ctrl: ctrl}
+	mock.recorder = &MockShipmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShipmentRepository) EXPECT() *MockShipmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddShipmentEvent mocks base method.
+func (m *MockShipmentRepository) AddShipmentEvent(shipmentID int, event models.ShipmentEventState) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddShipmentEvent", shipmentID, event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddShipmentEvent indicates an expected call of AddShipmentEvent.
+func (mr *MockShipmentRepositoryMockRecorder) AddShipmentEvent(shipmentID, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddShipmentEvent", reflect.TypeOf((*MockShipmentRepository)(nil).AddShipmentEvent), shipmentID, event)
+}
+
+// CreateShipment mocks base method.
+func (m *MockShipmentRepository) CreateShipment(orderID int, shipment models.ShipmentRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShipment", orderID, shipment)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShipment indicates an expected call of CreateShipment.
+func (mr *MockShipmentRepositoryMockRecorder) CreateShipment(orderID, shipment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShipment", reflect.TypeOf((*MockShipmentRepository)(nil).CreateShipment), orderID, shipment)
+}
+
+// GetShipmentByOrder mocks base method.
+func (m *MockShipmentRepository) GetShipmentByOrder(orderID int) (models.ShipmentDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShipmentByOrder", orderID)
+	ret0, _ := ret[0].(models.ShipmentDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShipmentByOrder indicates an expected call of GetShipmentByOrder.
+func (mr *MockShipmentRepositoryMockRecorder) GetShipmentByOrder(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShipmentByOrder", reflect.TypeOf((*MockShipmentRepository)(nil).GetShipmentByOrder), orderID)
+}
+
+// GetShipmentByTracking mocks base method.
+func (m *MockShipmentRepository) GetShipmentByTracking(carrier, trackingNumber string) (models.ShipmentDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShipmentByTracking", carrier, trackingNumber)
+	ret0, _ := ret[0].(models.ShipmentDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShipmentByTracking indicates an expected call of GetShipmentByTracking.
+func (mr *MockShipmentRepositoryMockRecorder) GetShipmentByTracking(carrier, trackingNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShipmentByTracking", reflect.TypeOf((*MockShipmentRepository)(nil).GetShipmentByTracking), carrier, trackingNumber)
+}
+
+// GetShipmentEvents mocks base method.
+func (m *MockShipmentRepository) GetShipmentEvents(shipmentID int) ([]models.ShipmentEventState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShipmentEvents", shipmentID)
+	ret0, _ := ret[0].([]models.ShipmentEventState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShipmentEvents indicates an expected call of GetShipmentEvents.
+func (mr *MockShipmentRepositoryMockRecorder) GetShipmentEvents(shipmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShipmentEvents", reflect.TypeOf((*MockShipmentRepository)(nil).GetShipmentEvents), shipmentID)
+}
+
+// RefreshShipmentStatus mocks base method.
+func (m *MockShipmentRepository) RefreshShipmentStatus(shipmentID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshShipmentStatus", shipmentID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshShipmentStatus indicates an expected call of RefreshShipmentStatus.
+func (mr *MockShipmentRepositoryMockRecorder) RefreshShipmentStatus(shipmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshShipmentStatus", reflect.TypeOf((*MockShipmentRepository)(nil).RefreshShipmentStatus), shipmentID)
+}
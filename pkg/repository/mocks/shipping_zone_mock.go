@@ -0,0 +1,137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repository/interface/shipping_zone.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/ahdaan98/pkg/utils/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockShippingZoneRepository is a mock of ShippingZoneRepository interface.
+type MockShippingZoneRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShippingZoneRepositoryMockRecorder
+}
+
+// MockShippingZoneRepositoryMockRecorder is the mock recorder for MockShippingZoneRepository.
+type MockShippingZoneRepositoryMockRecorder struct {
+	mock *MockShippingZoneRepository
+}
+
+// NewMockShippingZoneRepository creates a new mock instance.
+func NewMockShippingZoneRepository(ctrl *gomock.Controller) *MockShippingZoneRepository {
+	mock := &MockShippingZoneRepository{ctrl: ctrl}
+	mock.recorder = &MockShippingZoneRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShippingZoneRepository) EXPECT() *MockShippingZoneRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateShippingZone mocks base method.
+func (m *MockShippingZoneRepository) CreateShippingZone(zone models.ShippingZoneRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShippingZone", zone)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShippingZone indicates an expected call of CreateShippingZone.
+func (mr *MockShippingZoneRepositoryMockRecorder) CreateShippingZone(zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShippingZone", reflect.TypeOf((*MockShippingZoneRepository)(nil).CreateShippingZone), zone)
+}
+
+// DeleteShippingZone mocks base method.
+func (m *MockShippingZoneRepository) DeleteShippingZone(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShippingZone", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShippingZone indicates an expected call of DeleteShippingZone.
+func (mr *MockShippingZoneRepositoryMockRecorder) DeleteShippingZone(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShippingZone", reflect.TypeOf((*MockShippingZoneRepository)(nil).DeleteShippingZone), id)
+}
+
+// FindShippingZone mocks base method.
+func (m *MockShippingZoneRepository) FindShippingZone(state string, pin int) (models.ShippingZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindShippingZone", state, pin)
+	ret0, _ := ret[0].(models.ShippingZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindShippingZone indicates an expected call of FindShippingZone.
+func (mr *MockShippingZoneRepositoryMockRecorder) FindShippingZone(state, pin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShippingZone", reflect.TypeOf((*MockShippingZoneRepository)(nil).FindShippingZone), state, pin)
+}
+
+// GetShippingZone mocks base method.
+func (m *MockShippingZoneRepository) GetShippingZone(id int) (models.ShippingZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShippingZone", id)
+	ret0, _ := ret[0].(models.ShippingZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShippingZone indicates an expected call of GetShippingZone.
+func (mr *MockShippingZoneRepositoryMockRecorder) GetShippingZone(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShippingZone", reflect.TypeOf((*MockShippingZoneRepository)(nil).GetShippingZone), id)
+}
+
+// GetShippingZones mocks base method.
+func (m *MockShippingZoneRepository) GetShippingZones() ([]models.ShippingZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShippingZones")
+	ret0, _ := ret[0].([]models.ShippingZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShippingZones indicates an expected call of GetShippingZones.
+func (mr *MockShippingZoneRepositoryMockRecorder) GetShippingZones() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShippingZones", reflect.TypeOf((*MockShippingZoneRepository)(nil).GetShippingZones))
+}
+
+// SetShippingZoneRules mocks base method.
+func (m *MockShippingZoneRepository) SetShippingZoneRules(id int, areas []models.ShippingArea, rates []models.ShippingRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetShippingZoneRules", id, areas, rates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetShippingZoneRules indicates an expected call of SetShippingZoneRules.
+func (mr *MockShippingZoneRepositoryMockRecorder) SetShippingZoneRules(id, areas, rates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetShippingZoneRules", reflect.TypeOf((*MockShippingZoneRepository)(nil).SetShippingZoneRules), id, areas, rates)
+}
+
+// UpdateShippingZone mocks base method.
+func (m *MockShippingZoneRepository) UpdateShippingZone(id int, zone models.ShippingZoneRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShippingZone", id, zone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateShippingZone indicates an expected call of UpdateShippingZone.
+func (mr *MockShippingZoneRepositoryMockRecorder) UpdateShippingZone(id, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShippingZone", reflect.TypeOf((*MockShippingZoneRepository)(nil).UpdateShippingZone), id, zone)
+}
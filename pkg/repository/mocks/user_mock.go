@@ -1,7 +1,7 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: C:\Users\ahdaa\GOLANG\Leuse_Ecommerce\pkg\repository\interface\user.go
+// Source: pkg/repository/interface/user.go
 
-// Package mock_interfaces is a generated GoMock package.
+// Package mocks is a generated GoMock package.
 package mocks
 
 import (
@@ -303,10 +303,10 @@ func (mr *MockUserRepositoryMockRecorder) GetCartID(id interface{}) *gomock.Call
 }
 
 // GetProductsInCart mocks base method.
-func (m *MockUserRepository) GetProductsInCart(cart_id int) ([]int, error) {
+func (m *MockUserRepository) GetProductsInCart(cart_id int) ([]models.CartProduct, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetProductsInCart", cart_id)
-	ret0, _ := ret[0].([]int)
+	ret0, _ := ret[0].([]models.CartProduct)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repository/interface/wallet.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/ahdaan98/pkg/utils/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWalletRepository is a mock of WalletRepository interface.
+type MockWalletRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletRepositoryMockRecorder
+}
+
+// MockWalletRepositoryMockRecorder is the mock recorder for MockWalletRepository.
+type MockWalletRepositoryMockRecorder struct {
+	mock *MockWalletRepository
+}
+
+// NewMockWalletRepository creates a new mock instance.
+func NewMockWalletRepository(ctrl *gomock.Controller) *MockWalletRepository {
+	mock := &MockWalletRepository{ctrl: ctrl}
+	mock.recorder = &MockWalletRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletRepository) EXPECT() *MockWalletRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddToWallet mocks base method.
+func (m *MockWalletRepository) AddToWallet(Price float64, UserId int) (models.WalletAmount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToWallet", Price, UserId)
+	ret0, _ := ret[0].(models.WalletAmount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddToWallet indicates an expected call of AddToWallet.
+func (mr *MockWalletRepositoryMockRecorder) AddToWallet(Price, UserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToWallet", reflect.TypeOf((*MockWalletRepository)(nil).AddToWallet), Price, UserId)
+}
+
+// GetWallet mocks base method.
+func (m *MockWalletRepository) GetWallet(userID int) (models.WalletAmount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallet", userID)
+	ret0, _ := ret[0].(models.WalletAmount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallet indicates an expected call of GetWallet.
+func (mr *MockWalletRepositoryMockRecorder) GetWallet(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallet", reflect.TypeOf((*MockWalletRepository)(nil).GetWallet), userID)
+}
@@ -6,6 +6,7 @@ import (
 	interfaces "github.com/ahdaan98/pkg/repository/interface"
 	"errors"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -20,34 +21,51 @@ func NewOrderRepository(db *gorm.DB) interfaces.OrderRepository {
 	}
 }
 
-func (i *orderRepository) OrderItems(userid, addressid, paymentid int, total float64) (int, error) {
+func (i *orderRepository) OrderItems(userid, addressid, paymentid int, totals models.OrderTotals) (int, error) {
 
 	var id int
 	query := `
-    INSERT INTO orders (created_at,user_id,address_id, payment_method_id, final_price)
-    VALUES (Now(),?, ?, ?, ?)
+    INSERT INTO orders (created_at,user_id,address_id, payment_method_id, subtotal, discount, shipping_method, shipping, tax, final_price)
+    VALUES (Now(),?, ?, ?, ?, ?, ?, ?, ?, ?)
     RETURNING id
     `
-	i.DB.Raw(query, userid, addressid, paymentid, total).Scan(&id)
-	fmt.Println("id...........", id)
+	if err := i.DB.Raw(query, userid, addressid, paymentid, totals.Subtotal, totals.Discount, totals.ShippingMethod, totals.Shipping, totals.Tax, totals.GrandTotal).Scan(&id).Error; err != nil {
+		return 0, err
+	}
 	return id, nil
 
 }
 
-func (i *orderRepository) AddOrderProducts(order_id int, cart []models.GetCart) error {
+// AddOrderProducts stores the items of a new order together with a copy of
+// the product's name, SKU, brand and category as they are right now, and the
+// GST charged on each of them.
+func (i *orderRepository) AddOrderProducts(order_id int, lines []models.OrderItemLine) error {
 	query := `
-    INSERT INTO order_items (order_id,inventory_id,quantity,total_price)
-    VALUES (?, ?, ?, ?)
+    INSERT INTO order_items (order_id, inventory_id, quantity, total_price, status,
+    product_name, sku, brand_name, category_name, unit_price, discount, hsn_code, tax_rate, tax_amount)
+    SELECT ?, inv.id, ?, ?, ?, inv.product_name, inv.sku, COALESCE(b.brand_name, ''), COALESCE(c.category_name, ''), ?, ?, ?, ?, ?
+    FROM inventories inv
+    LEFT JOIN brands b ON b.id = inv.brand_id
+    LEFT JOIN categories c ON c.id = inv.category_id
+    WHERE inv.id = ?
+    RETURNING id
     `
 
-	for _, v := range cart {
-		var inv int
-		if err := i.DB.Raw("select id from inventories where product_name=$1", v.ProductName).Scan(&inv).Error; err != nil {
+	for _, v := range lines {
+		var ids []int
+		if err := i.DB.Raw(query, order_id, v.Quantity, v.TotalPrice, domain.OrderStatusPending, v.UnitPrice, v.Discount, v.HSNCode, v.TaxRate, v.TaxAmount, v.InventoryID).Scan(&ids).Error; err != nil {
 			return err
 		}
+		if len(ids) == 0 {
+			return errors.New("product does not exist")
+		}
 
-		if err := i.DB.Exec(query, order_id, inv, v.Quantity, v.Total).Error; err != nil {
-			return err
+		for _, tax := range v.Taxes {
+			err := i.DB.Exec("INSERT INTO order_item_taxes (order_item_id, component, rate, amount) VALUES (?, ?, ?, ?)",
+				ids[0], tax.Component, tax.Rate, tax.Amount).Error
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -55,18 +73,6 @@ func (i *orderRepository) AddOrderProducts(order_id int, cart []models.GetCart)
 
 }
 
-func (i *orderRepository) ReduceInventoryQuantity(productName string, quantity int) error {
-	query := `
-        UPDATE inventories
-        SET stock = stock - ?
-        WHERE product_name = ?
-    `
-	if err := i.DB.Exec(query, quantity, productName).Error; err != nil {
-		return err
-	}
-	return nil
-}
-
 func (i *orderRepository) GetOrders(orderID int) (domain.OrderResponse, error) {
 
 	var order domain.OrderResponse
@@ -80,45 +86,6 @@ func (i *orderRepository) GetOrders(orderID int) (domain.OrderResponse, error) {
 	return order, nil
 }
 
-func (i *orderRepository) OrderItemsInv(productNames []string, categoryIds []int, prices, quantities []int, totalPrices []float64, userID int, orderID int) error {
-	// Loop through the provided slices and perform necessary actions
-	for idx := range productNames {
-		orderInv := domain.OrderItemInv{
-			ProductName: productNames[idx],
-			Category_id: categoryIds[idx],
-			Quantity:    quantities[idx],
-			Price:       prices[idx],
-			Total:       totalPrices[idx],
-			UserID:      uint(userID),
-			OrderID:     uint(orderID),
-		}
-
-		fmt.Println("order id at repo ", orderID)
-		// Execute the SQL query
-		query := `
-			INSERT INTO order_item_invs (product_name, category_id, quantity, price, total, user_id, order_id)
-			VALUES (?, ?, ?, ?, ?, ?, ?)
-		`
-
-		result := i.DB.Exec(
-			query,
-			orderInv.ProductName,
-			orderInv.Category_id,
-			orderInv.Quantity,
-			orderInv.Price,
-			orderInv.Total,
-			orderInv.UserID,
-			orderInv.OrderID,
-		)
-
-		if result.Error != nil {
-			return result.Error
-		}
-	}
-
-	return nil
-}
-
 func (o *orderRepository) CheckOrderStatusByID(id int) (string, error) {
 
 	var status string
@@ -130,16 +97,6 @@ func (o *orderRepository) CheckOrderStatusByID(id int) (string, error) {
 	return status, nil
 }
 
-func (i *orderRepository) CancelOrder(id int) error {
-
-	if err := i.DB.Exec("update orders set order_status='CANCELED' where id=$1", id).Error; err != nil {
-		return err
-	}
-
-	return nil
-
-}
-
 func (i *orderRepository) GetAllOrders(userID, page, pageSize int) ([]models.OrderDetails, error) {
 	if page == 0 {
 		page = 1
@@ -147,7 +104,10 @@ func (i *orderRepository) GetAllOrders(userID, page, pageSize int) ([]models.Ord
 	offset := (page - 1) * pageSize
 	var order []models.OrderDetails
 
-	err := i.DB.Raw("SELECT id as order_id, address_id, payment_method_id, final_price as price, order_status, payment_status FROM orders WHERE user_id = ? OFFSET ? LIMIT ?", userID, offset, pageSize).Scan(&order).Error
+	err := i.DB.Raw(`
+	SELECT id as order_id, address_id, payment_method_id, final_price as price, order_status, payment_status,
+	CASE WHEN order_status = ? AND payment_status = ? THEN payment_expires_at END AS payment_expires_at
+	FROM orders WHERE user_id = ? OFFSET ? LIMIT ?`, domain.OrderStatusPending, domain.PaymentStatusNotPaid, userID, offset, pageSize).Scan(&order).Error
 	if err != nil {
 		return nil, err
 	}
@@ -155,30 +115,105 @@ func (i *orderRepository) GetAllOrders(userID, page, pageSize int) ([]models.Ord
 	return order, nil
 }
 
-func (o *orderRepository) GetOrderDetailsBrief(page int) ([]models.CombinedOrderDetails, error) {
+// orderSortColumns maps the sort keys admins can use to ORDER BY clauses. A
+// leading "-" sorts descending; the order id breaks ties.
+var orderSortColumns = map[string]string{
+	"created_at":  "orders.created_at ASC, orders.id ASC",
+	"-created_at": "orders.created_at DESC, orders.id DESC",
+	"amount":      "orders.final_price ASC, orders.id ASC",
+	"-amount":     "orders.final_price DESC, orders.id DESC",
+	"status":      "orders.order_status ASC, orders.id DESC",
+	"-status":     "orders.order_status DESC, orders.id DESC",
+	"name":        "users.name ASC, orders.id DESC",
+	"-name":       "users.name DESC, orders.id DESC",
+}
 
-	if page == 0 {
-		page = 1
+// SearchOrders lists one page of the orders matching query, along with how
+// many orders match in total.
+func (o *orderRepository) SearchOrders(query models.AdminOrderQuery) ([]models.AdminOrderSummary, int64, error) {
+	sort := query.Sort
+	if sort == "" {
+		sort = "-created_at"
+	}
+	orderBy, ok := orderSortColumns[sort]
+	if !ok {
+		return nil, 0, errors.New("cannot sort orders by " + query.Sort)
 	}
-	offset := (page - 1) * 3
 
-	var orderDetails []models.CombinedOrderDetails
+	db := o.filterOrders(query)
 
-	err := o.DB.Raw(`
-	SELECT orders.id AS order_id, orders.final_price, orders.order_status, orders.payment_status, 
-	users.name, users.email, users.phone, addresses.house_name, addresses.state, 
-	addresses.pin, addresses.street, addresses.city 
-	FROM orders 
-	INNER JOIN users ON orders.user_id = users.id 
-	INNER JOIN addresses ON users.id = addresses.user_id 
-	LIMIT ? OFFSET ?
-`, 2, offset).Scan(&orderDetails).Error
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
 
+	var orders []models.AdminOrderSummary
+	err := db.Select(`orders.id AS order_id, orders.created_at, orders.user_id, users.name, users.email, users.phone,
+	payment_methods.payment_name AS payment_method, orders.final_price, orders.order_status, orders.payment_status,
+	addresses.house_name, addresses.street, addresses.city, addresses.state, addresses.pin`).
+		Order(orderBy).
+		Limit(query.PageSize).
+		Offset((query.Page - 1) * query.PageSize).
+		Scan(&orders).Error
 	if err != nil {
-		return []models.CombinedOrderDetails{}, nil
+		return nil, 0, err
 	}
 
-	return orderDetails, nil
+	return orders, total, nil
+}
+
+// filterOrders builds the joins and conditions shared by the admin order
+// queries.
+func (o *orderRepository) filterOrders(query models.AdminOrderQuery) *gorm.DB {
+	db := o.DB.Table("orders").
+		Joins("INNER JOIN users ON users.id = orders.user_id").
+		Joins("LEFT JOIN addresses ON addresses.id = orders.address_id").
+		Joins("LEFT JOIN payment_methods ON payment_methods.id = orders.payment_method_id").
+		Where("orders.deleted_at IS NULL")
+
+	if query.OrderStatus != "" {
+		db = db.Where("orders.order_status = ?", query.OrderStatus)
+	}
+	if query.PaymentStatus != "" {
+		db = db.Where("orders.payment_status = ?", query.PaymentStatus)
+	}
+	if query.PaymentMethodID != 0 {
+		db = db.Where("orders.payment_method_id = ?", query.PaymentMethodID)
+	}
+	if query.UserID != 0 {
+		db = db.Where("orders.user_id = ?", query.UserID)
+	}
+	if query.From != nil {
+		db = db.Where("orders.created_at >= ?", *query.From)
+	}
+	if query.Before != nil {
+		db = db.Where("orders.created_at < ?", *query.Before)
+	}
+	if query.MinAmount != nil {
+		db = db.Where("orders.final_price >= ?", *query.MinAmount)
+	}
+	if query.MaxAmount != nil {
+		db = db.Where("orders.final_price <= ?", *query.MaxAmount)
+	}
+	if query.Search != "" {
+		term := "%" + query.Search + "%"
+		db = db.Where("users.name ILIKE ? OR users.email ILIKE ? OR users.phone ILIKE ?", term, term, term)
+	}
+
+	return db
+}
+
+// SearchOrderIDs lists the ids of up to limit orders matching query, oldest
+// first.
+func (o *orderRepository) SearchOrderIDs(query models.AdminOrderQuery, limit int) ([]int, error) {
+	var ids []int
+
+	err := o.filterOrders(query).Order("orders.id").Limit(limit).Pluck("orders.id", &ids).Error
+	if err != nil {
+		return []int{}, err
+	}
+
+	return ids, nil
 }
 
 // CheckOrdersStatusByID retrieves the order status by ID
@@ -191,16 +226,6 @@ func (o *orderRepository) CheckOrdersStatusByID(id int) (string, error) {
 	return status, nil
 }
 
-// GetShipmentStatus retrieves the shipment status by order ID
-func (i *orderRepository) GetShipmentStatus(orderID int) (string, error) {
-	var shipmentStatus string
-	err := i.DB.Exec("UPDATE orders SET order_status = 'DELIVERED', payment_status = 'PAID' WHERE id = ?", orderID).Error
-	if err != nil {
-		return "", err
-	}
-	return shipmentStatus, nil
-}
-
 func (i *orderRepository) GetOrderStatus(orderID int) (string, error) {
 	var shipmentStatus string
 	err := i.DB.Raw("SELECT order_status FROM orders WHERE id = ?", orderID).Scan(&shipmentStatus).Error
@@ -210,24 +235,6 @@ func (i *orderRepository) GetOrderStatus(orderID int) (string, error) {
 	return shipmentStatus, nil
 }
 
-// ApproveOrder updates the order status to 'order_placed' for the provided order ID
-func (i *orderRepository) ApproveOrder(orderID string) error {
-	err := i.DB.Exec("UPDATE orders SET order_status = 'order_placed' WHERE id = ?", orderID).Error
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-// ChangeOrderStatus updates the order status for the provided order ID
-func (i *orderRepository) ChangeOrderStatus(orderID int, status string) error {
-	err := i.DB.Exec("UPDATE orders SET order_status = ? WHERE id = ?", status, orderID).Error
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
 func (o *orderRepository) GetShipmentsStatus(orderID int) (string, error) {
 
 	var shipmentStatus string
@@ -240,17 +247,6 @@ func (o *orderRepository) GetShipmentsStatus(orderID int) (string, error) {
 
 }
 
-func (o *orderRepository) ReturnOrder(shipmentStatus string, orderID int) error {
-
-	err := o.DB.Exec("update orders set order_status = ?, payment_status = 'RETURNED TO WALLET' where id = ?", shipmentStatus, orderID).Error
-	if err != nil {
-		return err
-	}
-
-	return nil
-
-}
-
 func (o *orderRepository) GetOrderDetailsByOrderId(orderID string) (models.CombinedOrderDetails, error) {
 	var orderDetails models.CombinedOrderDetails
 
@@ -344,6 +340,10 @@ func (repo *orderRepository) GetDetailedOrderThroughId(orderId int) (models.Comb
 	query := `
 	SELECT 
         o.id AS order_id,
+        o.subtotal AS subtotal,
+        o.discount AS discount,
+        o.shipping AS shipping,
+        o.tax AS tax,
         o.final_price AS final_price,
         o.order_status AS order_status,
         o.payment_status AS payment_status,
@@ -354,7 +354,9 @@ func (repo *orderRepository) GetDetailedOrderThroughId(orderId int) (models.Comb
         a.state AS state,
         a.pin AS pin,
         a.street AS street,
-        a.city AS city
+        a.city AS city,
+        o.shipping_method AS shipping_method,
+        o.created_at AS order_date
 	FROM orders o
 	JOIN users u ON o.user_id = u.id
 	JOIN addresses a ON o.address_id = a.id 
@@ -398,26 +400,6 @@ func (i *orderRepository) FindUserID(orderID int) (int, error) {
 	return status, err
 }
 
-func (i *orderRepository) UpdateOrder(orderID int) ([]models.CombinedOrderDetails, error) {
-	var body []models.CombinedOrderDetails
-
-	err := i.DB.Exec("UPDATE orders SET order_status ='CANCELED', payment_status = 'RETURNED TO WALLET' WHERE id = ?", orderID).Error
-	if err != nil {
-		return nil, err
-	}
-	return body, nil
-}
-
-func (i *orderRepository) UpdateReturnedOrder(orderID int) ([]models.CombinedOrderDetails, error) {
-	var body []models.CombinedOrderDetails
-
-	err := i.DB.Exec("UPDATE orders SET order_status ='RETURNED', payment_status = 'RETURNED TO WALLET' WHERE id = ?", orderID).Error
-	if err != nil {
-		return nil, err
-	}
-	return body, nil
-}
-
 func (o *orderRepository) CheckOrderStatusByOrderId(orderID int) (string, error) {
 
 	var status string
@@ -457,10 +439,12 @@ func (o *orderRepository) GetItemsByOrderId(orderId int) ([]models.ItemDetails,
 	var items []models.ItemDetails
 
 	query := `
-	SELECT oi.id AS order_item_id, oi.product_name, oi.quantity, oi.price, oi.total, o.id AS order_id, o.created_at, o.updated_at, o.final_price, o.order_status, o.payment_status
+	SELECT oi.id AS order_item_id, oi.product_name, oi.sku, oi.hsn_code, oi.quantity, oi.unit_price AS price, oi.total_price AS total, oi.discount, oi.tax_rate, oi.tax_amount,
+	o.id AS order_id, o.created_at, o.updated_at, o.final_price, o.order_status, o.payment_status
 	FROM orders o
-	JOIN order_item_invs oi ON o.id = oi.order_id
-	WHERE o.id = ?;
+	JOIN order_items oi ON o.id = oi.order_id
+	WHERE o.id = ?
+	ORDER BY oi.id;
 	`
 
 	if err := o.DB.Raw(query, orderId).Scan(&items).Error; err != nil {
@@ -468,4 +452,203 @@ func (o *orderRepository) GetItemsByOrderId(orderId int) ([]models.ItemDetails,
 	}
 
 	return items, nil
-}
\ No newline at end of file
+}
+
+// GetOrderItemTaxes reads the GST components charged on the items of an order.
+func (o *orderRepository) GetOrderItemTaxes(orderID int) ([]models.OrderItemTax, error) {
+	var taxes []models.OrderItemTax
+
+	query := `
+	SELECT t.order_item_id, t.component, t.rate, t.amount
+	FROM order_item_taxes t
+	JOIN order_items oi ON oi.id = t.order_item_id
+	WHERE oi.order_id = ?
+	ORDER BY t.order_item_id, t.id
+	`
+	if err := o.DB.Raw(query, orderID).Scan(&taxes).Error; err != nil {
+		return nil, err
+	}
+
+	return taxes, nil
+}
+
+// LockOrderState reads the current status of an order and holds a row lock on
+// it until the surrounding transaction ends.
+func (o *orderRepository) LockOrderState(orderID int) (models.OrderState, error) {
+	var state models.OrderState
+
+	query := `
+	SELECT id AS order_id, user_id, order_status, payment_status, final_price
+	FROM orders
+	WHERE id = ?
+	FOR UPDATE
+	`
+	result := o.DB.Raw(query, orderID).Scan(&state)
+	if result.Error != nil {
+		return models.OrderState{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.OrderState{}, errors.New("no order exists")
+	}
+
+	return state, nil
+}
+
+func (o *orderRepository) UpdateOrderState(orderID int, orderStatus, paymentStatus string) error {
+	err := o.DB.Exec("UPDATE orders SET order_status = ?, payment_status = ?, updated_at = NOW() WHERE id = ?", orderStatus, paymentStatus, orderID).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReduceFinalPrice takes amount off what is due for an order that has not been
+// paid yet.
+func (o *orderRepository) ReduceFinalPrice(orderID int, amount float64) error {
+	return o.DB.Exec("UPDATE orders SET final_price = final_price - ?, updated_at = NOW() WHERE id = ?", amount, orderID).Error
+}
+
+func (o *orderRepository) AddStatusHistory(history domain.OrderStatusHistory) error {
+	query := `
+	INSERT INTO order_status_history (order_id, previous_order_status, order_status, previous_payment_status, payment_status, actor_role, actor_id, reason, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, NOW())
+	`
+	err := o.DB.Exec(query, history.OrderID, history.PreviousOrderStatus, history.OrderStatus, history.PreviousPaymentStatus, history.PaymentStatus, history.ActorRole, history.ActorID, history.Reason).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o *orderRepository) GetStatusHistory(orderID int) ([]domain.OrderStatusHistory, error) {
+	var history []domain.OrderStatusHistory
+
+	err := o.DB.Raw("SELECT * FROM order_status_history WHERE order_id = ? ORDER BY created_at, id", orderID).Scan(&history).Error
+	if err != nil {
+		return []domain.OrderStatusHistory{}, err
+	}
+
+	return history, nil
+}
+
+// LockOrderItems reads the items of an order ordered by inventory id and holds
+// row locks on them until the surrounding transaction ends.
+func (o *orderRepository) LockOrderItems(orderID int) ([]models.OrderItemState, error) {
+	var items []models.OrderItemState
+
+	query := `
+	SELECT id, inventory_id, product_name, quantity, total_price, status, canceled_quantity, returned_quantity, damaged_quantity, refund_amount
+	FROM order_items
+	WHERE order_id = ?
+	ORDER BY inventory_id, id
+	FOR UPDATE
+	`
+	if err := o.DB.Raw(query, orderID).Scan(&items).Error; err != nil {
+		return []models.OrderItemState{}, err
+	}
+
+	return items, nil
+}
+
+func (o *orderRepository) GetOrderItemStates(orderID int) ([]models.OrderItemState, error) {
+	var items []models.OrderItemState
+
+	query := `
+	SELECT id, inventory_id, quantity, total_price, status, canceled_quantity, returned_quantity, damaged_quantity, refund_amount,
+	product_name, sku, brand_name, category_name, unit_price, discount, tax_amount
+	FROM order_items
+	WHERE order_id = ?
+	ORDER BY id
+	`
+	if err := o.DB.Raw(query, orderID).Scan(&items).Error; err != nil {
+		return []models.OrderItemState{}, err
+	}
+
+	return items, nil
+}
+
+func (o *orderRepository) UpdateOrderItem(item models.OrderItemState) error {
+	query := `
+	UPDATE order_items
+	SET status = ?, canceled_quantity = ?, returned_quantity = ?, damaged_quantity = ?, refund_amount = ?
+	WHERE id = ?
+	`
+	err := o.DB.Exec(query, item.Status, item.CanceledQuantity, item.ReturnedQuantity, item.DamagedQuantity, item.RefundAmount, item.ID).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetOpenItemsStatus moves every item of an order that is not canceled or
+// returned to status.
+func (o *orderRepository) SetOpenItemsStatus(orderID int, status string) error {
+	query := `
+	UPDATE order_items
+	SET status = ?
+	WHERE order_id = ? AND status NOT IN (?, ?)
+	`
+	err := o.DB.Exec(query, status, orderID, domain.OrderStatusCanceled, domain.OrderStatusReturned).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o *orderRepository) GetPaymentMethodByID(paymentMethodID int) (models.PaymentMethodResponse, error) {
+	var method models.PaymentMethodResponse
+	err := o.DB.Raw("SELECT id, payment_name, kind FROM payment_methods WHERE id = ?", paymentMethodID).Scan(&method).Error
+	if err != nil {
+		return models.PaymentMethodResponse{}, err
+	}
+	return method, nil
+}
+
+func (o *orderRepository) SetPaymentExpiry(orderID int, expiresAt time.Time) error {
+	err := o.DB.Exec("UPDATE orders SET payment_expires_at = ? WHERE id = ?", expiresAt, orderID).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetExpiredUnpaidOrders lists up to limit pending orders whose online payment
+// was not received before it expired, oldest first.
+func (o *orderRepository) GetExpiredUnpaidOrders(limit int) ([]int, error) {
+	var ids []int
+
+	query := `
+	SELECT id
+	FROM orders
+	WHERE order_status = ? AND payment_status = ? AND payment_expires_at < NOW()
+	ORDER BY payment_expires_at
+	LIMIT ?
+	`
+	err := o.DB.Raw(query, domain.OrderStatusPending, domain.PaymentStatusNotPaid, limit).Scan(&ids).Error
+	if err != nil {
+		return []int{}, err
+	}
+
+	return ids, nil
+}
+
+// GetReorderItems lists the products of an order with their current price and
+// stock, one line per product.
+func (o *orderRepository) GetReorderItems(orderID int) ([]models.ReorderItem, error) {
+	var items []models.ReorderItem
+
+	query := `
+	SELECT oi.inventory_id, COALESCE(i.product_name, MAX(oi.product_name), '') AS product_name, SUM(oi.quantity) AS quantity,
+	COALESCE(i.stock, 0) AS stock, COALESCE(i.price, 0) AS price, i.id IS NOT NULL AS available
+	FROM order_items oi
+	LEFT JOIN inventories i ON i.id = oi.inventory_id
+	WHERE oi.order_id = ?
+	GROUP BY oi.inventory_id, i.id, i.product_name, i.stock, i.price
+	ORDER BY MIN(oi.id)
+	`
+	if err := o.DB.Raw(query, orderID).Scan(&items).Error; err != nil {
+		return []models.ReorderItem{}, err
+	}
+
+	return items, nil
+}
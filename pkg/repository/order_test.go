@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ahdaan98/pkg/utils/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestSearchOrders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock DB: %v", err)
+	}
+	defer db.Close()
+
+	DB, _ := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	min := 100.0
+	query := models.AdminOrderQuery{
+		OrderStatus: "SHIPPED",
+		From:        &from,
+		MinAmount:   &min,
+		Search:      "anu",
+		Sort:        "-amount",
+		Page:        3,
+		PageSize:    10,
+	}
+
+	where := `WHERE orders.deleted_at IS NULL AND orders.order_status = \$1 AND orders.created_at >= \$2 AND orders.final_price >= \$3 AND \(users.name ILIKE \$4 OR users.email ILIKE \$5 OR users.phone ILIKE \$6\)`
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "orders" INNER JOIN users .* `+where).
+		WithArgs("SHIPPED", from, min, "%anu%", "%anu%", "%anu%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(21))
+	mock.ExpectQuery(`SELECT orders.id AS order_id, .* `+where+` ORDER BY orders.final_price DESC, orders.id DESC LIMIT \$7 OFFSET \$8`).
+		WithArgs("SHIPPED", from, min, "%anu%", "%anu%", "%anu%", 10, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"order_id", "name", "final_price"}).AddRow(7, "Anu", 450.0))
+
+	orders, total, err := NewOrderRepository(DB).SearchOrders(query)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(21), total)
+	assert.Equal(t, []models.AdminOrderSummary{{OrderID: 7, Name: "Anu", FinalPrice: 450}}, orders)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, _, err = NewOrderRepository(DB).SearchOrders(models.AdminOrderQuery{Sort: "password", Page: 1, PageSize: 10})
+	assert.EqualError(t, err, "cannot sort orders by password")
+}
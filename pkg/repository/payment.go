@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"github.com/ahdaan98/pkg/domain"
 	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	"github.com/ahdaan98/pkg/utils/models"
 	"errors"
 	"fmt"
 
@@ -20,22 +22,28 @@ func NewPaymentRepository(DB *gorm.DB) interfaces.PaymentRepository {
 
 // --------------------------------------- add payment details ----------------------------------------- \\
 
-func (repo *paymentRepositoryImpl) AddRazorPayDetails(orderId int, razorPayId string) error {
+// AddRazorPayDetails records a new payment attempt for a gateway order and
+// returns its id.
+func (repo *paymentRepositoryImpl) AddRazorPayDetails(orderId int, razorPayId string, amount int64) (int, error) {
+	var id int
+
 	query := `
-	insert into payments (order_id,razer_id) values($1,$2) 
+	INSERT INTO payment_attempts (order_id, gateway_order_id, amount, status, created_at, updated_at)
+	VALUES (?, ?, ?, ?, NOW(), NOW())
+	RETURNING id
 	`
-	if err := repo.DB.Exec(query, orderId, razorPayId).Error; err != nil {
+	if err := repo.DB.Raw(query, orderId, razorPayId, amount, domain.PaymentCreated).Scan(&id).Error; err != nil {
 		err = errors.New("error in inserting values to razor pay data table" + err.Error())
-		return err
+		return 0, err
 	}
-	return nil
+	return id, nil
 }
 
 // ---------------------------------------- update payment details ------------------------------------------- \\
 
 func (repo *paymentRepositoryImpl) UpdatePaymentDetails(orderId string, paymentId string) error {
 	fmt.Println("razerId,paymetnId", orderId, paymentId)
-	if err := repo.DB.Exec("update payments set payment = $1 where razer_id = $2", paymentId, orderId).Error; err != nil {
+	if err := repo.DB.Exec("update payment_attempts set payment_id = $1, status = $2, updated_at = NOW() where gateway_order_id = $3", paymentId, domain.PaymentCaptured, orderId).Error; err != nil {
 		err = errors.New("error in updating the razer pay table " + err.Error())
 		return err
 	}
@@ -57,22 +65,107 @@ func (repo *paymentRepositoryImpl) GetPaymentStatus(orderId string) (bool, error
 	return isPaid, nil
 }
 
-// -------------------------------------------- update payment status ---------------------------------- \\
+// GetPayment reads the payment attempt made for a razorpay order. The attempt
+// is empty when there is none.
+func (repo *paymentRepositoryImpl) GetPayment(razorId string) (models.PaymentDetails, error) {
+	var payment models.PaymentDetails
 
-func (repo *paymentRepositoryImpl) UpdatePaymentStatus(status bool, orderId string) error {
-	var paymentStatus string
-	if status {
-		paymentStatus = "PAID"
-	} else {
-		paymentStatus = "NOT PAID"
+	query := `
+	SELECT id, order_id, gateway_order_id, payment_id, amount, status, failure_reason, created_at, updated_at
+	FROM payment_attempts
+	WHERE gateway_order_id = ?
+	`
+	if err := repo.DB.Raw(query, razorId).Scan(&payment).Error; err != nil {
+		return models.PaymentDetails{}, err
 	}
 
+	return payment, nil
+}
+
+// LockPayment reads the payment attempt made for a razorpay order and locks it
+// for the rest of the transaction. The attempt is empty when there is none.
+func (repo *paymentRepositoryImpl) LockPayment(razorId string) (models.PaymentDetails, error) {
+	var payment models.PaymentDetails
+
 	query := `
-		UPDATE orders SET payment_status = $1, order_status = 'SHIPPED' WHERE id = $2 
+	SELECT id, order_id, gateway_order_id, payment_id, amount, status, failure_reason, created_at, updated_at
+	FROM payment_attempts
+	WHERE gateway_order_id = ?
+	FOR UPDATE
 	`
-	if err := repo.DB.Exec(query, paymentStatus, orderId).Error; err != nil {
-		err = errors.New("error in updating orders payment status: " + err.Error())
-		return err
+	if err := repo.DB.Raw(query, razorId).Scan(&payment).Error; err != nil {
+		return models.PaymentDetails{}, err
 	}
-	return nil
-}
\ No newline at end of file
+
+	return payment, nil
+}
+
+func (repo *paymentRepositoryImpl) MarkPaymentCaptured(razorId, paymentId string) error {
+	return repo.DB.Exec("UPDATE payment_attempts SET payment_id = ?, status = ?, failure_reason = '', updated_at = NOW() WHERE gateway_order_id = ?",
+		paymentId, domain.PaymentCaptured, razorId).Error
+}
+
+// MarkPaymentFailed records a failed attempt. An attempt that was captured or
+// refunded keeps its status and payment id.
+func (repo *paymentRepositoryImpl) MarkPaymentFailed(razorId, paymentId, reason string) error {
+	return repo.DB.Exec("UPDATE payment_attempts SET payment_id = ?, status = ?, failure_reason = ?, updated_at = NOW() WHERE gateway_order_id = ? AND status NOT IN (?, ?)",
+		paymentId, domain.PaymentFailed, reason, razorId, domain.PaymentCaptured, domain.PaymentRefunded).Error
+}
+
+// MarkPaymentRefunded records a captured payment that is given back instead of
+// paying for the order.
+func (repo *paymentRepositoryImpl) MarkPaymentRefunded(razorId, paymentId, reason string) error {
+	return repo.DB.Exec("UPDATE payment_attempts SET payment_id = ?, status = ?, failure_reason = ?, updated_at = NOW() WHERE gateway_order_id = ?",
+		paymentId, domain.PaymentRefunded, reason, razorId).Error
+}
+
+// GetCapturedPayment finds the attempt an order was paid with. The attempt is
+// empty when the order was not paid through the gateway.
+func (repo *paymentRepositoryImpl) GetCapturedPayment(orderId int) (models.PaymentDetails, error) {
+	var payment models.PaymentDetails
+
+	query := `
+	SELECT id, order_id, gateway_order_id, payment_id, amount, status, failure_reason, created_at, updated_at
+	FROM payment_attempts
+	WHERE order_id = ? AND status = ?
+	ORDER BY id DESC
+	LIMIT 1
+	`
+	if err := repo.DB.Raw(query, orderId, domain.PaymentCaptured).Scan(&payment).Error; err != nil {
+		return models.PaymentDetails{}, err
+	}
+
+	return payment, nil
+}
+
+func (repo *paymentRepositoryImpl) GetPaymentByPaymentID(paymentId string) (models.PaymentDetails, error) {
+	var payment models.PaymentDetails
+
+	query := `
+	SELECT id, order_id, gateway_order_id, payment_id, amount, status, failure_reason, created_at, updated_at
+	FROM payment_attempts
+	WHERE payment_id = ?
+	`
+	if err := repo.DB.Raw(query, paymentId).Scan(&payment).Error; err != nil {
+		return models.PaymentDetails{}, err
+	}
+
+	return payment, nil
+}
+
+// GetPaymentAttempts lists every attempt at paying for an order, oldest first.
+func (repo *paymentRepositoryImpl) GetPaymentAttempts(orderId int) ([]models.PaymentDetails, error) {
+	var attempts []models.PaymentDetails
+
+	query := `
+	SELECT id, order_id, gateway_order_id, payment_id, amount, status, failure_reason, created_at, updated_at
+	FROM payment_attempts
+	WHERE order_id = ?
+	ORDER BY id
+	`
+	if err := repo.DB.Raw(query, orderId).Scan(&attempts).Error; err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+	"gorm.io/gorm"
+)
+
+type pinCodeRepository struct {
+	DB *gorm.DB
+}
+
+func NewPinCodeRepository(DB *gorm.DB) interfaces.PinCodeRepository {
+	return &pinCodeRepository{
+		DB: DB,
+	}
+}
+
+// SavePinCodes adds the pin codes, overwriting the ones already listed.
+func (p *pinCodeRepository) SavePinCodes(pins []models.PinCode) error {
+	if len(pins) == 0 {
+		return nil
+	}
+
+	rows := make([]string, 0, len(pins))
+	args := make([]interface{}, 0, len(pins)*4)
+	for _, pin := range pins {
+		rows = append(rows, "(?, ?, ?, ?, NOW())")
+		args = append(args, pin.Pin, pin.Serviceable, pin.CODAllowed, pin.DeliveryDays)
+	}
+
+	query := `
+	INSERT INTO pin_codes (pin, serviceable, cod_allowed, delivery_days, updated_at)
+	VALUES ` + strings.Join(rows, ", ") + `
+	ON CONFLICT (pin) DO UPDATE SET
+		serviceable = EXCLUDED.serviceable,
+		cod_allowed = EXCLUDED.cod_allowed,
+		delivery_days = EXCLUDED.delivery_days,
+		updated_at = EXCLUDED.updated_at
+	`
+	return p.DB.Exec(query, args...).Error
+}
+
+// GetPinCode returns an empty pin code when pin is not listed.
+func (p *pinCodeRepository) GetPinCode(pin string) (models.PinCode, error) {
+	var pinCode models.PinCode
+
+	err := p.DB.Raw("SELECT pin, serviceable, cod_allowed, delivery_days FROM pin_codes WHERE pin = ?", pin).Scan(&pinCode).Error
+	if err != nil {
+		return models.PinCode{}, err
+	}
+
+	return pinCode, nil
+}
+
+func (p *pinCodeRepository) GetPinCodes(page, count int) ([]models.PinCode, error) {
+	var pinCodes []models.PinCode
+
+	offset := (page - 1) * count
+	err := p.DB.Raw("SELECT pin, serviceable, cod_allowed, delivery_days FROM pin_codes ORDER BY pin LIMIT ? OFFSET ?", count, offset).Scan(&pinCodes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return pinCodes, nil
+}
+
+func (p *pinCodeRepository) DeletePinCode(pin string) error {
+	result := p.DB.Exec("DELETE FROM pin_codes WHERE pin = ?", pin)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("pin code is not listed")
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"github.com/ahdaan98/pkg/domain"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+	"gorm.io/gorm"
+)
+
+type refundRepository struct {
+	DB *gorm.DB
+}
+
+func NewRefundRepository(DB *gorm.DB) interfaces.RefundRepository {
+	return &refundRepository{
+		DB: DB,
+	}
+}
+
+const refundColumns = `id, order_id, method, amount, payment_id, COALESCE(gateway_refund_id, '') AS gateway_refund_id,
+	status, attempts, failure_reason, reason, unapplied, created_at, updated_at`
+
+func (r *refundRepository) AddRefund(refund models.RefundDetails) (int, error) {
+	var id int
+
+	query := `
+	INSERT INTO refunds (order_id, method, amount, payment_id, gateway_refund_id, status, reason, unapplied, created_at, updated_at)
+	VALUES (?, ?, ?, ?, NULLIF(?, ''), ?, ?, ?, NOW(), NOW())
+	RETURNING id
+	`
+	err := r.DB.Raw(query, refund.OrderID, refund.Method, refund.Amount, refund.PaymentID, refund.GatewayRefundID, refund.Status, refund.Reason, refund.Unapplied).Scan(&id).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// LockRefund reads a refund and locks it for the rest of the transaction. The
+// refund is empty when there is none.
+func (r *refundRepository) LockRefund(refundID int) (models.RefundDetails, error) {
+	var refund models.RefundDetails
+
+	if err := r.DB.Raw("SELECT "+refundColumns+" FROM refunds WHERE id = ? FOR UPDATE", refundID).Scan(&refund).Error; err != nil {
+		return models.RefundDetails{}, err
+	}
+
+	return refund, nil
+}
+
+// LockGatewayRefund is LockRefund for the refund the gateway knows by
+// gatewayRefundID.
+func (r *refundRepository) LockGatewayRefund(gatewayRefundID string) (models.RefundDetails, error) {
+	var refund models.RefundDetails
+
+	if err := r.DB.Raw("SELECT "+refundColumns+" FROM refunds WHERE gateway_refund_id = ? FOR UPDATE", gatewayRefundID).Scan(&refund).Error; err != nil {
+		return models.RefundDetails{}, err
+	}
+
+	return refund, nil
+}
+
+// ClaimRefund marks a refund that has not been sent to the gateway yet as
+// SENDING and returns it. The refund is empty when it is not waiting to be sent
+// or another server claimed it first.
+func (r *refundRepository) ClaimRefund(refundID int) (models.RefundDetails, error) {
+	var refund models.RefundDetails
+
+	query := `
+	UPDATE refunds SET status = ?, updated_at = NOW()
+	WHERE id = ? AND method = ? AND status = ? AND gateway_refund_id IS NULL
+	RETURNING ` + refundColumns
+	if err := r.DB.Raw(query, domain.RefundSending, refundID, domain.RefundToOriginal, domain.RefundInitiated).Scan(&refund).Error; err != nil {
+		return models.RefundDetails{}, err
+	}
+
+	return refund, nil
+}
+
+// SetGatewayRefund records the refund the gateway created. A SENDING refund goes
+// back to INITIATED to wait for the gateway's outcome.
+func (r *refundRepository) SetGatewayRefund(refundID int, gatewayRefundID string) error {
+	query := `
+	UPDATE refunds SET gateway_refund_id = ?, status = CASE WHEN status = ? THEN ? ELSE status END, updated_at = NOW()
+	WHERE id = ?
+	`
+	return r.DB.Exec(query, gatewayRefundID, domain.RefundSending, domain.RefundInitiated, refundID).Error
+}
+
+// AddRefundAttempt counts a failed try at sending a refund to the gateway.
+func (r *refundRepository) AddRefundAttempt(refundID int, reason string) error {
+	return r.DB.Exec("UPDATE refunds SET attempts = attempts + 1, failure_reason = ?, updated_at = NOW() WHERE id = ?", reason, refundID).Error
+}
+
+func (r *refundRepository) UpdateRefundStatus(refundID int, status, reason string) error {
+	return r.DB.Exec("UPDATE refunds SET status = ?, failure_reason = ?, updated_at = NOW() WHERE id = ?", status, reason, refundID).Error
+}
+
+// CountPendingRefunds counts the refunds of an order's payment that are still
+// waiting for the gateway, SENDING ones included. Unapplied refunds are left
+// out.
+func (r *refundRepository) CountPendingRefunds(orderID int) (int, error) {
+	var count int
+
+	err := r.DB.Raw("SELECT COUNT(*) FROM refunds WHERE order_id = ? AND status IN (?, ?) AND NOT unapplied", orderID, domain.RefundInitiated, domain.RefundSending).Scan(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetPendingGatewayRefunds lists, oldest first, refunds to the original payment
+// method that have not been sent to the gateway yet.
+func (r *refundRepository) GetPendingGatewayRefunds(limit int) ([]int, error) {
+	var ids []int
+
+	query := `
+	SELECT id FROM refunds
+	WHERE method = ? AND status = ? AND gateway_refund_id IS NULL
+	ORDER BY id
+	LIMIT ?
+	`
+	if err := r.DB.Raw(query, domain.RefundToOriginal, domain.RefundInitiated, limit).Scan(&ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *refundRepository) GetOrderRefunds(orderID int) ([]models.RefundDetails, error) {
+	var refunds []models.RefundDetails
+
+	if err := r.DB.Raw("SELECT "+refundColumns+" FROM refunds WHERE order_id = ? ORDER BY id", orderID).Scan(&refunds).Error; err != nil {
+		return nil, err
+	}
+
+	return refunds, nil
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ahdaan98/pkg/domain"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+	"gorm.io/gorm"
+)
+
+type returnRepository struct {
+	DB *gorm.DB
+}
+
+func NewReturnRepository(DB *gorm.DB) interfaces.ReturnRepository {
+	return &returnRepository{
+		DB: DB,
+	}
+}
+
+func (r *returnRepository) CreateReturnRequest(orderID, userID int, reason, refundTo string) (int, error) {
+	var id int
+
+	query := `
+	INSERT INTO return_requests (order_id, user_id, status, reason, refund_to, created_at, updated_at)
+	VALUES (?, ?, ?, ?, ?, NOW(), NOW())
+	RETURNING id
+	`
+	if err := r.DB.Raw(query, orderID, userID, domain.ReturnStatusRequested, reason, refundTo).Scan(&id).Error; err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *returnRepository) AddReturnItem(returnID, orderItemID, quantity, damagedQuantity int) error {
+	err := r.DB.Exec("INSERT INTO return_request_items (return_request_id, order_item_id, quantity, damaged_quantity) VALUES (?, ?, ?, ?)", returnID, orderItemID, quantity, damagedQuantity).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *returnRepository) AddReturnImage(returnID int, image string) error {
+	err := r.DB.Exec("INSERT INTO return_request_images (return_request_id, image) VALUES (?, ?)", returnID, image).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// LockReturnRequest reads a return request and holds a row lock on it until
+// the surrounding transaction ends.
+func (r *returnRepository) LockReturnRequest(returnID int) (models.ReturnRequestDetails, error) {
+	var request models.ReturnRequestDetails
+
+	result := r.DB.Raw("SELECT * FROM return_requests WHERE id = ? FOR UPDATE", returnID).Scan(&request)
+	if result.Error != nil {
+		return models.ReturnRequestDetails{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ReturnRequestDetails{}, errors.New("no return request exists")
+	}
+
+	return request, nil
+}
+
+func (r *returnRepository) GetReturnRequest(returnID int) (models.ReturnRequestDetails, error) {
+	var request models.ReturnRequestDetails
+
+	result := r.DB.Raw("SELECT * FROM return_requests WHERE id = ?", returnID).Scan(&request)
+	if result.Error != nil {
+		return models.ReturnRequestDetails{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ReturnRequestDetails{}, errors.New("no return request exists")
+	}
+
+	return request, nil
+}
+
+func (r *returnRepository) GetReturnItems(returnID int) ([]models.ReturnItemDetails, error) {
+	var items []models.ReturnItemDetails
+
+	err := r.DB.Raw("SELECT id, order_item_id, quantity, damaged_quantity FROM return_request_items WHERE return_request_id = ? ORDER BY id", returnID).Scan(&items).Error
+	if err != nil {
+		return []models.ReturnItemDetails{}, err
+	}
+
+	return items, nil
+}
+
+func (r *returnRepository) GetReturnImages(returnID int) ([]string, error) {
+	var images []string
+
+	err := r.DB.Raw("SELECT image FROM return_request_images WHERE return_request_id = ? ORDER BY id", returnID).Scan(&images).Error
+	if err != nil {
+		return []string{}, err
+	}
+
+	return images, nil
+}
+
+func (r *returnRepository) GetUserReturnRequests(userID int) ([]models.ReturnRequestDetails, error) {
+	var requests []models.ReturnRequestDetails
+
+	err := r.DB.Raw("SELECT * FROM return_requests WHERE user_id = ? ORDER BY created_at DESC", userID).Scan(&requests).Error
+	if err != nil {
+		return []models.ReturnRequestDetails{}, err
+	}
+
+	return requests, nil
+}
+
+func (r *returnRepository) GetReturnRequests(status string, page int) ([]models.ReturnRequestDetails, error) {
+	var requests []models.ReturnRequestDetails
+
+	if page == 0 {
+		page = 1
+	}
+	offset := (page - 1) * 10
+
+	query := r.DB.Table("return_requests")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	err := query.Order("created_at").Limit(10).Offset(offset).Scan(&requests).Error
+	if err != nil {
+		return []models.ReturnRequestDetails{}, err
+	}
+
+	return requests, nil
+}
+
+// PendingReturnQuantities sums, per order item, the units that are in a
+// return request which is still being processed.
+func (r *returnRepository) PendingReturnQuantities(orderID int) ([]models.OrderItemQuantity, error) {
+	var pending []models.OrderItemQuantity
+
+	query := `
+	SELECT ri.order_item_id, SUM(ri.quantity) AS quantity
+	FROM return_request_items ri
+	JOIN return_requests rr ON rr.id = ri.return_request_id
+	WHERE rr.order_id = ? AND rr.status NOT IN (?, ?, ?)
+	GROUP BY ri.order_item_id
+	`
+	err := r.DB.Raw(query, orderID, domain.ReturnStatusRejected, domain.ReturnStatusCanceled, domain.ReturnStatusRefunded).Scan(&pending).Error
+	if err != nil {
+		return []models.OrderItemQuantity{}, err
+	}
+
+	return pending, nil
+}
+
+// UpdateReturnStatus moves a return request to status. An empty note keeps the
+// admin note the request already has.
+func (r *returnRepository) UpdateReturnStatus(returnID int, status, note string) error {
+	query := `
+	UPDATE return_requests
+	SET status = ?, admin_note = COALESCE(NULLIF(?, ''), admin_note), updated_at = NOW()
+	WHERE id = ?
+	`
+	if err := r.DB.Exec(query, status, note, returnID).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *returnRepository) SchedulePickup(returnID int, pickupDate time.Time) error {
+	query := `
+	UPDATE return_requests
+	SET status = ?, pickup_date = ?, updated_at = NOW()
+	WHERE id = ?
+	`
+	if err := r.DB.Exec(query, domain.ReturnStatusPickupScheduled, pickupDate, returnID).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *returnRepository) SetReturnItemDamage(returnItemID, damagedQuantity int) error {
+	err := r.DB.Exec("UPDATE return_request_items SET damaged_quantity = ? WHERE id = ?", damagedQuantity, returnItemID).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/ahdaan98/pkg/domain"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+	"gorm.io/gorm"
+)
+
+type shipmentRepository struct {
+	DB *gorm.DB
+}
+
+func NewShipmentRepository(DB *gorm.DB) interfaces.ShipmentRepository {
+	return &shipmentRepository{
+		DB: DB,
+	}
+}
+
+func (s *shipmentRepository) CreateShipment(orderID int, shipment models.ShipmentRequest) (int, error) {
+	var id int
+
+	query := `
+	INSERT INTO shipments (order_id, carrier, tracking_number, packages, weight_kg, status, created_at, updated_at)
+	VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW())
+	RETURNING id
+	`
+	err := s.DB.Raw(query, orderID, shipment.Carrier, shipment.TrackingNumber, shipment.Packages, shipment.WeightKg, domain.ShipmentStatusDispatched).Scan(&id).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *shipmentRepository) GetShipmentByOrder(orderID int) (models.ShipmentDetails, error) {
+	var shipment models.ShipmentDetails
+
+	result := s.DB.Raw("SELECT * FROM shipments WHERE order_id = ?", orderID).Scan(&shipment)
+	if result.Error != nil {
+		return models.ShipmentDetails{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ShipmentDetails{}, errors.New("no shipment exists")
+	}
+
+	return shipment, nil
+}
+
+func (s *shipmentRepository) GetShipmentByTracking(carrier, trackingNumber string) (models.ShipmentDetails, error) {
+	var shipment models.ShipmentDetails
+
+	result := s.DB.Raw("SELECT * FROM shipments WHERE carrier = ? AND tracking_number = ?", carrier, trackingNumber).Scan(&shipment)
+	if result.Error != nil {
+		return models.ShipmentDetails{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ShipmentDetails{}, errors.New("no shipment exists")
+	}
+
+	return shipment, nil
+}
+
+func (s *shipmentRepository) GetShipmentEvents(shipmentID int) ([]models.ShipmentEventState, error) {
+	var events []models.ShipmentEventState
+
+	err := s.DB.Raw("SELECT status, location, description, occurred_at FROM shipment_events WHERE shipment_id = ? ORDER BY occurred_at, id", shipmentID).Scan(&events).Error
+	if err != nil {
+		return []models.ShipmentEventState{}, err
+	}
+
+	return events, nil
+}
+
+// AddShipmentEvent stores a tracking scan. It reports false when the same scan
+// was stored before.
+func (s *shipmentRepository) AddShipmentEvent(shipmentID int, event models.ShipmentEventState) (bool, error) {
+	query := `
+	INSERT INTO shipment_events (shipment_id, status, location, description, occurred_at, created_at)
+	VALUES (?, ?, ?, ?, ?, NOW())
+	ON CONFLICT (shipment_id, status, occurred_at) DO NOTHING
+	`
+	result := s.DB.Exec(query, shipmentID, event.Status, event.Location, event.Description, event.OccurredAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// RefreshShipmentStatus sets the shipment status to that of its latest scan,
+// so scans arriving out of order do not move it backwards. A delivered
+// shipment stays delivered.
+func (s *shipmentRepository) RefreshShipmentStatus(shipmentID int) (string, error) {
+	var status string
+
+	query := `
+	UPDATE shipments
+	SET status = (
+		SELECT status FROM shipment_events
+		WHERE shipment_id = shipments.id
+		ORDER BY occurred_at DESC, id DESC
+		LIMIT 1
+	), updated_at = NOW()
+	WHERE id = ? AND status <> ?
+	RETURNING status
+	`
+	result := s.DB.Raw(query, shipmentID, domain.ShipmentStatusDelivered).Scan(&status)
+	if result.Error != nil {
+		return "", result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ShipmentStatusDelivered, nil
+	}
+
+	return status, nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"errors"
+
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+	"gorm.io/gorm"
+)
+
+type shippingZoneRepository struct {
+	DB *gorm.DB
+}
+
+func NewShippingZoneRepository(DB *gorm.DB) interfaces.ShippingZoneRepository {
+	return &shippingZoneRepository{
+		DB: DB,
+	}
+}
+
+func (s *shippingZoneRepository) CreateShippingZone(zone models.ShippingZoneRequest) (int, error) {
+	var id int
+
+	query := `
+	INSERT INTO shipping_zones (name, free_shipping_threshold)
+	VALUES (?, ?)
+	RETURNING id
+	`
+	if err := s.DB.Raw(query, zone.Name, zone.FreeShippingThreshold).Scan(&id).Error; err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *shippingZoneRepository) UpdateShippingZone(id int, zone models.ShippingZoneRequest) error {
+	result := s.DB.Exec("UPDATE shipping_zones SET name = ?, free_shipping_threshold = ? WHERE id = ?", zone.Name, zone.FreeShippingThreshold, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("shipping zone does not exist")
+	}
+
+	return nil
+}
+
+// SetShippingZoneRules replaces the areas and rate table of a zone.
+func (s *shippingZoneRepository) SetShippingZoneRules(id int, areas []models.ShippingArea, rates []models.ShippingRate) error {
+	if err := s.DB.Exec("DELETE FROM shipping_zone_areas WHERE shipping_zone_id = ?", id).Error; err != nil {
+		return err
+	}
+	if err := s.DB.Exec("DELETE FROM shipping_rates WHERE shipping_zone_id = ?", id).Error; err != nil {
+		return err
+	}
+
+	for _, a := range areas {
+		err := s.DB.Exec("INSERT INTO shipping_zone_areas (shipping_zone_id, state, pin_from, pin_to) VALUES (?, ?, ?, ?)",
+			id, a.State, a.PinFrom, a.PinTo).Error
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range rates {
+		err := s.DB.Exec("INSERT INTO shipping_rates (shipping_zone_id, method, basis, min_value, max_value, charge) VALUES (?, ?, ?, ?, ?, ?)",
+			id, r.Method, r.Basis, r.MinValue, r.MaxValue, r.Charge).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *shippingZoneRepository) DeleteShippingZone(id int) error {
+	result := s.DB.Exec("DELETE FROM shipping_zones WHERE id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("shipping zone does not exist")
+	}
+
+	return nil
+}
+
+func (s *shippingZoneRepository) GetShippingZones() ([]models.ShippingZone, error) {
+	var zones []models.ShippingZone
+
+	if err := s.DB.Raw("SELECT id, name, free_shipping_threshold FROM shipping_zones ORDER BY id").Scan(&zones).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range zones {
+		if err := s.loadRules(&zones[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return zones, nil
+}
+
+func (s *shippingZoneRepository) GetShippingZone(id int) (models.ShippingZone, error) {
+	var zone models.ShippingZone
+
+	if err := s.DB.Raw("SELECT id, name, free_shipping_threshold FROM shipping_zones WHERE id = ?", id).Scan(&zone).Error; err != nil {
+		return models.ShippingZone{}, err
+	}
+	if zone.ID == 0 {
+		return models.ShippingZone{}, errors.New("shipping zone does not exist")
+	}
+
+	if err := s.loadRules(&zone); err != nil {
+		return models.ShippingZone{}, err
+	}
+
+	return zone, nil
+}
+
+// FindShippingZone picks the narrowest pin code range holding pin, or else a
+// zone listing the state. The zone is empty when neither matches.
+func (s *shippingZoneRepository) FindShippingZone(state string, pin int) (models.ShippingZone, error) {
+	var zone models.ShippingZone
+
+	query := `
+	SELECT z.id, z.name, z.free_shipping_threshold
+	FROM shipping_zones z
+	JOIN shipping_zone_areas a ON a.shipping_zone_id = z.id
+	WHERE (a.pin_from > 0 AND ? BETWEEN a.pin_from AND a.pin_to)
+	OR (a.pin_from = 0 AND LOWER(TRIM(a.state)) = LOWER(TRIM(?)))
+	ORDER BY a.pin_from = 0, a.pin_to - a.pin_from, z.id
+	LIMIT 1
+	`
+	if err := s.DB.Raw(query, pin, state).Scan(&zone).Error; err != nil {
+		return models.ShippingZone{}, err
+	}
+	if zone.ID == 0 {
+		return models.ShippingZone{}, nil
+	}
+
+	if err := s.loadRules(&zone); err != nil {
+		return models.ShippingZone{}, err
+	}
+
+	return zone, nil
+}
+
+func (s *shippingZoneRepository) loadRules(zone *models.ShippingZone) error {
+	if err := s.DB.Raw("SELECT state, pin_from, pin_to FROM shipping_zone_areas WHERE shipping_zone_id = ? ORDER BY id", zone.ID).Scan(&zone.Areas).Error; err != nil {
+		return err
+	}
+
+	query := `
+	SELECT method, basis, min_value, max_value, charge
+	FROM shipping_rates
+	WHERE shipping_zone_id = ?
+	ORDER BY method, basis, min_value
+	`
+	return s.DB.Raw(query, zone.ID).Scan(&zone.Rates).Error
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	"gorm.io/gorm"
+)
+
+type transactionRepository struct {
+	DB *gorm.DB
+}
+
+func NewTransactionRepository(DB *gorm.DB) interfaces.TransactionRepository {
+	return &transactionRepository{
+		DB: DB,
+	}
+}
+
+func (t *transactionRepository) WithTransaction(fn func(repos interfaces.TxRepositories) error) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(interfaces.TxRepositories{
+			Order:        NewOrderRepository(tx),
+			Cart:         NewCartRepository(tx),
+			Inventory:    NewInventoryRespository(tx),
+			Wallet:       NewWalletRepository(tx),
+			Payment:      NewPaymentRepository(tx),
+			Return:       NewReturnRepository(tx),
+			Shipment:     NewShipmentRepository(tx),
+			Coupon:       NewCouponRepository(tx),
+			BulkJob:      NewBulkJobRepository(tx),
+			ShippingZone: NewShippingZoneRepository(tx),
+			Invoice:      NewInvoiceRepository(tx),
+			Refund:       NewRefundRepository(tx),
+		})
+	})
+}
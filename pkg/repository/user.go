@@ -184,12 +184,12 @@ func (ad *userDatabase) GetCartID(id int) (int, error) {
 
 }
 
-func (ad *userDatabase) GetProductsInCart(cart_id int) ([]int, error) {
+func (ad *userDatabase) GetProductsInCart(cart_id int) ([]models.CartProduct, error) {
 
-	var cart_products []int
+	var cart_products []models.CartProduct
 
-	if err := ad.DB.Raw("select inventory_id from line_items where cart_id=?", cart_id).Scan(&cart_products).Error; err != nil {
-		return []int{}, err
+	if err := ad.DB.Raw("select line_items.inventory_id, inventories.weight_grams from line_items join inventories on inventories.id = line_items.inventory_id where line_items.cart_id=?", cart_id).Scan(&cart_products).Error; err != nil {
+		return []models.CartProduct{}, err
 	}
 
 	return cart_products, nil
@@ -35,7 +35,7 @@ func (wt *walletRepository) GetWallet(userID int) (models.WalletAmount, error) {
 // 	return walletAmount, nil
 // }
 
-func (wt *walletRepository) AddToWallet(Price, UserId int) (models.WalletAmount, error) {
+func (wt *walletRepository) AddToWallet(Price float64, UserId int) (models.WalletAmount, error) {
 	fmt.Println("amount inside repo", Price)
 	fmt.Println("UserId inside repo", UserId)
 
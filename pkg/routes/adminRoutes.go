@@ -6,7 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func AdminRoutes(engine *gin.RouterGroup, categoryHandler *handler.CategoryHandler, brandHandler *handler.BrandHandler, inventoryHandler *handler.InventoryHandler, adminHandler *handler.AdminHandler, orderHandler *handler.OrderHandler, couponHandler *handler.CouponHandler) {
+func AdminRoutes(engine *gin.RouterGroup, categoryHandler *handler.CategoryHandler, brandHandler *handler.BrandHandler, inventoryHandler *handler.InventoryHandler, adminHandler *handler.AdminHandler, orderHandler *handler.OrderHandler, couponHandler *handler.CouponHandler, returnHandler *handler.ReturnHandler, shipmentHandler *handler.ShipmentHandler, bulkOrderHandler *handler.BulkOrderHandler, shippingZoneHandler *handler.ShippingZoneHandler, pinCodeHandler *handler.PinCodeHandler, invoiceHandler *handler.InvoiceHandler, paymentHandler *handler.PaymentHandler) {
 
 	engine.POST("/login", adminHandler.AdminLogin)
 	engine.Static("/uploads", "./uploads")
@@ -41,6 +41,7 @@ func AdminRoutes(engine *gin.RouterGroup, categoryHandler *handler.CategoryHandl
 			category.GET("", categoryHandler.GetCategories)
 			category.POST("/add", categoryHandler.AddCategory)
 			category.PUT("/edit", categoryHandler.EditCategory)
+			category.PUT("/:id/tax", categoryHandler.SetCategoryTax)
 			category.DELETE("/:id", categoryHandler.DeleteCategory)
 			category.GET("/filter", categoryHandler.FilterByCategory)
 		}
@@ -65,6 +66,31 @@ func AdminRoutes(engine *gin.RouterGroup, categoryHandler *handler.CategoryHandl
 		{
 			orders.GET("", orderHandler.GetAdminOrders)
 			orders.PUT("/status", orderHandler.ApproveOrder)
+			orders.POST("/bulk", bulkOrderHandler.StartBulkJob)
+			orders.GET("/bulk/:id", bulkOrderHandler.GetBulkJob)
+			orders.GET("/:id/timeline", orderHandler.GetOrderTimeline)
+			orders.POST("/:id/shipment", shipmentHandler.CreateShipment)
+			orders.GET("/:id/shipment", shipmentHandler.GetShipment)
+			orders.GET("/:id/invoice", invoiceHandler.GetOrderInvoice)
+			orders.GET("/:id/invoice/link", invoiceHandler.GetAdminInvoiceLink)
+			orders.GET("/:id/invoices", invoiceHandler.GetOrderDocuments)
+			orders.GET("/:id/refunds", paymentHandler.GetOrderRefunds)
+			orders.GET("/:id/payments", paymentHandler.GetPaymentAttempts)
+		}
+
+		engine.GET("/invoices/:id", invoiceHandler.GetInvoice)
+		engine.GET("/credit-notes/:id", invoiceHandler.GetCreditNote)
+
+		returns := engine.Group("/returns")
+		{
+			returns.GET("", returnHandler.GetReturns)
+			returns.GET("/:id", returnHandler.GetReturn)
+			returns.PUT("/:id/approve", returnHandler.ApproveReturn)
+			returns.PUT("/:id/reject", returnHandler.RejectReturn)
+			returns.PUT("/:id/pickup", returnHandler.SchedulePickup)
+			returns.PUT("/:id/receive", returnHandler.ReceiveReturn)
+			returns.PUT("/:id/inspect", returnHandler.InspectReturn)
+			returns.PUT("/:id/refund", returnHandler.RefundReturn)
 		}
 
 		coupon := engine.Group("/coupons")
@@ -73,6 +99,23 @@ func AdminRoutes(engine *gin.RouterGroup, categoryHandler *handler.CategoryHandl
 			coupon.GET("", couponHandler.GetCoupons)
 			coupon.PATCH("/:id", couponHandler.UpdateCoupon)
 		}
+
+		shipping := engine.Group("/shipping/zones")
+		{
+			shipping.POST("", shippingZoneHandler.AddShippingZone)
+			shipping.GET("", shippingZoneHandler.GetShippingZones)
+			shipping.GET("/:id", shippingZoneHandler.GetShippingZone)
+			shipping.PUT("/:id", shippingZoneHandler.EditShippingZone)
+			shipping.DELETE("/:id", shippingZoneHandler.DeleteShippingZone)
+		}
+
+		pincodes := engine.Group("/pincodes")
+		{
+			pincodes.GET("", pinCodeHandler.GetPinCodes)
+			pincodes.PUT("", pinCodeHandler.SetPinCode)
+			pincodes.POST("/import", pinCodeHandler.ImportPinCodes)
+			pincodes.DELETE("/:pin", pinCodeHandler.DeletePinCode)
+		}
 		engine.GET("/dashboard", adminHandler.DashBoard)
 	}
 }
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/ahdaan98/pkg/api/handler"
+	"github.com/gin-gonic/gin"
+)
+
+// FakeCheckoutRoutes must only be registered when the fake gateway is in use.
+func FakeCheckoutRoutes(engine *gin.RouterGroup, fakeCheckoutHandler *handler.FakeCheckoutHandler) {
+
+	engine.POST("/pay", fakeCheckoutHandler.Pay)
+}
@@ -6,7 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func UserRoutes(engine *gin.RouterGroup, categoryHandler *handler.CategoryHandler, brandHandler *handler.BrandHandler, inventoryHandler *handler.InventoryHandler, userHandler *handler.UserHandler, cartHandler *handler.CartHandler, orderHandler *handler.OrderHandler, paymentHandler *handler.PaymentHandler, walletHandler *handler.WalletHandler, couponHandler *handler.CouponHandler) {
+func UserRoutes(engine *gin.RouterGroup, categoryHandler *handler.CategoryHandler, brandHandler *handler.BrandHandler, inventoryHandler *handler.InventoryHandler, userHandler *handler.UserHandler, cartHandler *handler.CartHandler, orderHandler *handler.OrderHandler, paymentHandler *handler.PaymentHandler, walletHandler *handler.WalletHandler, couponHandler *handler.CouponHandler, returnHandler *handler.ReturnHandler, shipmentHandler *handler.ShipmentHandler, pinCodeHandler *handler.PinCodeHandler, invoiceHandler *handler.InvoiceHandler, idempotency *middleware.Idempotency) {
 
 	engine.POST("/signup", userHandler.UserSignUp)
 	engine.POST("/login", userHandler.UserLogin)
@@ -16,15 +16,15 @@ func UserRoutes(engine *gin.RouterGroup, categoryHandler *handler.CategoryHandle
 	products:=engine.Group("/products")
 	products.GET("/list",inventoryHandler.ListProductsWithImages)
 	products.GET("", inventoryHandler.ListProducts)
+	products.GET("/:id/delivery", pinCodeHandler.CheckDelivery)
 	engine.GET("/categories/filter", categoryHandler.FilterByCategory)
 	engine.GET("/brands/filter", brandHandler.FilterByBrand)
 	products.GET("/filter/brand",brandHandler.FilterByBrand)
 
-	engine.GET("/payment", paymentHandler.MakePaymentRazorpay) // Update this route
 	engine.GET("/verifypayment", paymentHandler.VerifyPayment) // Update this route
 	
 
-	engine.GET("/invoice/print", orderHandler.PrintInvoice)
+	engine.GET("/invoices/download", invoiceHandler.DownloadInvoice)
 
 	engine.Use(middleware.UserAuthMiddleware)
 	{
@@ -42,9 +42,28 @@ func UserRoutes(engine *gin.RouterGroup, categoryHandler *handler.CategoryHandle
 				orders.GET("", orderHandler.GetOrders)
 				orders.GET("/all", orderHandler.GetAllOrders)
 				orders.DELETE("", orderHandler.CancelOrder)
-				orders.PUT("/return", orderHandler.ReturnOrder)
+				orders.PUT("/return", returnHandler.ReturnOrder)
+				orders.GET("/:id/timeline", orderHandler.GetUserOrderTimeline)
+				orders.GET("/:id/items", orderHandler.GetUserOrderItems)
+				orders.GET("/:id/tracking", shipmentHandler.GetUserShipment)
+				orders.GET("/:id/invoice/link", invoiceHandler.GetInvoiceLink)
+				orders.GET("/:id/refunds", paymentHandler.GetUserRefunds)
+				orders.POST("/:id/payment/retry", idempotency.Handle, paymentHandler.RetryPayment)
+				orders.POST("/:id/reorder", orderHandler.Reorder)
+				orders.PUT("/:id/items/cancel", orderHandler.CancelOrderItems)
+				orders.PUT("/:id/items/return", returnHandler.ReturnOrderItems)
 			}
 
+			returns := profile.Group("/returns")
+			{
+				returns.POST("", returnHandler.RequestReturn)
+				returns.GET("", returnHandler.GetUserReturns)
+				returns.GET("/:id", returnHandler.GetUserReturn)
+				returns.PUT("/:id/cancel", returnHandler.CancelReturn)
+			}
+
+			profile.GET("/credit-notes/:id", invoiceHandler.GetUserCreditNote)
+
 		}
 
 		cart := engine.Group("/cart")
@@ -58,9 +77,13 @@ func UserRoutes(engine *gin.RouterGroup, categoryHandler *handler.CategoryHandle
 		checkout := engine.Group("/check-out")
 		{
 			checkout.GET("", cartHandler.CheckOut)
-			checkout.POST("", orderHandler.OrderItemsFromCart)
+			checkout.GET("/quote", orderHandler.GetCheckoutQuote)
+			checkout.POST("", idempotency.Handle, orderHandler.OrderItemsFromCart)
 		}
 
+		engine.GET("/invoice/print", invoiceHandler.PrintInvoice)
+		engine.GET("/payment", idempotency.Handle, paymentHandler.MakePaymentRazorpay)
+
 		wallet := engine.Group("/wallet")
 		{
 			wallet.GET("", walletHandler.ViewWallet)
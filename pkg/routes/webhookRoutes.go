@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/ahdaan98/pkg/api/handler"
+	"github.com/ahdaan98/pkg/api/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func WebhookRoutes(engine *gin.RouterGroup, shipmentHandler *handler.ShipmentHandler, paymentHandler *handler.PaymentHandler) {
+
+	engine.POST("/courier", middleware.CourierWebhookMiddleware, shipmentHandler.TrackingWebhook)
+	engine.POST("/razorpay", paymentHandler.RazorpayWebhook)
+}
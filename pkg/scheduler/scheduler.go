@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+
+	services "github.com/ahdaan98/pkg/usecase/interface"
+)
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      func() error
+}
+
+// Scheduler runs background jobs inside the server, each on its own fixed
+// interval.
+type Scheduler struct {
+	jobs []job
+}
+
+func NewScheduler(orderUseCase services.OrderUseCase, bulkOrderUseCase services.BulkOrderUseCase, paymentUseCase services.PaymentUseCase) *Scheduler {
+	s := &Scheduler{}
+
+	s.Every("expire unpaid orders", time.Minute, func() error {
+		expired, err := orderUseCase.ExpireUnpaidOrders()
+		if expired > 0 {
+			log.Printf("expired %d unpaid orders", expired)
+		}
+		return err
+	})
+
+	s.Every("resume bulk order jobs", time.Minute, func() error {
+		_, err := bulkOrderUseCase.ResumeStalledJobs()
+		return err
+	})
+
+	s.Every("send pending refunds", time.Minute, func() error {
+		sent, err := paymentUseCase.SendPendingRefunds()
+		if sent > 0 {
+			log.Printf("sent %d refunds to the payment gateway", sent)
+		}
+		return err
+	})
+
+	return s
+}
+
+// Every adds a job that runs once per interval after Start is called.
+func (s *Scheduler) Every(name string, interval time.Duration, run func() error) {
+	s.jobs = append(s.jobs, job{name: name, interval: interval, run: run})
+}
+
+// Start runs every job in its own goroutine for as long as the server lives.
+func (s *Scheduler) Start() {
+	for _, j := range s.jobs {
+		go func(j job) {
+			ticker := time.NewTicker(j.interval)
+			defer ticker.Stop()
+
+			for range ticker.C {
+				if err := j.run(); err != nil {
+					log.Printf("scheduler: %s: %v", j.name, err)
+				}
+			}
+		}(j)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ahdaan98/pkg/domain"
@@ -132,13 +133,20 @@ func (ad *AdminUseCase) UnBlockUser(id int) error {
 	return nil
 }
 
-func (i *AdminUseCase) NewPaymentMethod(id string) error {
+func (i *AdminUseCase) NewPaymentMethod(id, kind string) error {
 
 	// parsedID, err := strconv.Atoi(id)
 	// if err != nil || parsedID <= 0 {
 	// 	return errors.New("invalid id")
 	// }
 
+	kind = strings.ToUpper(strings.TrimSpace(kind))
+	switch kind {
+	case domain.PaymentKindOnline, domain.PaymentKindCOD, domain.PaymentKindOther:
+	default:
+		return errors.New("kind should be online, cod or other")
+	}
+
 	exists, err := i.repo.CheckIfPaymentMethodAlreadyExists(id)
 	if err != nil {
 		return err
@@ -148,7 +156,7 @@ func (i *AdminUseCase) NewPaymentMethod(id string) error {
 		return errors.New("payment method already exists")
 	}
 
-	err = i.repo.NewPaymentMethod(id)
+	err = i.repo.NewPaymentMethod(id, kind)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/ahdaan98/pkg/domain"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+)
+
+// authorizeOrder makes sure the order was placed by the user acting on it.
+// The user always comes from the login token, never from the request.
+func authorizeOrder(orders interfaces.OrderRepository, userID, orderID int) error {
+	if orderID <= 0 {
+		return errors.New("enter a valid order id")
+	}
+
+	owner, err := orders.FindUserID(orderID)
+	if err != nil {
+		return err
+	}
+	if owner == 0 {
+		return errors.New("no order exists")
+	}
+	if owner != userID {
+		return domain.ErrForbidden
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ahdaan98/pkg/domain"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	services "github.com/ahdaan98/pkg/usecase/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+)
+
+// maxBulkOrders caps the orders a single bulk job may touch.
+const maxBulkOrders = 1000
+
+// bulkTargets is the order status each bulk action moves an order to.
+var bulkTargets = map[string]string{
+	domain.BulkActionShip:    domain.OrderStatusShipped,
+	domain.BulkActionDeliver: domain.OrderStatusDelivered,
+	domain.BulkActionCancel:  domain.OrderStatusCanceled,
+}
+
+type bulkOrderUseCase struct {
+	bulkJobRepository interfaces.BulkJobRepository
+	orderRepository   interfaces.OrderRepository
+	transaction       interfaces.TransactionRepository
+}
+
+func NewBulkOrderUseCase(repo interfaces.BulkJobRepository, orderRepo interfaces.OrderRepository, transaction interfaces.TransactionRepository) services.BulkOrderUseCase {
+	return &bulkOrderUseCase{
+		bulkJobRepository: repo,
+		orderRepository:   orderRepo,
+		transaction:       transaction,
+	}
+}
+
+// StartBulkJob queues action for the requested orders and starts working
+// through them in the background. The returned job can be polled with
+// GetBulkJob.
+func (b *bulkOrderUseCase) StartBulkJob(adminID int, request models.BulkOrderRequest) (models.BulkJobDetails, error) {
+	action := strings.ToLower(strings.TrimSpace(request.Action))
+	if _, ok := bulkTargets[action]; !ok {
+		return models.BulkJobDetails{}, errors.New("action should be one of ship, deliver or cancel")
+	}
+
+	orderIDs, err := b.bulkOrderIDs(request)
+	if err != nil {
+		return models.BulkJobDetails{}, err
+	}
+
+	jobID, err := b.bulkJobRepository.CreateBulkJob(adminID, action, orderIDs)
+	if err != nil {
+		return models.BulkJobDetails{}, err
+	}
+
+	go func() {
+		// a panic would take the whole server down; the job is left
+		// running and picked up again by ResumeStalledJobs
+		defer func() {
+			if p := recover(); p != nil {
+				log.Printf("bulk order job %d: panic: %v", jobID, p)
+			}
+		}()
+		if err := b.RunBulkJob(jobID); err != nil {
+			log.Printf("bulk order job %d: %v", jobID, err)
+		}
+	}()
+
+	return b.bulkJobRepository.GetBulkJob(jobID)
+}
+
+// bulkOrderIDs resolves the orders a request applies to, either the listed
+// ids or every order matching its filter.
+func (b *bulkOrderUseCase) bulkOrderIDs(request models.BulkOrderRequest) ([]int, error) {
+	if len(request.OrderIDs) > 0 && request.Filter != nil {
+		return nil, errors.New("give either order ids or a filter, not both")
+	}
+
+	var ids []int
+	switch {
+	case len(request.OrderIDs) > 0:
+		if len(request.OrderIDs) > maxBulkOrders {
+			return nil, fmt.Errorf("a bulk action can change at most %d orders", maxBulkOrders)
+		}
+		ids = request.OrderIDs
+	case request.Filter != nil:
+		query, err := adminOrderQuery(*request.Filter)
+		if err != nil {
+			return nil, err
+		}
+		// one more than allowed is enough to tell the filter is too broad
+		ids, err = b.orderRepository.SearchOrderIDs(query, maxBulkOrders+1)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) > maxBulkOrders {
+			return nil, fmt.Errorf("the filter matches more than %d orders, narrow it down", maxBulkOrders)
+		}
+	default:
+		return nil, errors.New("give the order ids or a filter")
+	}
+
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, errors.New("enter a valid order id")
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, errors.New("no orders match the filter")
+	}
+
+	return unique, nil
+}
+
+func (b *bulkOrderUseCase) GetBulkJob(jobID int) (models.BulkJobDetails, error) {
+	if jobID <= 0 {
+		return models.BulkJobDetails{}, errors.New("enter a valid job id")
+	}
+
+	job, err := b.bulkJobRepository.GetBulkJob(jobID)
+	if err != nil {
+		return models.BulkJobDetails{}, err
+	}
+
+	job.Results, err = b.bulkJobRepository.GetBulkJobResults(jobID)
+	if err != nil {
+		return models.BulkJobDetails{}, err
+	}
+
+	return job, nil
+}
+
+// RunBulkJob applies a job's action to every order that has no result yet.
+// Each order is changed in its own transaction, so one order that cannot move
+// does not hold back the rest. Nothing is done when another worker holds the
+// job.
+func (b *bulkOrderUseCase) RunBulkJob(jobID int) error {
+	claimed, err := b.bulkJobRepository.ClaimBulkJob(jobID)
+	if err != nil || !claimed {
+		return err
+	}
+
+	job, err := b.bulkJobRepository.GetBulkJob(jobID)
+	if err != nil {
+		return err
+	}
+
+	orderIDs, err := b.bulkJobRepository.GetPendingBulkOrders(jobID)
+	if err != nil {
+		return err
+	}
+
+	for _, orderID := range orderIDs {
+		err := b.transaction.WithTransaction(func(repos interfaces.TxRepositories) error {
+			if err := applyBulkAction(repos, job, orderID); err != nil {
+				return err
+			}
+			return repos.BulkJob.RecordBulkResult(jobID, orderID, domain.BulkResultSucceeded, "")
+		})
+		if err != nil {
+			// the job still finishes; the order just shows no result
+			if recordErr := b.bulkJobRepository.RecordBulkResult(jobID, orderID, domain.BulkResultFailed, err.Error()); recordErr != nil {
+				log.Printf("bulk order job %d: recording order %d: %v", jobID, orderID, recordErr)
+			}
+		}
+	}
+
+	return b.bulkJobRepository.FinishBulkJob(jobID)
+}
+
+// applyBulkAction moves one order as the job's action asks, if the order is in
+// a state that allows it.
+func applyBulkAction(repos interfaces.TxRepositories, job models.BulkJobDetails, orderID int) error {
+	state, err := repos.Order.LockOrderState(orderID)
+	if err != nil {
+		return err
+	}
+
+	target := bulkTargets[job.Action]
+	if !domain.CanChangeOrderStatus(state.OrderStatus, target) {
+		return &domain.InvalidTransitionError{Field: "order status", From: state.OrderStatus, To: target}
+	}
+
+	actor := domain.Actor{Role: domain.ActorAdmin, ID: job.AdminID}
+	reason := fmt.Sprintf("bulk job %d", job.ID)
+
+	if target != domain.OrderStatusCanceled {
+		return advanceOrder(repos, state, target, actor, reason)
+	}
+
+	return closeOrderItems(repos, state, domain.OrderStatusCanceled, allOpenItems(), "", actor, reason)
+}
+
+// ResumeStalledJobs picks up jobs that were left unfinished, for example by a
+// restart, and runs them to the end. It returns how many jobs it ran.
+func (b *bulkOrderUseCase) ResumeStalledJobs() (int, error) {
+	ids, err := b.bulkJobRepository.GetStalledBulkJobs()
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for _, jobID := range ids {
+		if err := b.RunBulkJob(jobID); err != nil {
+			errs = append(errs, fmt.Errorf("bulk job %d: %w", jobID, err))
+		}
+	}
+
+	return len(ids), errors.Join(errs...)
+}
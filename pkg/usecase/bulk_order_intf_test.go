@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ahdaan98/pkg/domain"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	repo_mocks "github.com/ahdaan98/pkg/repository/mocks"
+	"github.com/ahdaan98/pkg/utils/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type bulkOrderTestMocks struct {
+	bulkJob *repo_mocks.MockBulkJobRepository
+	order   *repo_mocks.MockOrderRepository
+	coupon  *repo_mocks.MockCouponRepository
+	invoice *repo_mocks.MockInvoiceRepository
+}
+
+func newBulkOrderTestUseCase(ctrl *gomock.Controller) (*bulkOrderUseCase, bulkOrderTestMocks) {
+	m := bulkOrderTestMocks{
+		bulkJob: repo_mocks.NewMockBulkJobRepository(ctrl),
+		order:   repo_mocks.NewMockOrderRepository(ctrl),
+		coupon:  repo_mocks.NewMockCouponRepository(ctrl),
+		invoice: repo_mocks.NewMockInvoiceRepository(ctrl),
+	}
+
+	transaction := newTestTransaction(ctrl, interfaces.TxRepositories{
+		Order:   m.order,
+		Coupon:  m.coupon,
+		BulkJob: m.bulkJob,
+		Invoice: m.invoice,
+	})
+
+	return NewBulkOrderUseCase(m.bulkJob, m.order, transaction).(*bulkOrderUseCase), m
+}
+
+func TestRunBulkJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, m := newBulkOrderTestUseCase(ctrl)
+
+	m.bulkJob.EXPECT().ClaimBulkJob(3).Return(true, nil)
+	m.bulkJob.EXPECT().GetBulkJob(3).Return(models.BulkJobDetails{ID: 3, AdminID: 1, Action: domain.BulkActionDeliver, Total: 3}, nil)
+	m.bulkJob.EXPECT().GetPendingBulkOrders(3).Return([]int{5, 6, 7}, nil)
+
+	// order 5 is shipped and gets delivered
+	m.order.EXPECT().LockOrderState(5).Return(models.OrderState{OrderID: 5, OrderStatus: domain.OrderStatusShipped, PaymentStatus: domain.PaymentStatusPaid}, nil)
+	m.order.EXPECT().UpdateOrderState(5, domain.OrderStatusDelivered, domain.PaymentStatusPaid).Return(nil)
+	m.order.EXPECT().AddStatusHistory(gomock.Any()).DoAndReturn(func(h domain.OrderStatusHistory) error {
+		assert.Equal(t, domain.ActorAdmin, h.ActorRole)
+		assert.Equal(t, 1, h.ActorID)
+		assert.Equal(t, "bulk job 3", h.Reason)
+		return nil
+	})
+	m.order.EXPECT().SetOpenItemsStatus(5, domain.OrderStatusDelivered).Return(nil)
+	m.invoice.EXPECT().GetInvoiceByOrder(5).Return(models.Invoice{}, nil)
+	m.invoice.EXPECT().NextDocumentNumber(domain.SeriesInvoice, gomock.Any()).Return(1, nil)
+	m.order.EXPECT().GetDetailedOrderThroughId(5).Return(models.CombinedOrderDetails{}, nil)
+	m.invoice.EXPECT().CreateInvoice(gomock.Any()).Return(1, nil)
+	m.bulkJob.EXPECT().RecordBulkResult(3, 5, domain.BulkResultSucceeded, "").Return(nil)
+
+	// order 6 was never shipped, so it cannot be delivered
+	m.order.EXPECT().LockOrderState(6).Return(models.OrderState{OrderID: 6, OrderStatus: domain.OrderStatusPending, PaymentStatus: domain.PaymentStatusNotPaid}, nil)
+	// and when even its failure cannot be recorded the job goes on
+	m.bulkJob.EXPECT().RecordBulkResult(3, 6, domain.BulkResultFailed, "order status cannot change from PENDING to DELIVERED").Return(errors.New("connection reset"))
+
+	// order 7 does not exist
+	m.order.EXPECT().LockOrderState(7).Return(models.OrderState{}, errors.New("no order exists"))
+	m.bulkJob.EXPECT().RecordBulkResult(3, 7, domain.BulkResultFailed, "no order exists").Return(nil)
+
+	m.bulkJob.EXPECT().FinishBulkJob(3).Return(nil)
+
+	assert.NoError(t, uc.RunBulkJob(3))
+}
+
+func TestRunBulkJobHeldElsewhere(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, m := newBulkOrderTestUseCase(ctrl)
+
+	m.bulkJob.EXPECT().ClaimBulkJob(3).Return(false, nil)
+
+	assert.NoError(t, uc.RunBulkJob(3))
+}
+
+func TestBulkOrderIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		request models.BulkOrderRequest
+		stub    func(m bulkOrderTestMocks)
+		want    []int
+		wantErr error
+	}{
+		{
+			name:    "listed ids are deduplicated",
+			request: models.BulkOrderRequest{OrderIDs: []int{4, 2, 4}},
+			want:    []int{4, 2},
+		},
+		{
+			name:    "filter is resolved to ids",
+			request: models.BulkOrderRequest{Filter: &models.AdminOrderFilter{OrderStatus: "shipped"}},
+			stub: func(m bulkOrderTestMocks) {
+				m.order.EXPECT().SearchOrderIDs(models.AdminOrderQuery{OrderStatus: domain.OrderStatusShipped, Page: 1, PageSize: defaultAdminOrderPageSize}, maxBulkOrders+1).Return([]int{8, 9}, nil)
+			},
+			want: []int{8, 9},
+		},
+		{
+			name:    "filter matching too many orders",
+			request: models.BulkOrderRequest{Filter: &models.AdminOrderFilter{}},
+			stub: func(m bulkOrderTestMocks) {
+				m.order.EXPECT().SearchOrderIDs(gomock.Any(), maxBulkOrders+1).Return(make([]int, maxBulkOrders+1), nil)
+			},
+			wantErr: errors.New("the filter matches more than 1000 orders, narrow it down"),
+		},
+		{
+			name:    "both ids and filter",
+			request: models.BulkOrderRequest{OrderIDs: []int{1}, Filter: &models.AdminOrderFilter{}},
+			wantErr: errors.New("give either order ids or a filter, not both"),
+		},
+		{
+			name:    "neither ids nor filter",
+			request: models.BulkOrderRequest{},
+			wantErr: errors.New("give the order ids or a filter"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, m := newBulkOrderTestUseCase(ctrl)
+			if tt.stub != nil {
+				tt.stub(m)
+			}
+
+			ids, err := uc.bulkOrderIDs(tt.request)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.want, ids)
+			}
+		})
+	}
+}
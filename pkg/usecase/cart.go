@@ -2,6 +2,11 @@ package usecase
 
 import (
 	"errors"
+	"strings"
+	"time"
+
+	"github.com/ahdaan98/pkg/domain"
+	"github.com/ahdaan98/pkg/pricing"
 	interfaces "github.com/ahdaan98/pkg/repository/interface"
 	services "github.com/ahdaan98/pkg/usecase/interface"
 	"github.com/ahdaan98/pkg/utils/models"
@@ -12,14 +17,18 @@ type cartUseCase struct {
 	inventoryRepository interfaces.InventoryRepository
 	userUseCase         services.UserUseCase
 	adrepo              interfaces.AdminRepository
+	pinCodes            interfaces.PinCodeRepository
+	calculator          *pricing.Calculator
 }
 
-func NewCartUseCase(repo interfaces.CartRepository, inventoryRepo interfaces.InventoryRepository, userUseCase services.UserUseCase, adrepo interfaces.AdminRepository) services.CartUseCase {
+func NewCartUseCase(repo interfaces.CartRepository, inventoryRepo interfaces.InventoryRepository, userUseCase services.UserUseCase, adrepo interfaces.AdminRepository, pinCodes interfaces.PinCodeRepository, calculator *pricing.Calculator) services.CartUseCase {
 	return &cartUseCase{
 		repo:                repo,
 		inventoryRepository: inventoryRepo,
 		userUseCase:         userUseCase,
 		adrepo:              adrepo,
+		pinCodes:            pinCodes,
+		calculator:          calculator,
 	}
 }
 
@@ -76,12 +85,91 @@ func (i *cartUseCase) CheckOut(id int) (models.CheckOut, error) {
 	if err != nil {
 		return models.CheckOut{}, err
 	}
+	delivery, serviceable, cod, err := i.deliveryToAddresses(address)
+	if err != nil {
+		return models.CheckOut{}, err
+	}
+	// cash on delivery is only offered when some address allows it
+	if !cod {
+		methods := paymethods[:0]
+		for _, m := range paymethods {
+			if m.Kind != domain.PaymentKindCOD {
+				methods = append(methods, m)
+			}
+		}
+		paymethods = methods
+	}
+
 	var checkout models.CheckOut
 
 	checkout.CartID = products.ID
 	checkout.Addresses = address
 	checkout.Products = products.Data
 	checkout.PaymentMethod = paymethods
+	checkout.Delivery = delivery
+	checkout.Shipping = i.shippingOptions(id, serviceable, products)
 
 	return checkout, err
 }
+
+// deliveryToAddresses looks up the delivery terms of each address. It also
+// returns the addresses that can be delivered to and whether any of them
+// allows cash on delivery.
+func (i *cartUseCase) deliveryToAddresses(addresses []models.Address) ([]models.DeliveryEstimate, []models.Address, bool, error) {
+	var delivery []models.DeliveryEstimate
+	var serviceable []models.Address
+	cod := false
+
+	now := time.Now()
+	for _, a := range addresses {
+		pinCode, err := i.pinCodes.GetPinCode(strings.TrimSpace(a.Pin))
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		estimate := models.DeliveryEstimate{
+			AddressID:   int(a.Id),
+			Pin:         a.Pin,
+			Serviceable: pinCode.Serviceable,
+			CODAllowed:  pinCode.CODAllowed,
+		}
+		if pinCode.Serviceable {
+			estimate.DeliveryDays = pinCode.DeliveryDays
+			by := deliveryDate(now, pinCode.DeliveryDays)
+			estimate.EstimatedDelivery = &by
+			serviceable = append(serviceable, a)
+			cod = cod || pinCode.CODAllowed
+		}
+		delivery = append(delivery, estimate)
+	}
+
+	return delivery, serviceable, cod, nil
+}
+
+// shippingOptions quotes each shipping method to each address. Methods that
+// cannot deliver to an address are left out.
+func (i *cartUseCase) shippingOptions(userID int, addresses []models.Address, cart models.GetCartResponse) []models.ShippingOption {
+	var options []models.ShippingOption
+	if len(cart.Data) == 0 {
+		return options
+	}
+
+	items := cartItems(cart)
+	for _, a := range addresses {
+		address := domain.Address{Id: a.Id, UserID: a.UserID, State: a.State, Pin: a.Pin}
+		for _, method := range []string{domain.ShippingStandard, domain.ShippingExpress} {
+			quote, err := i.calculator.Quote(pricing.Input{UserID: userID, Items: items, Address: &address, ShippingMethod: method})
+			if err != nil {
+				continue
+			}
+			options = append(options, models.ShippingOption{
+				AddressID:  int(a.Id),
+				Method:     method,
+				Charge:     quote.Shipping,
+				GrandTotal: quote.GrandTotal,
+			})
+		}
+	}
+
+	return options
+}
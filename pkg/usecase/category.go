@@ -41,6 +41,10 @@ func (cat *CategoryUseCase) AddCategory(category models.AddCategory) (domain.Cat
 		return domain.Category{}, errors.New("cateogory name can contain upto 6 characters only")
 	}
 
+	if err := domain.ValidateTaxClass(category.HSNCode, category.GSTRate); err != nil {
+		return domain.Category{}, err
+	}
+
 	Exist, err := cat.repo.CheckCategoryExist(category.CategoryName)
 	if err != nil {
 		return domain.Category{}, err
@@ -114,6 +118,29 @@ func (cat *CategoryUseCase) EditCategory(EditCategory models.EditCategory, id in
 	return category, nil
 }
 
+// SetCategoryTax sets the HSN code and GST rate the category's products are
+// taxed with unless they set their own.
+func (cat *CategoryUseCase) SetCategoryTax(tax models.CategoryTax, id int) (domain.Category, error) {
+	if id <= 0 {
+		return domain.Category{}, errors.New("check value properly, id cannot be negative or zero")
+	}
+
+	if err := domain.ValidateTaxClass(tax.HSNCode, tax.GSTRate); err != nil {
+		return domain.Category{}, err
+	}
+
+	exist, err := cat.repo.CheckCategoryExistByID(id)
+	if err != nil {
+		return domain.Category{}, err
+	}
+
+	if !exist {
+		return domain.Category{}, errors.New("category with this id not exist")
+	}
+
+	return cat.repo.SetCategoryTax(tax, id)
+}
+
 func (cat *CategoryUseCase) DeleteCategory(id int) error {
 	if id <=0 {
 		return errors.New("check value properly, id cannot be negative or zero")
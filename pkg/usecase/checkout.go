@@ -0,0 +1,293 @@
+package usecase
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/ahdaan98/pkg/domain"
+	"github.com/ahdaan98/pkg/pricing"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+)
+
+// checkout carries a single order through the checkout pipeline.
+type checkout struct {
+	UserID         int
+	AddressID      int
+	PaymentID      int
+	CouponID       int
+	ShippingMethod string
+
+	Cart          models.GetCartResponse
+	Address       *domain.Address
+	PaymentMethod models.PaymentMethodResponse
+	Quote         pricing.Quote
+	OrderID       int
+}
+
+// checkoutStep is one stage of the checkout pipeline. Every step runs inside
+// the checkout transaction, so returning an error rolls back the whole order.
+type checkoutStep func(c *checkout, repos interfaces.TxRepositories) error
+
+// checkoutSteps builds the pipeline every order goes through:
+// validate -> delivery -> price -> persist -> [redeem coupon] ->
+// payment deadline -> reserve stock -> clear cart.
+func (i *orderUseCase) checkoutSteps(c *checkout) []checkoutStep {
+	steps := []checkoutStep{
+		i.validateCheckout,
+		i.checkDelivery,
+		i.priceCheckout,
+		i.persistOrder,
+	}
+	if c.CouponID != 0 {
+		steps = append(steps, i.redeemCoupon)
+	}
+	return append(steps,
+		i.setPaymentDeadline,
+		i.reserveStock,
+		i.clearCart,
+	)
+}
+
+func (i *orderUseCase) validateCheckout(c *checkout, repos interfaces.TxRepositories) error {
+	if c.UserID <= 0 || c.AddressID <= 0 || c.PaymentID < 0 || c.CouponID < 0 {
+		return errors.New("enter a valid number")
+	}
+
+	exist, err := repos.Cart.CheckCart(c.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.New("cart is empty")
+	}
+
+	cart, err := checkoutCart(repos.Cart, c.UserID)
+	if err != nil {
+		return err
+	}
+	if len(cart.Data) == 0 {
+		return errors.New("cart is empty")
+	}
+	c.Cart = cart
+
+	return nil
+}
+
+// checkDelivery makes sure the order can be delivered to its address, and
+// paid for on delivery when that is the chosen payment method.
+func (i *orderUseCase) checkDelivery(c *checkout, repos interfaces.TxRepositories) error {
+	address, err := checkoutAddress(repos.Cart, c.UserID, c.AddressID)
+	if err != nil {
+		return err
+	}
+
+	pinCode, err := serviceablePin(i.pinCodes, address.Pin)
+	if err != nil {
+		return err
+	}
+
+	method, err := repos.Order.GetPaymentMethodByID(c.PaymentID)
+	if err != nil {
+		return err
+	}
+	if method.Kind == domain.PaymentKindCOD && !pinCode.CODAllowed {
+		return errors.New("cash on delivery is not available for this pin code")
+	}
+
+	c.Address = address
+	c.PaymentMethod = method
+	return nil
+}
+
+func (i *orderUseCase) priceCheckout(c *checkout, repos interfaces.TxRepositories) error {
+	quote, err := i.quoteCart(c.UserID, c.Address, c.CouponID, c.ShippingMethod, c.Cart)
+	if err != nil {
+		return err
+	}
+	c.Quote = quote
+	return nil
+}
+
+// checkoutCart reads the products in the user's cart at their current price.
+func checkoutCart(carts interfaces.CartRepository, userID int) (models.GetCartResponse, error) {
+	cartID, err := carts.GetCartId(userID)
+	if err != nil {
+		return models.GetCartResponse{}, err
+	}
+
+	items, err := carts.GetCartItems(cartID)
+	if err != nil {
+		return models.GetCartResponse{}, err
+	}
+
+	cart := models.GetCartResponse{ID: cartID, Data: items}
+	for _, item := range items {
+		cart.Subtotal += item.Total
+	}
+	return cart, nil
+}
+
+// checkoutAddress finds one of the user's addresses. Addresses of other
+// users are refused.
+func checkoutAddress(carts interfaces.CartRepository, userID, addressID int) (*domain.Address, error) {
+	address, err := carts.GetAddress(userID, addressID)
+	if err != nil {
+		return nil, err
+	}
+	if int(address.Id) != addressID {
+		return nil, domain.ErrForbidden
+	}
+	return &address, nil
+}
+
+// quoteCart prices a cart for delivery to an address by a shipping method,
+// with an optional coupon.
+func (i *orderUseCase) quoteCart(userID int, address *domain.Address, couponID int, shippingMethod string, cart models.GetCartResponse) (pricing.Quote, error) {
+	in := pricing.Input{UserID: userID, Items: cartItems(cart), Address: address, ShippingMethod: shippingMethod}
+
+	if couponID != 0 {
+		var err error
+		in.Coupon, err = i.checkoutCoupon(couponID)
+		if err != nil {
+			return pricing.Quote{}, err
+		}
+	}
+
+	return i.calculator.Quote(in)
+}
+
+// cartItems turns the products in a cart into items to price.
+func cartItems(cart models.GetCartResponse) []pricing.Item {
+	items := make([]pricing.Item, 0, len(cart.Data))
+	for _, item := range cart.Data {
+		items = append(items, pricing.Item{
+			InventoryID: item.ProductID,
+			ProductName: item.ProductName,
+			CategoryID:  item.CategoryID,
+			BrandID:     item.BrandID,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.Price,
+			WeightGrams: item.WeightGrams,
+		})
+	}
+	return items
+}
+
+func (i *orderUseCase) checkoutCoupon(couponID int) (*pricing.Coupon, error) {
+	couponIdExist, err := i.couponRepository.CheckCouponById(couponID)
+	if err != nil {
+		return nil, err
+	}
+	if !couponIdExist {
+		return nil, errors.New("coupon does not exist")
+	}
+
+	amount, err := i.couponRepository.GetCouponById(couponID)
+	if err != nil {
+		return nil, errors.New("error in getting coupon")
+	}
+
+	return &pricing.Coupon{ID: couponID, Amount: float64(amount)}, nil
+}
+
+func (i *orderUseCase) persistOrder(c *checkout, repos interfaces.TxRepositories) error {
+	totals := models.OrderTotals{
+		Subtotal:       c.Quote.Subtotal,
+		Discount:       c.Quote.Discount(),
+		ShippingMethod: c.Quote.ShippingMethod,
+		Shipping:       c.Quote.Shipping,
+		Tax:            c.Quote.Tax,
+		GrandTotal:     c.Quote.GrandTotal,
+	}
+	orderID, err := repos.Order.OrderItems(c.UserID, c.AddressID, c.PaymentID, totals)
+	if err != nil {
+		return err
+	}
+	c.OrderID = orderID
+
+	err = repos.Order.AddStatusHistory(domain.OrderStatusHistory{
+		OrderID:       uint(orderID),
+		OrderStatus:   domain.OrderStatusPending,
+		PaymentStatus: domain.PaymentStatusNotPaid,
+		ActorRole:     domain.ActorUser,
+		ActorID:       c.UserID,
+		Reason:        "order placed",
+	})
+	if err != nil {
+		return err
+	}
+
+	lines := make([]models.OrderItemLine, 0, len(c.Quote.Lines))
+	for _, l := range c.Quote.Lines {
+		line := models.OrderItemLine{
+			InventoryID: l.InventoryID,
+			Quantity:    l.Quantity,
+			UnitPrice:   l.UnitPrice,
+			TotalPrice:  l.Subtotal,
+			Discount:    l.Discount(),
+			HSNCode:     l.HSNCode,
+			TaxRate:     l.TaxRate,
+			TaxAmount:   l.Tax,
+		}
+		for _, t := range l.Taxes {
+			line.Taxes = append(line.Taxes, models.OrderItemTax{Component: t.Component, Rate: t.Rate, Amount: t.Amount})
+		}
+		lines = append(lines, line)
+	}
+
+	return repos.Order.AddOrderProducts(orderID, lines)
+}
+
+func (i *orderUseCase) redeemCoupon(c *checkout, repos interfaces.TxRepositories) error {
+	var discount float64
+	for _, d := range c.Quote.OrderDiscounts {
+		if d.Code == pricing.CouponCode {
+			discount += d.Amount
+		}
+	}
+	return repos.Coupon.RedeemCoupon(c.CouponID, c.UserID, c.OrderID, discount)
+}
+
+// setPaymentDeadline gives orders paid online a time limit; orders left unpaid
+// past it are canceled by ExpireUnpaidOrders.
+func (i *orderUseCase) setPaymentDeadline(c *checkout, repos interfaces.TxRepositories) error {
+	if c.PaymentMethod.Kind != domain.PaymentKindOnline {
+		return nil
+	}
+
+	return repos.Order.SetPaymentExpiry(c.OrderID, time.Now().Add(i.unpaidOrderTTL))
+}
+
+func (i *orderUseCase) reserveStock(c *checkout, repos interfaces.TxRepositories) error {
+	// lock the inventory rows in a fixed order so that concurrent
+	// checkouts of the same products cannot deadlock each other
+	items := make([]models.GetCart, len(c.Cart.Data))
+	copy(items, c.Cart.Data)
+	sort.Slice(items, func(a, b int) bool { return items[a].ProductID < items[b].ProductID })
+
+	for _, v := range items {
+		stock, err := repos.Inventory.LockStock(v.ProductID)
+		if err != nil {
+			return err
+		}
+		if stock.Stock < v.Quantity {
+			return &domain.InsufficientStockError{
+				ProductID:   v.ProductID,
+				ProductName: v.ProductName,
+				Requested:   v.Quantity,
+				Available:   stock.Stock,
+			}
+		}
+		if err := repos.Inventory.ReduceStock(v.ProductID, v.Quantity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (i *orderUseCase) clearCart(c *checkout, repos interfaces.TxRepositories) error {
+	return repos.Cart.ClearCart(c.Cart.ID)
+}
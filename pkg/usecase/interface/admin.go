@@ -15,7 +15,7 @@ type AdminUseCase interface {
 	Blockuser(id int) error
 	UnBlockUser(id int) error
 
-	NewPaymentMethod(string) error
+	NewPaymentMethod(name, kind string) error
 	ListPaymentMethods() ([]domain.PaymentMethod, error)
 	DeletePaymentMethod(id int) error
 
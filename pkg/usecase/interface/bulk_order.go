@@ -0,0 +1,10 @@
+package interfaces
+
+import "github.com/ahdaan98/pkg/utils/models"
+
+type BulkOrderUseCase interface {
+	StartBulkJob(adminID int, request models.BulkOrderRequest) (models.BulkJobDetails, error)
+	GetBulkJob(jobID int) (models.BulkJobDetails, error)
+	RunBulkJob(jobID int) error
+	ResumeStalledJobs() (int, error)
+}
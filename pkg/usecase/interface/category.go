@@ -8,6 +8,7 @@ import (
 type CategoryUseCase interface {
 	AddCategory(category models.AddCategory) (domain.Category, error)
 	EditCategory(EditCategory models.EditCategory, id int) (domain.Category, error)
+	SetCategoryTax(tax models.CategoryTax, id int) (domain.Category, error)
 	DeleteCategory(id int) error
 	ListCategories() ([]domain.Category, error)
 	FilterByCategory(categoryID,page, per_product int) ([]models.FilterByCategoryResponse, string, error)
@@ -0,0 +1,15 @@
+package interfaces
+
+import "github.com/ahdaan98/pkg/utils/models"
+
+type InvoiceUseCase interface {
+	GetOrderInvoice(orderID int) (models.DocumentFile, error)
+	GetUserInvoice(userID, orderID int) (models.DocumentFile, error)
+	GetInvoice(invoiceID int) (models.DocumentFile, error)
+	GetCreditNote(creditNoteID int) (models.DocumentFile, error)
+	GetUserCreditNote(userID, creditNoteID int) (models.DocumentFile, error)
+	GetInvoiceLink(orderID int) (models.InvoiceLink, error)
+	GetUserInvoiceLink(userID, orderID int) (models.InvoiceLink, error)
+	GetSignedInvoice(invoiceID int, expires int64, signature string) (models.DocumentFile, error)
+	GetOrderDocuments(orderID int) (models.OrderDocuments, error)
+}
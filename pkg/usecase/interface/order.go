@@ -2,19 +2,23 @@ package interfaces
 
 import (
 	"github.com/ahdaan98/pkg/domain"
+	"github.com/ahdaan98/pkg/pricing"
 	"github.com/ahdaan98/pkg/utils/models"
-
-	"github.com/jung-kurt/gofpdf"
 )
 
 type OrderUseCase interface {
-	OrderItemsFromCart(userid int, addressid int, paymentid int, couponid int) error
-	GetOrders(orderId int) (domain.OrderResponse, error)
+	OrderItemsFromCart(userid int, addressid int, paymentid int, couponid int, shippingMethod string) error
+	GetCheckoutQuote(userID, addressID, couponID int, shippingMethod string) (pricing.Quote, error)
+	GetOrders(userID, orderId int) (domain.OrderResponse, error)
 	GetAllOrders(userId, page, pageSize int) ([]models.OrderDetails, error)
-	CancelOrder(orderId int) error
-	GetAdminOrders(page int) ([]models.CombinedOrderDetails, error)
-	OrdersStatus(orderId int) error
-	ReturnOrder(orderID int) error
+	CancelOrder(userID, orderId int, refundTo string) error
+	GetAdminOrders(filter models.AdminOrderFilter) (models.AdminOrderList, error)
+	OrdersStatus(adminID, orderId int) error
+	ExpireUnpaidOrders() (int, error)
+	Reorder(userID, orderID int) (models.ReorderResult, error)
+	CancelOrderItems(userID, orderID int, items []models.OrderItemQuantity, refundTo string) error
+	GetUserOrderItems(userID, orderID int) ([]models.OrderItemState, error)
 	PaymentMethodID(order_id int) (int, error)
-	PrintInvoice(orderIdInt int) (*gofpdf.Fpdf, error)
+	GetOrderTimeline(orderID int) ([]domain.OrderStatusHistory, error)
+	GetUserOrderTimeline(userID, orderID int) ([]domain.OrderStatusHistory, error)
 }
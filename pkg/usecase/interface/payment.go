@@ -4,5 +4,11 @@ import "github.com/ahdaan98/pkg/utils/models"
 
 type PaymentUseCase interface {
 	MakePaymentRazorpay(orderId, userId int) (models.CombinedOrderDetails, string, error)
-	SavePaymentDetails(paymentId, razorId, orderId string) error
+	SavePaymentDetails(paymentId, razorId, orderId, signature string) error
+	RetryPayment(userID, orderID int) (models.PaymentDetails, error)
+	GetPaymentAttempts(orderID int) ([]models.PaymentDetails, error)
+	HandleRazorpayWebhook(body []byte, signature string) error
+	SendPendingRefunds() (int, error)
+	GetOrderRefunds(orderID int) ([]models.RefundDetails, error)
+	GetUserRefunds(userID, orderID int) ([]models.RefundDetails, error)
 }
\ No newline at end of file
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"io"
+
+	"github.com/ahdaan98/pkg/utils/models"
+)
+
+type PinCodeUseCase interface {
+	ImportPinCodes(file io.Reader) (models.PinCodeImport, error)
+	SetPinCode(pin models.PinCode) (models.PinCode, error)
+	GetPinCodes(page, count int) ([]models.PinCode, error)
+	DeletePinCode(pin string) error
+	CheckDelivery(productID int, pin string) (models.DeliveryEstimate, error)
+}
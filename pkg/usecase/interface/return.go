@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/ahdaan98/pkg/utils/models"
+)
+
+type ReturnUseCase interface {
+	RequestReturn(userID int, request models.ReturnRequest) (models.ReturnRequestDetails, error)
+	ReturnOrder(userID, orderID int, damaged []models.DamagedItem) (models.ReturnRequestDetails, error)
+	GetUserReturns(userID int) ([]models.ReturnRequestDetails, error)
+	GetUserReturn(userID, returnID int) (models.ReturnRequestDetails, error)
+	CancelReturn(userID, returnID int) error
+
+	GetReturns(status string, page int) ([]models.ReturnRequestDetails, error)
+	GetReturn(returnID int) (models.ReturnRequestDetails, error)
+	ApproveReturn(returnID int, note string) error
+	RejectReturn(returnID int, note string) error
+	SchedulePickup(returnID int, pickupDate time.Time) error
+	ReceiveReturn(returnID int) error
+	InspectReturn(returnID int, items []models.InspectedItem) error
+	RefundReturn(adminID, returnID int) error
+}
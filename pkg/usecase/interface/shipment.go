@@ -0,0 +1,10 @@
+package interfaces
+
+import "github.com/ahdaan98/pkg/utils/models"
+
+type ShipmentUseCase interface {
+	CreateShipment(adminID, orderID int, shipment models.ShipmentRequest) (models.ShipmentDetails, error)
+	GetShipment(orderID int) (models.ShipmentDetails, error)
+	GetUserShipment(userID, orderID int) (models.ShipmentDetails, error)
+	RecordTrackingEvent(event models.ShipmentEventRequest) error
+}
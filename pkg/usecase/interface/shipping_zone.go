@@ -0,0 +1,11 @@
+package interfaces
+
+import "github.com/ahdaan98/pkg/utils/models"
+
+type ShippingZoneUseCase interface {
+	AddShippingZone(zone models.ShippingZoneRequest) (models.ShippingZone, error)
+	EditShippingZone(id int, zone models.ShippingZoneRequest) (models.ShippingZone, error)
+	DeleteShippingZone(id int) error
+	GetShippingZones() ([]models.ShippingZone, error)
+	GetShippingZone(id int) (models.ShippingZone, error)
+}
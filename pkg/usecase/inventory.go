@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/ahdaan98/pkg/config"
+	"github.com/ahdaan98/pkg/domain"
 	repo "github.com/ahdaan98/pkg/repository/interface"
 	usecase "github.com/ahdaan98/pkg/usecase/interface"
 	"github.com/ahdaan98/pkg/utils/models"
@@ -25,6 +26,13 @@ func (i *InventoryUseCase) AddInventory(inventory models.AddInventory) (models.I
 		return models.InventoryResponse{}, errors.New("check values properly, id cannot be negative or zero")
 	}
 
+	if inventory.WeightGrams < 0 {
+		return models.InventoryResponse{}, errors.New("weight cannot be negative")
+	}
+	if err := validateProductTax(inventory.HSNCode, inventory.GSTRate); err != nil {
+		return models.InventoryResponse{}, err
+	}
+
 	Exist, err := i.repository.CheckInventoryExist(inventory.ProductName)
 	if err != nil || Exist {
 		return models.InventoryResponse{}, err
@@ -49,6 +57,12 @@ func (i *InventoryUseCase) EditInventory(inventory models.EditInventory, id int)
 	if inventory.BrandID <= 0 || inventory.CategoryID <= 0 || inventory.Price <= 0 || id <= 0 {
 		return models.InventoryResponse{}, errors.New("check values properly, id cannot be negative or zero")
 	}
+	if inventory.WeightGrams < 0 {
+		return models.InventoryResponse{}, errors.New("weight cannot be negative")
+	}
+	if err := validateProductTax(inventory.HSNCode, inventory.GSTRate); err != nil {
+		return models.InventoryResponse{}, err
+	}
 	Exist, err := i.repository.CheckInventoryExist(inventory.ProductName)
 	if err != nil {
 		return models.InventoryResponse{}, err
@@ -182,3 +196,13 @@ func (uc *InventoryUseCase) ListProductsWithImages(page, per_product int) ([]mod
 
 	return responseList, nil
 }
+
+// validateProductTax checks the HSN code and GST rate a product overrides its
+// category's with. A nil rate keeps the category's.
+func validateProductTax(hsnCode string, gstRate *float64) error {
+	rate := 0.0
+	if gstRate != nil {
+		rate = *gstRate
+	}
+	return domain.ValidateTaxClass(hsnCode, rate)
+}
@@ -0,0 +1,644 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ahdaan98/pkg/config"
+	"github.com/ahdaan98/pkg/domain"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	services "github.com/ahdaan98/pkg/usecase/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+	"github.com/jung-kurt/gofpdf"
+)
+
+const sellerName = "Leuse India Pvt Ltd"
+
+const (
+	defaultInvoiceDir     = "invoices"
+	defaultInvoiceLinkTTL = 72 * time.Hour
+)
+
+var errInvalidInvoiceLink = errors.New("invoice link is invalid or has expired")
+
+type invoiceUseCase struct {
+	invoiceRepository interfaces.InvoiceRepository
+	orderRepository   interfaces.OrderRepository
+	dir               string
+	sellerGSTIN       string
+	sellerState       string
+	linkSecret        []byte
+	linkTTL           time.Duration
+}
+
+func NewInvoiceUseCase(repo interfaces.InvoiceRepository, orderRepo interfaces.OrderRepository, cfg config.Config) services.InvoiceUseCase {
+	dir := cfg.INVOICE_DIR
+	if dir == "" {
+		dir = defaultInvoiceDir
+	}
+	ttl, err := time.ParseDuration(cfg.INVOICE_LINK_TTL)
+	if err != nil || ttl <= 0 {
+		ttl = defaultInvoiceLinkTTL
+	}
+
+	return &invoiceUseCase{
+		invoiceRepository: repo,
+		orderRepository:   orderRepo,
+		dir:               dir,
+		sellerGSTIN:       cfg.SELLER_GSTIN,
+		sellerState:       cfg.SELLER_STATE,
+		linkSecret:        []byte(cfg.INVOICE_LINK_SECRET),
+		linkTTL:           ttl,
+	}
+}
+
+// issueInvoice gives a delivered order the next invoice number of the
+// financial year and copies the buyer onto it. Orders are only ever invoiced
+// once.
+func issueInvoice(repos interfaces.TxRepositories, orderID int, at time.Time) error {
+	repo := repos.Invoice
+	invoice, err := repo.GetInvoiceByOrder(orderID)
+	if err != nil {
+		return err
+	}
+	if invoice.ID != 0 {
+		return nil
+	}
+
+	year := domain.FinancialYear(at)
+	sequence, err := repo.NextDocumentNumber(domain.SeriesInvoice, year)
+	if err != nil {
+		return err
+	}
+
+	order, err := repos.Order.GetDetailedOrderThroughId(orderID)
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.CreateInvoice(models.Invoice{
+		OrderID:       orderID,
+		Number:        domain.DocumentNumber(domain.SeriesInvoice, year, sequence),
+		FinancialYear: year,
+		IssuedAt:      at,
+		Buyer: models.InvoiceBuyer{
+			Name:      order.Name,
+			Email:     order.Email,
+			Phone:     order.Phone,
+			HouseName: order.HouseName,
+			Street:    order.Street,
+			City:      order.City,
+			State:     order.State,
+			Pin:       order.Pin,
+		},
+	})
+	return err
+}
+
+// issueCreditNote credits canceled or returned items against the order's
+// invoice. Orders that were never invoiced need no credit note.
+func issueCreditNote(repo interfaces.InvoiceRepository, orderID int, items []models.CreditNoteItem, reason string, at time.Time) error {
+	invoice, err := repo.GetInvoiceByOrder(orderID)
+	if err != nil {
+		return err
+	}
+	if invoice.ID == 0 || len(items) == 0 {
+		return nil
+	}
+
+	var amount float64
+	for _, item := range items {
+		amount += item.Amount
+	}
+
+	year := domain.FinancialYear(at)
+	sequence, err := repo.NextDocumentNumber(domain.SeriesCreditNote, year)
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.CreateCreditNote(models.CreditNote{
+		InvoiceID:     invoice.ID,
+		OrderID:       orderID,
+		Number:        domain.DocumentNumber(domain.SeriesCreditNote, year, sequence),
+		FinancialYear: year,
+		Amount:        math.Round(amount*100) / 100,
+		Reason:        reason,
+		IssuedAt:      at,
+		Items:         items,
+	})
+	return err
+}
+
+func (i *invoiceUseCase) GetOrderInvoice(orderID int) (models.DocumentFile, error) {
+	if orderID < 1 {
+		return models.DocumentFile{}, errors.New("enter a valid order id")
+	}
+
+	invoice, err := i.invoiceRepository.GetInvoiceByOrder(orderID)
+	if err != nil {
+		return models.DocumentFile{}, err
+	}
+	if invoice.ID == 0 {
+		return models.DocumentFile{}, errors.New("wait for the invoice until the product is received")
+	}
+
+	return i.invoiceFile(invoice)
+}
+
+// GetUserInvoice sends the invoice of an order placed by the user.
+func (i *invoiceUseCase) GetUserInvoice(userID, orderID int) (models.DocumentFile, error) {
+	if err := authorizeOrder(i.orderRepository, userID, orderID); err != nil {
+		return models.DocumentFile{}, err
+	}
+
+	return i.GetOrderInvoice(orderID)
+}
+
+func (i *invoiceUseCase) GetInvoice(invoiceID int) (models.DocumentFile, error) {
+	if invoiceID < 1 {
+		return models.DocumentFile{}, errors.New("enter a valid invoice id")
+	}
+
+	invoice, err := i.invoiceRepository.GetInvoice(invoiceID)
+	if err != nil {
+		return models.DocumentFile{}, err
+	}
+
+	return i.invoiceFile(invoice)
+}
+
+func (i *invoiceUseCase) GetCreditNote(creditNoteID int) (models.DocumentFile, error) {
+	if creditNoteID < 1 {
+		return models.DocumentFile{}, errors.New("enter a valid credit note id")
+	}
+
+	note, err := i.invoiceRepository.GetCreditNote(creditNoteID)
+	if err != nil {
+		return models.DocumentFile{}, err
+	}
+
+	file := models.DocumentFile{FileName: documentFileName(note.Number), Path: note.FilePath}
+	if storedFileExists(note.FilePath) {
+		return file, nil
+	}
+
+	invoice, err := i.invoiceRepository.GetInvoice(note.InvoiceID)
+	if err != nil {
+		return models.DocumentFile{}, err
+	}
+
+	file.Path, err = i.store(i.renderCreditNote(note, invoice.Buyer), file.FileName)
+	if err != nil {
+		return models.DocumentFile{}, err
+	}
+	if err := i.invoiceRepository.SetCreditNoteFile(note.ID, file.Path); err != nil {
+		return models.DocumentFile{}, err
+	}
+
+	return file, nil
+}
+
+// GetUserCreditNote sends a credit note issued against an order of the user.
+func (i *invoiceUseCase) GetUserCreditNote(userID, creditNoteID int) (models.DocumentFile, error) {
+	if creditNoteID < 1 {
+		return models.DocumentFile{}, errors.New("enter a valid credit note id")
+	}
+
+	note, err := i.invoiceRepository.GetCreditNote(creditNoteID)
+	if err != nil {
+		return models.DocumentFile{}, err
+	}
+	if err := authorizeOrder(i.orderRepository, userID, note.OrderID); err != nil {
+		return models.DocumentFile{}, err
+	}
+
+	return i.GetCreditNote(creditNoteID)
+}
+
+// GetInvoiceLink signs a link to the invoice of an order that can be
+// downloaded without logging in until it expires, for use in emails.
+func (i *invoiceUseCase) GetInvoiceLink(orderID int) (models.InvoiceLink, error) {
+	if len(i.linkSecret) == 0 {
+		return models.InvoiceLink{}, errors.New("invoice links are not enabled")
+	}
+	if orderID < 1 {
+		return models.InvoiceLink{}, errors.New("enter a valid order id")
+	}
+
+	invoice, err := i.invoiceRepository.GetInvoiceByOrder(orderID)
+	if err != nil {
+		return models.InvoiceLink{}, err
+	}
+	if invoice.ID == 0 {
+		return models.InvoiceLink{}, errors.New("wait for the invoice until the product is received")
+	}
+
+	expiresAt := time.Now().Add(i.linkTTL).Truncate(time.Second)
+	return models.InvoiceLink{
+		InvoiceID: invoice.ID,
+		Expires:   expiresAt.Unix(),
+		Signature: i.signInvoiceLink(invoice.ID, expiresAt.Unix()),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (i *invoiceUseCase) GetUserInvoiceLink(userID, orderID int) (models.InvoiceLink, error) {
+	if err := authorizeOrder(i.orderRepository, userID, orderID); err != nil {
+		return models.InvoiceLink{}, err
+	}
+
+	return i.GetInvoiceLink(orderID)
+}
+
+// GetSignedInvoice sends the invoice a signed link points to. Links that were
+// tampered with or have expired are refused.
+func (i *invoiceUseCase) GetSignedInvoice(invoiceID int, expires int64, signature string) (models.DocumentFile, error) {
+	if len(i.linkSecret) == 0 || invoiceID < 1 || time.Now().Unix() > expires {
+		return models.DocumentFile{}, errInvalidInvoiceLink
+	}
+
+	given, err := hex.DecodeString(signature)
+	if err != nil {
+		return models.DocumentFile{}, errInvalidInvoiceLink
+	}
+	want, _ := hex.DecodeString(i.signInvoiceLink(invoiceID, expires))
+	if !hmac.Equal(given, want) {
+		return models.DocumentFile{}, errInvalidInvoiceLink
+	}
+
+	return i.GetInvoice(invoiceID)
+}
+
+func (i *invoiceUseCase) signInvoiceLink(invoiceID int, expires int64) string {
+	mac := hmac.New(sha256.New, i.linkSecret)
+	fmt.Fprintf(mac, "invoice:%d:%d", invoiceID, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (i *invoiceUseCase) GetOrderDocuments(orderID int) (models.OrderDocuments, error) {
+	if orderID < 1 {
+		return models.OrderDocuments{}, errors.New("enter a valid order id")
+	}
+
+	var documents models.OrderDocuments
+	invoice, err := i.invoiceRepository.GetInvoiceByOrder(orderID)
+	if err != nil {
+		return models.OrderDocuments{}, err
+	}
+	if invoice.ID != 0 {
+		documents.Invoice = &invoice
+	}
+
+	documents.CreditNotes, err = i.invoiceRepository.GetCreditNotesByOrder(orderID)
+	if err != nil {
+		return models.OrderDocuments{}, err
+	}
+
+	return documents, nil
+}
+
+// invoiceFile returns the stored PDF of an invoice, rendering and storing it
+// the first time. The buyer is the one copied onto the invoice.
+func (i *invoiceUseCase) invoiceFile(invoice models.Invoice) (models.DocumentFile, error) {
+	file := models.DocumentFile{FileName: documentFileName(invoice.Number), Path: invoice.FilePath}
+	if storedFileExists(invoice.FilePath) {
+		return file, nil
+	}
+
+	order, err := i.orderRepository.GetDetailedOrderThroughId(invoice.OrderID)
+	if err != nil {
+		return models.DocumentFile{}, err
+	}
+
+	items, err := i.orderRepository.GetItemsByOrderId(invoice.OrderID)
+	if err != nil {
+		return models.DocumentFile{}, err
+	}
+
+	taxes, err := i.orderRepository.GetOrderItemTaxes(invoice.OrderID)
+	if err != nil {
+		return models.DocumentFile{}, err
+	}
+	for idx := range items {
+		for _, t := range taxes {
+			if t.OrderItemID == items[idx].OrderItemID {
+				items[idx].Taxes = append(items[idx].Taxes, t)
+			}
+		}
+	}
+
+	file.Path, err = i.store(i.renderInvoice(invoice, order, items), file.FileName)
+	if err != nil {
+		return models.DocumentFile{}, err
+	}
+	if err := i.invoiceRepository.SetInvoiceFile(invoice.ID, file.Path); err != nil {
+		return models.DocumentFile{}, err
+	}
+
+	return file, nil
+}
+
+// store writes a rendered document into the invoice directory.
+func (i *invoiceUseCase) store(pdf *gofpdf.Fpdf, name string) (string, error) {
+	if err := os.MkdirAll(i.dir, 0o755); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(i.dir, name)
+	if err := pdf.OutputFileAndClose(path); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+func storedFileExists(path string) bool {
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// documentFileName turns a document number such as INV/2024-25/000042 into
+// a file name.
+func documentFileName(number string) string {
+	return strings.ReplaceAll(number, "/", "-") + ".pdf"
+}
+
+// invoiceTaxGroup is a row of the tax breakup: the items sold under one HSN
+// code at one GST rate.
+type invoiceTaxGroup struct {
+	HSNCode string
+	Rate    float64
+	Taxable float64
+	Tax     map[string]float64
+}
+
+// renderInvoice lays out a GST tax invoice: the seller and buyer with their
+// states, every item with its taxable value and GST components, the tax
+// broken up by HSN code and rate, and the order totals.
+func (i *invoiceUseCase) renderInvoice(invoice models.Invoice, order models.CombinedOrderDetails, items []models.ItemDetails) *gofpdf.Fpdf {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddPage()
+
+	pdf.SetFont("Arial", "B", 24)
+	pdf.SetTextColor(31, 73, 125)
+	pdf.Cell(0, 14, "Tax Invoice")
+	pdf.Ln(14)
+
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Arial", "B", 12)
+	pdf.Cell(0, 6, sellerName)
+	pdf.Ln(6)
+	pdf.SetFont("Arial", "", 10)
+	i.renderSeller(pdf)
+	pdf.Cell(0, 5, "Invoice No: "+invoice.Number)
+	pdf.Ln(5)
+	pdf.Cell(0, 5, "Invoice Date: "+invoice.IssuedAt.Format("02-01-2006"))
+	pdf.Ln(5)
+	pdf.Cell(0, 5, "Order: "+order.OrderId)
+	pdf.Ln(5)
+	if order.OrderDate != nil {
+		pdf.Cell(0, 5, "Order Date: "+order.OrderDate.Format("02-01-2006"))
+		pdf.Ln(5)
+	}
+	pdf.Ln(3)
+
+	pdf.SetFont("Arial", "B", 11)
+	pdf.Cell(0, 6, "Bill To / Ship To")
+	pdf.Ln(6)
+	pdf.SetFont("Arial", "", 10)
+	renderBuyer(pdf, invoice.Buyer)
+	pdf.Cell(0, 5, "Place of Supply: "+invoice.Buyer.State)
+	pdf.Ln(8)
+
+	// the core PDF fonts have no rupee sign
+	pdf.SetFont("Arial", "I", 9)
+	pdf.Cell(0, 5, "All amounts are in Indian Rupees (INR)")
+	pdf.Ln(6)
+
+	headers := []string{"Item", "HSN", "Qty", "Rate", "Discount", "Taxable", "CGST", "SGST", "IGST", "Total"}
+	widths := []float64{42, 16, 10, 18, 16, 20, 17, 17, 17, 17}
+	pdf.SetFont("Arial", "B", 9)
+	pdf.SetFillColor(217, 217, 217)
+	for c, h := range headers {
+		pdf.CellFormat(widths[c], 8, h, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(8)
+
+	var groups []*invoiceTaxGroup
+	taxTotals := map[string]float64{}
+	var taxable float64
+
+	pdf.SetFont("Arial", "", 9)
+	for _, item := range items {
+		value := item.Total - item.Discount
+		taxable += value
+
+		components := map[string]float64{}
+		for _, t := range item.Taxes {
+			components[t.Component] += t.Amount
+			taxTotals[t.Component] += t.Amount
+		}
+
+		var group *invoiceTaxGroup
+		for _, g := range groups {
+			if g.HSNCode == item.HSNCode && g.Rate == item.TaxRate {
+				group = g
+			}
+		}
+		if group == nil {
+			group = &invoiceTaxGroup{HSNCode: item.HSNCode, Rate: item.TaxRate, Tax: map[string]float64{}}
+			groups = append(groups, group)
+		}
+		group.Taxable += value
+		for c, amount := range components {
+			group.Tax[c] += amount
+		}
+
+		cells := []string{
+			item.ProductName,
+			item.HSNCode,
+			strconv.Itoa(item.Quantity),
+			invoiceAmount(item.Price),
+			invoiceAmount(item.Discount),
+			invoiceAmount(value),
+			invoiceAmount(components[domain.TaxCGST]),
+			invoiceAmount(components[domain.TaxSGST]),
+			invoiceAmount(components[domain.TaxIGST]),
+			invoiceAmount(value + item.TaxAmount),
+		}
+		for c, cell := range cells {
+			align := "R"
+			if c < 3 {
+				align = "L"
+			}
+			pdf.CellFormat(widths[c], 8, cell, "1", 0, align, false, 0, "")
+		}
+		pdf.Ln(8)
+	}
+	pdf.Ln(6)
+
+	pdf.SetFont("Arial", "B", 10)
+	pdf.Cell(0, 6, "Tax Breakup")
+	pdf.Ln(6)
+	pdf.SetFont("Arial", "B", 9)
+	for _, h := range []string{"HSN", "GST Rate", "Taxable", "CGST", "SGST", "IGST"} {
+		pdf.CellFormat(30, 8, h, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(8)
+	pdf.SetFont("Arial", "", 9)
+	for _, g := range groups {
+		cells := []string{
+			g.HSNCode,
+			strconv.FormatFloat(g.Rate, 'f', -1, 64) + "%",
+			invoiceAmount(g.Taxable),
+			invoiceAmount(g.Tax[domain.TaxCGST]),
+			invoiceAmount(g.Tax[domain.TaxSGST]),
+			invoiceAmount(g.Tax[domain.TaxIGST]),
+		}
+		for _, cell := range cells {
+			pdf.CellFormat(30, 8, cell, "1", 0, "R", false, 0, "")
+		}
+		pdf.Ln(8)
+	}
+	pdf.Ln(6)
+
+	// the totals are the quote the order was placed with
+	totals := []struct {
+		label  string
+		amount float64
+	}{
+		{"Subtotal:", order.Subtotal},
+		{"Discount:", order.Discount},
+		{"Taxable Value:", taxable},
+		{"CGST:", taxTotals[domain.TaxCGST]},
+		{"SGST:", taxTotals[domain.TaxSGST]},
+		{"IGST:", taxTotals[domain.TaxIGST]},
+		{shippingLabel(order.ShippingMethod), order.Shipping},
+		{"Final Amount:", order.FinalPrice},
+	}
+	pdf.SetFont("Arial", "B", 11)
+	for _, t := range totals {
+		pdf.CellFormat(150, 8, t.label, "1", 0, "R", true, 0, "")
+		pdf.CellFormat(40, 8, invoiceAmount(t.amount), "1", 0, "R", false, 0, "")
+		pdf.Ln(8)
+	}
+	pdf.Ln(4)
+
+	renderFooter(pdf)
+
+	return pdf
+}
+
+// renderCreditNote lays out a credit note: the invoice it is issued against,
+// the credited items and the amount credited.
+func (i *invoiceUseCase) renderCreditNote(note models.CreditNote, buyer models.InvoiceBuyer) *gofpdf.Fpdf {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddPage()
+
+	pdf.SetFont("Arial", "B", 24)
+	pdf.SetTextColor(31, 73, 125)
+	pdf.Cell(0, 14, "Credit Note")
+	pdf.Ln(14)
+
+	pdf.SetTextColor(0, 0, 0)
+	i.renderSeller(pdf)
+	for _, line := range []string{
+		"Credit Note No: " + note.Number,
+		"Credit Note Date: " + note.IssuedAt.Format("02-01-2006"),
+		"Against Invoice: " + note.InvoiceNumber,
+		"Order: " + strconv.Itoa(note.OrderID),
+		"Reason: " + note.Reason,
+	} {
+		pdf.Cell(0, 5, line)
+		pdf.Ln(5)
+	}
+	pdf.Ln(3)
+
+	pdf.SetFont("Arial", "B", 11)
+	pdf.Cell(0, 6, "Customer")
+	pdf.Ln(6)
+	pdf.SetFont("Arial", "", 10)
+	renderBuyer(pdf, buyer)
+	pdf.Ln(3)
+
+	pdf.SetFont("Arial", "I", 9)
+	pdf.Cell(0, 5, "All amounts are in Indian Rupees (INR)")
+	pdf.Ln(6)
+
+	widths := []float64{110, 30, 50}
+	pdf.SetFont("Arial", "B", 9)
+	pdf.SetFillColor(217, 217, 217)
+	for c, h := range []string{"Item", "Qty", "Amount"} {
+		pdf.CellFormat(widths[c], 8, h, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(8)
+
+	pdf.SetFont("Arial", "", 9)
+	for _, item := range note.Items {
+		pdf.CellFormat(widths[0], 8, item.ProductName, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[1], 8, strconv.Itoa(item.Quantity), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[2], 8, invoiceAmount(item.Amount), "1", 0, "R", false, 0, "")
+		pdf.Ln(8)
+	}
+
+	pdf.SetFont("Arial", "B", 11)
+	pdf.CellFormat(widths[0]+widths[1], 8, "Total Credit:", "1", 0, "R", true, 0, "")
+	pdf.CellFormat(widths[2], 8, invoiceAmount(note.Amount), "1", 0, "R", false, 0, "")
+	pdf.Ln(12)
+
+	renderFooter(pdf)
+
+	return pdf
+}
+
+func (i *invoiceUseCase) renderSeller(pdf *gofpdf.Fpdf) {
+	pdf.SetFont("Arial", "B", 12)
+	pdf.Cell(0, 6, sellerName)
+	pdf.Ln(6)
+	pdf.SetFont("Arial", "", 10)
+	if i.sellerGSTIN != "" {
+		pdf.Cell(0, 5, "GSTIN: "+i.sellerGSTIN)
+		pdf.Ln(5)
+	}
+	if i.sellerState != "" {
+		pdf.Cell(0, 5, "State: "+i.sellerState)
+		pdf.Ln(5)
+	}
+}
+
+func renderBuyer(pdf *gofpdf.Fpdf, buyer models.InvoiceBuyer) {
+	for _, line := range []string{buyer.Name, buyer.HouseName, buyer.Street, buyer.City + ", " + buyer.State + " - " + buyer.Pin} {
+		pdf.Cell(0, 5, line)
+		pdf.Ln(5)
+	}
+}
+
+func renderFooter(pdf *gofpdf.Fpdf) {
+	pdf.SetFont("Arial", "I", 9)
+	pdf.Cell(0, 6, "Generated by "+sellerName+". - "+time.Now().Format("2006-01-02 15:04:05"))
+	pdf.Ln(6)
+}
+
+func invoiceAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+func shippingLabel(method string) string {
+	if method == "" {
+		return "Shipping:"
+	}
+	return "Shipping (" + method + "):"
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ahdaan98/pkg/config"
+	"github.com/ahdaan98/pkg/domain"
+	repo_mocks "github.com/ahdaan98/pkg/repository/mocks"
+	"github.com/ahdaan98/pkg/utils/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUserInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	invoices := repo_mocks.NewMockInvoiceRepository(ctrl)
+	orders := repo_mocks.NewMockOrderRepository(ctrl)
+	uc := NewInvoiceUseCase(invoices, orders, config.Config{INVOICE_DIR: t.TempDir()})
+
+	// an invoice that was rendered before is served from disk
+	path := filepath.Join(t.TempDir(), "INV-2024-25-000001.pdf")
+	assert.NoError(t, os.WriteFile(path, []byte("%PDF"), 0o644))
+
+	orders.EXPECT().FindUserID(5).Return(1, nil).Times(2)
+	invoices.EXPECT().GetInvoiceByOrder(5).Return(models.Invoice{ID: 3, OrderID: 5, Number: "INV/2024-25/000001", FilePath: path}, nil)
+
+	file, err := uc.GetUserInvoice(1, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, models.DocumentFile{FileName: "INV-2024-25-000001.pdf", Path: path}, file)
+
+	_, err = uc.GetUserInvoice(2, 5)
+	assert.Equal(t, domain.ErrForbidden, err)
+}
+
+func TestSignedInvoiceLink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	invoices := repo_mocks.NewMockInvoiceRepository(ctrl)
+	orders := repo_mocks.NewMockOrderRepository(ctrl)
+	uc := NewInvoiceUseCase(invoices, orders, config.Config{INVOICE_DIR: t.TempDir(), INVOICE_LINK_SECRET: "secret", INVOICE_LINK_TTL: "1h"})
+
+	path := filepath.Join(t.TempDir(), "INV-2024-25-000001.pdf")
+	assert.NoError(t, os.WriteFile(path, []byte("%PDF"), 0o644))
+	invoice := models.Invoice{ID: 3, OrderID: 5, Number: "INV/2024-25/000001", FilePath: path}
+
+	orders.EXPECT().FindUserID(5).Return(1, nil)
+	invoices.EXPECT().GetInvoiceByOrder(5).Return(invoice, nil)
+
+	link, err := uc.GetUserInvoiceLink(1, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, link.InvoiceID)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), link.ExpiresAt, time.Minute)
+
+	invoices.EXPECT().GetInvoice(3).Return(invoice, nil)
+	file, err := uc.GetSignedInvoice(link.InvoiceID, link.Expires, link.Signature)
+	assert.NoError(t, err)
+	assert.Equal(t, path, file.Path)
+
+	// the signature covers both the invoice and the expiry
+	_, err = uc.GetSignedInvoice(4, link.Expires, link.Signature)
+	assert.Equal(t, errInvalidInvoiceLink, err)
+	_, err = uc.GetSignedInvoice(3, link.Expires+3600, link.Signature)
+	assert.Equal(t, errInvalidInvoiceLink, err)
+	_, err = uc.GetSignedInvoice(3, link.Expires, "not-hex")
+	assert.Equal(t, errInvalidInvoiceLink, err)
+
+	expired := time.Now().Add(-time.Minute).Unix()
+	_, err = uc.GetSignedInvoice(3, expired, uc.(*invoiceUseCase).signInvoiceLink(3, expired))
+	assert.Equal(t, errInvalidInvoiceLink, err)
+}
+
+func TestCreditNoteUsesInvoiceBuyer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	invoices := repo_mocks.NewMockInvoiceRepository(ctrl)
+	orders := repo_mocks.NewMockOrderRepository(ctrl)
+	uc := NewInvoiceUseCase(invoices, orders, config.Config{INVOICE_DIR: t.TempDir()})
+
+	// the buyer comes from the invoice, never from the user's current profile
+	buyer := models.InvoiceBuyer{Name: "Asha", HouseName: "Rose Villa", Street: "MG Road", City: "Kochi", State: "Kerala", Pin: "682001"}
+	invoices.EXPECT().GetCreditNote(9).Return(models.CreditNote{
+		ID:            9,
+		InvoiceID:     3,
+		InvoiceNumber: "INV/2024-25/000001",
+		OrderID:       5,
+		Number:        "CN/2024-25/000001",
+		Amount:        180,
+		Reason:        "items returned by customer",
+		IssuedAt:      time.Now(),
+		Items:         []models.CreditNoteItem{{OrderItemID: 20, ProductName: "Soap", Quantity: 1, Amount: 180}},
+	}, nil)
+	invoices.EXPECT().GetInvoice(3).Return(models.Invoice{ID: 3, OrderID: 5, Number: "INV/2024-25/000001", Buyer: buyer}, nil)
+	invoices.EXPECT().SetCreditNoteFile(9, gomock.Any()).Return(nil)
+
+	file, err := uc.GetCreditNote(9)
+	assert.NoError(t, err)
+	assert.Equal(t, "CN-2024-25-000001.pdf", file.FileName)
+	assert.FileExists(t, file.Path)
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/usecase/interface/bulk_order.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	reflect "reflect"
+
+	models "github.com/ahdaan98/pkg/utils/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBulkOrderUseCase is a mock of BulkOrderUseCase interface.
+type MockBulkOrderUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockBulkOrderUseCaseMockRecorder
+}
+
+// MockBulkOrderUseCaseMockRecorder is the mock recorder for MockBulkOrderUseCase.
+type MockBulkOrderUseCaseMockRecorder struct {
+	mock *MockBulkOrderUseCase
+}
+
+// NewMockBulkOrderUseCase creates a new mock instance.
+func NewMockBulkOrderUseCase(ctrl *gomock.Controller) *MockBulkOrderUseCase {
+	mock := &MockBulkOrderUseCase{ctrl: ctrl}
+	mock.recorder = &MockBulkOrderUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBulkOrderUseCase) EXPECT() *MockBulkOrderUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetBulkJob mocks base method.
+func (m *MockBulkOrderUseCase) GetBulkJob(jobID int) (models.BulkJobDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBulkJob", jobID)
+	ret0, _ := ret[0].(models.BulkJobDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBulkJob indicates an expected call of GetBulkJob.
+func (mr *MockBulkOrderUseCaseMockRecorder) GetBulkJob(jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBulkJob", reflect.TypeOf((*MockBulkOrderUseCase)(nil).GetBulkJob), jobID)
+}
+
+// ResumeStalledJobs mocks base method.
+func (m *MockBulkOrderUseCase) ResumeStalledJobs() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeStalledJobs")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeStalledJobs indicates an expected call of ResumeStalledJobs.
+func (mr *MockBulkOrderUseCaseMockRecorder) ResumeStalledJobs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeStalledJobs", reflect.TypeOf((*MockBulkOrderUseCase)(nil).ResumeStalledJobs))
+}
+
+// RunBulkJob mocks base method.
+func (m *MockBulkOrderUseCase) RunBulkJob(jobID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunBulkJob", jobID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunBulkJob indicates an expected call of RunBulkJob.
+func (mr *MockBulkOrderUseCaseMockRecorder) RunBulkJob(jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunBulkJob", reflect.TypeOf((*MockBulkOrderUseCase)(nil).RunBulkJob), jobID)
+}
+
+// StartBulkJob mocks base method.
+func (m *MockBulkOrderUseCase) StartBulkJob(adminID int, request models.BulkOrderRequest) (models.BulkJobDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartBulkJob", adminID, request)
+	ret0, _ := ret[0].(models.BulkJobDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartBulkJob indicates an expected call of StartBulkJob.
+func (mr *MockBulkOrderUseCaseMockRecorder) StartBulkJob(adminID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartBulkJob", reflect.TypeOf((*MockBulkOrderUseCase)(nil).StartBulkJob), adminID, request)
+}
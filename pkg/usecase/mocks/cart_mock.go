@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/usecase/interface/cart.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	reflect "reflect"
+
+	models "github.com/ahdaan98/pkg/utils/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCartUseCase is a mock of CartUseCase interface.
+type MockCartUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCartUseCaseMockRecorder
+}
+
+// MockCartUseCaseMockRecorder is the mock recorder for MockCartUseCase.
+type MockCartUseCaseMockRecorder struct {
+	mock *MockCartUseCase
+}
+
+// NewMockCartUseCase creates a new mock instance.
+func NewMockCartUseCase(ctrl *gomock.Controller) *MockCartUseCase {
+	mock := &MockCartUseCase{ctrl: ctrl}
+	mock.recorder = &MockCartUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCartUseCase) EXPECT() *MockCartUseCaseMockRecorder {
+	return m.recorder
+}
+
+// AddToCart mocks base method.
+func (m *MockCartUseCase) AddToCart(user_id, inventory_id, qty int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToCart", user_id, inventory_id, qty)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToCart indicates an expected call of AddToCart.
+func (mr *MockCartUseCaseMockRecorder) AddToCart(user_id, inventory_id, qty interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToCart", reflect.TypeOf((*MockCartUseCase)(nil).AddToCart), user_id, inventory_id, qty)
+}
+
+// CheckOut mocks base method.
+func (m *MockCartUseCase) CheckOut(id int) (models.CheckOut, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckOut", id)
+	ret0, _ := ret[0].(models.CheckOut)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckOut indicates an expected call of CheckOut.
+func (mr *MockCartUseCaseMockRecorder) CheckOut(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckOut", reflect.TypeOf((*MockCartUseCase)(nil).CheckOut), id)
+}
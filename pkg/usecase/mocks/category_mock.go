@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: C:\Users\ahdaa\GOLANG\Leuse_Ecommerce\pkg\usecase\interface\category.go
+// Source: pkg/usecase/interface/category.go
 
 // Package mock_interfaces is a generated GoMock package.
 package mock_interfaces
@@ -109,3 +109,18 @@ func (mr *MockCategoryUseCaseMockRecorder) ListCategories() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockCategoryUseCase)(nil).ListCategories))
 }
+
+// SetCategoryTax mocks base method.
+func (m *MockCategoryUseCase) SetCategoryTax(tax models.CategoryTax, id int) (domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCategoryTax", tax, id)
+	ret0, _ := ret[0].(domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCategoryTax indicates an expected call of SetCategoryTax.
+func (mr *MockCategoryUseCaseMockRecorder) SetCategoryTax(tax, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCategoryTax", reflect.TypeOf((*MockCategoryUseCase)(nil).SetCategoryTax), tax, id)
+}
@@ -0,0 +1,170 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/usecase/interface/invoice.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	reflect "reflect"
+
+	models "github.com/ahdaan98/pkg/utils/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockInvoiceUseCase is a mock of InvoiceUseCase interface.
+type MockInvoiceUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceUseCaseMockRecorder
+}
+
+// MockInvoiceUseCaseMockRecorder is the mock recorder for MockInvoiceUseCase.
+type MockInvoiceUseCaseMockRecorder struct {
+	mock *MockInvoiceUseCase
+}
+
+// NewMockInvoiceUseCase creates a new mock instance.
+func NewMockInvoiceUseCase(ctrl *gomock.Controller) *MockInvoiceUseCase {
+	mock := &MockInvoiceUseCase{ctrl: ctrl}
+	mock.recorder = &MockInvoiceUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoiceUseCase) EXPECT() *MockInvoiceUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetCreditNote mocks base method.
+func (m *MockInvoiceUseCase) GetCreditNote(creditNoteID int) (models.DocumentFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCreditNote", creditNoteID)
+	ret0, _ := ret[0].(models.DocumentFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCreditNote indicates an expected call of GetCreditNote.
+func (mr *MockInvoiceUseCaseMockRecorder) GetCreditNote(creditNoteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreditNote", reflect.TypeOf((*MockInvoiceUseCase)(nil).GetCreditNote), creditNoteID)
+}
+
+// GetInvoice mocks base method.
+func (m *MockInvoiceUseCase) GetInvoice(invoiceID int) (models.DocumentFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoice", invoiceID)
+	ret0, _ := ret[0].(models.DocumentFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoice indicates an expected call of GetInvoice.
+func (mr *MockInvoiceUseCaseMockRecorder) GetInvoice(invoiceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoice", reflect.TypeOf((*MockInvoiceUseCase)(nil).GetInvoice), invoiceID)
+}
+
+// GetInvoiceLink mocks base method.
+func (m *MockInvoiceUseCase) GetInvoiceLink(orderID int) (models.InvoiceLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceLink", orderID)
+	ret0, _ := ret[0].(models.InvoiceLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceLink indicates an expected call of GetInvoiceLink.
+func (mr *MockInvoiceUseCaseMockRecorder) GetInvoiceLink(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceLink", reflect.TypeOf((*MockInvoiceUseCase)(nil).GetInvoiceLink), orderID)
+}
+
+// GetOrderDocuments mocks base method.
+func (m *MockInvoiceUseCase) GetOrderDocuments(orderID int) (models.OrderDocuments, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderDocuments", orderID)
+	ret0, _ := ret[0].(models.OrderDocuments)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderDocuments indicates an expected call of GetOrderDocuments.
+func (mr *MockInvoiceUseCaseMockRecorder) GetOrderDocuments(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderDocuments", reflect.TypeOf((*MockInvoiceUseCase)(nil).GetOrderDocuments), orderID)
+}
+
+// GetOrderInvoice mocks base method.
+func (m *MockInvoiceUseCase) GetOrderInvoice(orderID int) (models.DocumentFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderInvoice", orderID)
+	ret0, _ := ret[0].(models.DocumentFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderInvoice indicates an expected call of GetOrderInvoice.
+func (mr *MockInvoiceUseCaseMockRecorder) GetOrderInvoice(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderInvoice", reflect.TypeOf((*MockInvoiceUseCase)(nil).GetOrderInvoice), orderID)
+}
+
+// GetSignedInvoice mocks base method.
+func (m *MockInvoiceUseCase) GetSignedInvoice(invoiceID int, expires int64, signature string) (models.DocumentFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSignedInvoice", invoiceID, expires, signature)
+	ret0, _ := ret[0].(models.DocumentFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSignedInvoice indicates an expected call of GetSignedInvoice.
+func (mr *MockInvoiceUseCaseMockRecorder) GetSignedInvoice(invoiceID, expires, signature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignedInvoice", reflect.TypeOf((*MockInvoiceUseCase)(nil).GetSignedInvoice), invoiceID, expires, signature)
+}
+
+// GetUserCreditNote mocks base method.
+func (m *MockInvoiceUseCase) GetUserCreditNote(userID, creditNoteID int) (models.DocumentFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCreditNote", userID, creditNoteID)
+	ret0, _ := ret[0].(models.DocumentFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCreditNote indicates an expected call of GetUserCreditNote.
+func (mr *MockInvoiceUseCaseMockRecorder) GetUserCreditNote(userID, creditNoteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCreditNote", reflect.TypeOf((*MockInvoiceUseCase)(nil).GetUserCreditNote), userID, creditNoteID)
+}
+
+// GetUserInvoice mocks base method.
+func (m *MockInvoiceUseCase) GetUserInvoice(userID, orderID int) (models.DocumentFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserInvoice", userID, orderID)
+	ret0, _ := ret[0].(models.DocumentFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserInvoice indicates an expected call of GetUserInvoice.
+func (mr *MockInvoiceUseCaseMockRecorder) GetUserInvoice(userID, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInvoice", reflect.TypeOf((*MockInvoiceUseCase)(nil).GetUserInvoice), userID, orderID)
+}
+
+// GetUserInvoiceLink mocks base method.
+func (m *MockInvoiceUseCase) GetUserInvoiceLink(userID, orderID int) (models.InvoiceLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserInvoiceLink", userID, orderID)
+	ret0, _ := ret[0].(models.InvoiceLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserInvoiceLink indicates an expected call of GetUserInvoiceLink.
+func (mr *MockInvoiceUseCaseMockRecorder) GetUserInvoiceLink(userID, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInvoiceLink", reflect.TypeOf((*MockInvoiceUseCase)(nil).GetUserInvoiceLink), userID, orderID)
+}
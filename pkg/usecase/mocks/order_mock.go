@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: C:\Users\ahdaa\GOLANG\Leuse_Ecommerce\pkg\usecase\interface\order.go
+// Source: pkg/usecase/interface/order.go
 
 // Package mock_interfaces is a generated GoMock package.
 package mock_interfaces
@@ -8,9 +8,9 @@ import (
 	reflect "reflect"
 
 	domain "github.com/ahdaan98/pkg/domain"
+	pricing "github.com/ahdaan98/pkg/pricing"
 	models "github.com/ahdaan98/pkg/utils/models"
 	gomock "github.com/golang/mock/gomock"
-	gofpdf "github.com/jung-kurt/gofpdf"
 )
 
 // MockOrderUseCase is a mock of OrderUseCase interface.
@@ -37,32 +37,61 @@ func (m *MockOrderUseCase) EXPECT() *MockOrderUseCaseMockRecorder {
 }
 
 // CancelOrder mocks base method.
-func (m *MockOrderUseCase) CancelOrder(orderId int) error {
+func (m *MockOrderUseCase) CancelOrder(userID, orderId int, refundTo string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CancelOrder", orderId)
+	ret := m.ctrl.Call(m, "CancelOrder", userID, orderId, refundTo)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CancelOrder indicates an expected call of CancelOrder.
-func (mr *MockOrderUseCaseMockRecorder) CancelOrder(orderId interface{}) *gomock.Call {
+func (mr *MockOrderUseCaseMockRecorder) CancelOrder(userID, orderId, refundTo interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrderUseCase)(nil).CancelOrder), orderId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrderUseCase)(nil).CancelOrder), userID, orderId, refundTo)
+}
+
+// CancelOrderItems mocks base method.
+func (m *MockOrderUseCase) CancelOrderItems(userID, orderID int, items []models.OrderItemQuantity, refundTo string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrderItems", userID, orderID, items, refundTo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelOrderItems indicates an expected call of CancelOrderItems.
+func (mr *MockOrderUseCaseMockRecorder) CancelOrderItems(userID, orderID, items, refundTo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrderItems", reflect.TypeOf((*MockOrderUseCase)(nil).CancelOrderItems), userID, orderID, items, refundTo)
+}
+
+// ExpireUnpaidOrders mocks base method.
+func (m *MockOrderUseCase) ExpireUnpaidOrders() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireUnpaidOrders")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireUnpaidOrders indicates an expected call of ExpireUnpaidOrders.
+func (mr *MockOrderUseCaseMockRecorder) ExpireUnpaidOrders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireUnpaidOrders", reflect.TypeOf((*MockOrderUseCase)(nil).ExpireUnpaidOrders))
 }
 
 // GetAdminOrders mocks base method.
-func (m *MockOrderUseCase) GetAdminOrders(page int) ([]models.CombinedOrderDetails, error) {
+func (m *MockOrderUseCase) GetAdminOrders(filter models.AdminOrderFilter) (models.AdminOrderList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAdminOrders", page)
-	ret0, _ := ret[0].([]models.CombinedOrderDetails)
+	ret := m.ctrl.Call(m, "GetAdminOrders", filter)
+	ret0, _ := ret[0].(models.AdminOrderList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAdminOrders indicates an expected call of GetAdminOrders.
-func (mr *MockOrderUseCaseMockRecorder) GetAdminOrders(page interface{}) *gomock.Call {
+func (mr *MockOrderUseCaseMockRecorder) GetAdminOrders(filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminOrders", reflect.TypeOf((*MockOrderUseCase)(nil).GetAdminOrders), page)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminOrders", reflect.TypeOf((*MockOrderUseCase)(nil).GetAdminOrders), filter)
 }
 
 // GetAllOrders mocks base method.
@@ -80,47 +109,107 @@ func (mr *MockOrderUseCaseMockRecorder) GetAllOrders(userId, page, pageSize inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrders", reflect.TypeOf((*MockOrderUseCase)(nil).GetAllOrders), userId, page, pageSize)
 }
 
+// GetCheckoutQuote mocks base method.
+func (m *MockOrderUseCase) GetCheckoutQuote(userID, addressID, couponID int, shippingMethod string) (pricing.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckoutQuote", userID, addressID, couponID, shippingMethod)
+	ret0, _ := ret[0].(pricing.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckoutQuote indicates an expected call of GetCheckoutQuote.
+func (mr *MockOrderUseCaseMockRecorder) GetCheckoutQuote(userID, addressID, couponID, shippingMethod interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckoutQuote", reflect.TypeOf((*MockOrderUseCase)(nil).GetCheckoutQuote), userID, addressID, couponID, shippingMethod)
+}
+
+// GetOrderTimeline mocks base method.
+func (m *MockOrderUseCase) GetOrderTimeline(orderID int) ([]domain.OrderStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderTimeline", orderID)
+	ret0, _ := ret[0].([]domain.OrderStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderTimeline indicates an expected call of GetOrderTimeline.
+func (mr *MockOrderUseCaseMockRecorder) GetOrderTimeline(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderTimeline", reflect.TypeOf((*MockOrderUseCase)(nil).GetOrderTimeline), orderID)
+}
+
 // GetOrders mocks base method.
-func (m *MockOrderUseCase) GetOrders(orderId int) (domain.OrderResponse, error) {
+func (m *MockOrderUseCase) GetOrders(userID, orderId int) (domain.OrderResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrders", orderId)
+	ret := m.ctrl.Call(m, "GetOrders", userID, orderId)
 	ret0, _ := ret[0].(domain.OrderResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOrders indicates an expected call of GetOrders.
-func (mr *MockOrderUseCaseMockRecorder) GetOrders(orderId interface{}) *gomock.Call {
+func (mr *MockOrderUseCaseMockRecorder) GetOrders(userID, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockOrderUseCase)(nil).GetOrders), userID, orderId)
+}
+
+// GetUserOrderItems mocks base method.
+func (m *MockOrderUseCase) GetUserOrderItems(userID, orderID int) ([]models.OrderItemState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrderItems", userID, orderID)
+	ret0, _ := ret[0].([]models.OrderItemState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrderItems indicates an expected call of GetUserOrderItems.
+func (mr *MockOrderUseCaseMockRecorder) GetUserOrderItems(userID, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrderItems", reflect.TypeOf((*MockOrderUseCase)(nil).GetUserOrderItems), userID, orderID)
+}
+
+// GetUserOrderTimeline mocks base method.
+func (m *MockOrderUseCase) GetUserOrderTimeline(userID, orderID int) ([]domain.OrderStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrderTimeline", userID, orderID)
+	ret0, _ := ret[0].([]domain.OrderStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrderTimeline indicates an expected call of GetUserOrderTimeline.
+func (mr *MockOrderUseCaseMockRecorder) GetUserOrderTimeline(userID, orderID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockOrderUseCase)(nil).GetOrders), orderId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrderTimeline", reflect.TypeOf((*MockOrderUseCase)(nil).GetUserOrderTimeline), userID, orderID)
 }
 
 // OrderItemsFromCart mocks base method.
-func (m *MockOrderUseCase) OrderItemsFromCart(userid, addressid, paymentid, couponid int) error {
+func (m *MockOrderUseCase) OrderItemsFromCart(userid, addressid, paymentid, couponid int, shippingMethod string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "OrderItemsFromCart", userid, addressid, paymentid, couponid)
+	ret := m.ctrl.Call(m, "OrderItemsFromCart", userid, addressid, paymentid, couponid, shippingMethod)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // OrderItemsFromCart indicates an expected call of OrderItemsFromCart.
-func (mr *MockOrderUseCaseMockRecorder) OrderItemsFromCart(userid, addressid, paymentid, couponid interface{}) *gomock.Call {
+func (mr *MockOrderUseCaseMockRecorder) OrderItemsFromCart(userid, addressid, paymentid, couponid, shippingMethod interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderItemsFromCart", reflect.TypeOf((*MockOrderUseCase)(nil).OrderItemsFromCart), userid, addressid, paymentid, couponid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderItemsFromCart", reflect.TypeOf((*MockOrderUseCase)(nil).OrderItemsFromCart), userid, addressid, paymentid, couponid, shippingMethod)
 }
 
 // OrdersStatus mocks base method.
-func (m *MockOrderUseCase) OrdersStatus(orderId int) error {
+func (m *MockOrderUseCase) OrdersStatus(adminID, orderId int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "OrdersStatus", orderId)
+	ret := m.ctrl.Call(m, "OrdersStatus", adminID, orderId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // OrdersStatus indicates an expected call of OrdersStatus.
-func (mr *MockOrderUseCaseMockRecorder) OrdersStatus(orderId interface{}) *gomock.Call {
+func (mr *MockOrderUseCaseMockRecorder) OrdersStatus(adminID, orderId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrdersStatus", reflect.TypeOf((*MockOrderUseCase)(nil).OrdersStatus), orderId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrdersStatus", reflect.TypeOf((*MockOrderUseCase)(nil).OrdersStatus), adminID, orderId)
 }
 
 // PaymentMethodID mocks base method.
@@ -138,31 +227,17 @@ func (mr *MockOrderUseCaseMockRecorder) PaymentMethodID(order_id interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaymentMethodID", reflect.TypeOf((*MockOrderUseCase)(nil).PaymentMethodID), order_id)
 }
 
-// PrintInvoice mocks base method.
-func (m *MockOrderUseCase) PrintInvoice(orderIdInt int) (*gofpdf.Fpdf, error) {
+// Reorder mocks base method.
+func (m *MockOrderUseCase) Reorder(userID, orderID int) (models.ReorderResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PrintInvoice", orderIdInt)
-	ret0, _ := ret[0].(*gofpdf.Fpdf)
+	ret := m.ctrl.Call(m, "Reorder", userID, orderID)
+	ret0, _ := ret[0].(models.ReorderResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// PrintInvoice indicates an expected call of PrintInvoice.
-func (mr *MockOrderUseCaseMockRecorder) PrintInvoice(orderIdInt interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrintInvoice", reflect.TypeOf((*MockOrderUseCase)(nil).PrintInvoice), orderIdInt)
-}
-
-// ReturnOrder mocks base method.
-func (m *MockOrderUseCase) ReturnOrder(orderID int) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReturnOrder", orderID)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// ReturnOrder indicates an expected call of ReturnOrder.
-func (mr *MockOrderUseCaseMockRecorder) ReturnOrder(orderID interface{}) *gomock.Call {
+// Reorder indicates an expected call of Reorder.
+func (mr *MockOrderUseCaseMockRecorder) Reorder(userID, orderID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnOrder", reflect.TypeOf((*MockOrderUseCase)(nil).ReturnOrder), orderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockOrderUseCase)(nil).Reorder), userID, orderID)
 }
@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/usecase/interface/payment.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	reflect "reflect"
+
+	models "github.com/ahdaan98/pkg/utils/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPaymentUseCase is a mock of PaymentUseCase interface.
+type MockPaymentUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentUseCaseMockRecorder
+}
+
+// MockPaymentUseCaseMockRecorder is the mock recorder for MockPaymentUseCase.
+type MockPaymentUseCaseMockRecorder struct {
+	mock *MockPaymentUseCase
+}
+
+// NewMockPaymentUseCase creates a new mock instance.
+func NewMockPaymentUseCase(ctrl *gomock.Controller) *MockPaymentUseCase {
+	mock := &MockPaymentUseCase{ctrl: ctrl}
+	mock.recorder = &MockPaymentUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentUseCase) EXPECT() *MockPaymentUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetOrderRefunds mocks base method.
+func (m *MockPaymentUseCase) GetOrderRefunds(orderID int) ([]models.RefundDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderRefunds", orderID)
+	ret0, _ := ret[0].([]models.RefundDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderRefunds indicates an expected call of GetOrderRefunds.
+func (mr *MockPaymentUseCaseMockRecorder) GetOrderRefunds(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderRefunds", reflect.TypeOf((*MockPaymentUseCase)(nil).GetOrderRefunds), orderID)
+}
+
+// GetPaymentAttempts mocks base method.
+func (m *MockPaymentUseCase) GetPaymentAttempts(orderID int) ([]models.PaymentDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentAttempts", orderID)
+	ret0, _ := ret[0].([]models.PaymentDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentAttempts indicates an expected call of GetPaymentAttempts.
+func (mr *MockPaymentUseCaseMockRecorder) GetPaymentAttempts(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentAttempts", reflect.TypeOf((*MockPaymentUseCase)(nil).GetPaymentAttempts), orderID)
+}
+
+// GetUserRefunds mocks base method.
+func (m *MockPaymentUseCase) GetUserRefunds(userID, orderID int) ([]models.RefundDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRefunds", userID, orderID)
+	ret0, _ := ret[0].([]models.RefundDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRefunds indicates an expected call of GetUserRefunds.
+func (mr *MockPaymentUseCaseMockRecorder) GetUserRefunds(userID, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRefunds", reflect.TypeOf((*MockPaymentUseCase)(nil).GetUserRefunds), userID, orderID)
+}
+
+// HandleRazorpayWebhook mocks base method.
+func (m *MockPaymentUseCase) HandleRazorpayWebhook(body []byte, signature string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleRazorpayWebhook", body, signature)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleRazorpayWebhook indicates an expected call of HandleRazorpayWebhook.
+func (mr *MockPaymentUseCaseMockRecorder) HandleRazorpayWebhook(body, signature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRazorpayWebhook", reflect.TypeOf((*MockPaymentUseCase)(nil).HandleRazorpayWebhook), body, signature)
+}
+
+// MakePaymentRazorpay mocks base method.
+func (m *MockPaymentUseCase) MakePaymentRazorpay(orderId, userId int) (models.CombinedOrderDetails, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MakePaymentRazorpay", orderId, userId)
+	ret0, _ := ret[0].(models.CombinedOrderDetails)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// MakePaymentRazorpay indicates an expected call of MakePaymentRazorpay.
+func (mr *MockPaymentUseCaseMockRecorder) MakePaymentRazorpay(orderId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakePaymentRazorpay", reflect.TypeOf((*MockPaymentUseCase)(nil).MakePaymentRazorpay), orderId, userId)
+}
+
+// RetryPayment mocks base method.
+func (m *MockPaymentUseCase) RetryPayment(userID, orderID int) (models.PaymentDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryPayment", userID, orderID)
+	ret0, _ := ret[0].(models.PaymentDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryPayment indicates an expected call of RetryPayment.
+func (mr *MockPaymentUseCaseMockRecorder) RetryPayment(userID, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryPayment", reflect.TypeOf((*MockPaymentUseCase)(nil).RetryPayment), userID, orderID)
+}
+
+// SavePaymentDetails mocks base method.
+func (m *MockPaymentUseCase) SavePaymentDetails(paymentId, razorId, orderId, signature string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePaymentDetails", paymentId, razorId, orderId, signature)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePaymentDetails indicates an expected call of SavePaymentDetails.
+func (mr *MockPaymentUseCaseMockRecorder) SavePaymentDetails(paymentId, razorId, orderId, signature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePaymentDetails", reflect.TypeOf((*MockPaymentUseCase)(nil).SavePaymentDetails), paymentId, razorId, orderId, signature)
+}
+
+// SendPendingRefunds mocks base method.
+func (m *MockPaymentUseCase) SendPendingRefunds() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPendingRefunds")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendPendingRefunds indicates an expected call of SendPendingRefunds.
+func (mr *MockPaymentUseCaseMockRecorder) SendPendingRefunds() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPendingRefunds", reflect.TypeOf((*MockPaymentUseCase)(nil).SendPendingRefunds))
+}
@@ -0,0 +1,224 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/usecase/interface/return.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/ahdaan98/pkg/utils/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReturnUseCase is a mock of ReturnUseCase interface.
+type MockReturnUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnUseCaseMockRecorder
+}
+
+// MockReturnUseCaseMockRecorder is the mock recorder for MockReturnUseCase.
+type MockReturnUseCaseMockRecorder struct {
+	mock *MockReturnUseCase
+}
+
+// NewMockReturnUseCase creates a new mock instance.
+func NewMockReturnUseCase(ctrl *gomock.Controller) *MockReturnUseCase {
+	mock := &MockReturnUseCase{ctrl: ctrl}
+	mock.recorder = &MockReturnUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnUseCase) EXPECT() *MockReturnUseCaseMockRecorder {
+	return m.recorder
+}
+
+// ApproveReturn mocks base method.
+func (m *MockReturnUseCase) ApproveReturn(returnID int, note string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveReturn", returnID, note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveReturn indicates an expected call of ApproveReturn.
+func (mr *MockReturnUseCaseMockRecorder) ApproveReturn(returnID, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveReturn", reflect.TypeOf((*MockReturnUseCase)(nil).ApproveReturn), returnID, note)
+}
+
+// CancelReturn mocks base method.
+func (m *MockReturnUseCase) CancelReturn(userID, returnID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelReturn", userID, returnID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelReturn indicates an expected call of CancelReturn.
+func (mr *MockReturnUseCaseMockRecorder) CancelReturn(userID, returnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelReturn", reflect.TypeOf((*MockReturnUseCase)(nil).CancelReturn), userID, returnID)
+}
+
+// GetReturn mocks base method.
+func (m *MockReturnUseCase) GetReturn(returnID int) (models.ReturnRequestDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturn", returnID)
+	ret0, _ := ret[0].(models.ReturnRequestDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturn indicates an expected call of GetReturn.
+func (mr *MockReturnUseCaseMockRecorder) GetReturn(returnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturn", reflect.TypeOf((*MockReturnUseCase)(nil).GetReturn), returnID)
+}
+
+// GetReturns mocks base method.
+func (m *MockReturnUseCase) GetReturns(status string, page int) ([]models.ReturnRequestDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturns", status, page)
+	ret0, _ := ret[0].([]models.ReturnRequestDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturns indicates an expected call of GetReturns.
+func (mr *MockReturnUseCaseMockRecorder) GetReturns(status, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturns", reflect.TypeOf((*MockReturnUseCase)(nil).GetReturns), status, page)
+}
+
+// GetUserReturn mocks base method.
+func (m *MockReturnUseCase) GetUserReturn(userID, returnID int) (models.ReturnRequestDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserReturn", userID, returnID)
+	ret0, _ := ret[0].(models.ReturnRequestDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserReturn indicates an expected call of GetUserReturn.
+func (mr *MockReturnUseCaseMockRecorder) GetUserReturn(userID, returnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserReturn", reflect.TypeOf((*MockReturnUseCase)(nil).GetUserReturn), userID, returnID)
+}
+
+// GetUserReturns mocks base method.
+func (m *MockReturnUseCase) GetUserReturns(userID int) ([]models.ReturnRequestDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserReturns", userID)
+	ret0, _ := ret[0].([]models.ReturnRequestDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserReturns indicates an expected call of GetUserReturns.
+func (mr *MockReturnUseCaseMockRecorder) GetUserReturns(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserReturns", reflect.TypeOf((*MockReturnUseCase)(nil).GetUserReturns), userID)
+}
+
+// InspectReturn mocks base method.
+func (m *MockReturnUseCase) InspectReturn(returnID int, items []models.InspectedItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectReturn", returnID, items)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InspectReturn indicates an expected call of InspectReturn.
+func (mr *MockReturnUseCaseMockRecorder) InspectReturn(returnID, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectReturn", reflect.TypeOf((*MockReturnUseCase)(nil).InspectReturn), returnID, items)
+}
+
+// ReceiveReturn mocks base method.
+func (m *MockReturnUseCase) ReceiveReturn(returnID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveReturn", returnID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReceiveReturn indicates an expected call of ReceiveReturn.
+func (mr *MockReturnUseCaseMockRecorder) ReceiveReturn(returnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveReturn", reflect.TypeOf((*MockReturnUseCase)(nil).ReceiveReturn), returnID)
+}
+
+// RefundReturn mocks base method.
+func (m *MockReturnUseCase) RefundReturn(adminID, returnID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundReturn", adminID, returnID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefundReturn indicates an expected call of RefundReturn.
+func (mr *MockReturnUseCaseMockRecorder) RefundReturn(adminID, returnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundReturn", reflect.TypeOf((*MockReturnUseCase)(nil).RefundReturn), adminID, returnID)
+}
+
+// RejectReturn mocks base method.
+func (m *MockReturnUseCase) RejectReturn(returnID int, note string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectReturn", returnID, note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RejectReturn indicates an expected call of RejectReturn.
+func (mr *MockReturnUseCaseMockRecorder) RejectReturn(returnID, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectReturn", reflect.TypeOf((*MockReturnUseCase)(nil).RejectReturn), returnID, note)
+}
+
+// RequestReturn mocks base method.
+func (m *MockReturnUseCase) RequestReturn(userID int, request models.ReturnRequest) (models.ReturnRequestDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestReturn", userID, request)
+	ret0, _ := ret[0].(models.ReturnRequestDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestReturn indicates an expected call of RequestReturn.
+func (mr *MockReturnUseCaseMockRecorder) RequestReturn(userID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestReturn", reflect.TypeOf((*MockReturnUseCase)(nil).RequestReturn), userID, request)
+}
+
+// ReturnOrder mocks base method.
+func (m *MockReturnUseCase) ReturnOrder(userID, orderID int, damaged []models.DamagedItem) (models.ReturnRequestDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnOrder", userID, orderID, damaged)
+	ret0, _ := ret[0].(models.ReturnRequestDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnOrder indicates an expected call of ReturnOrder.
+func (mr *MockReturnUseCaseMockRecorder) ReturnOrder(userID, orderID, damaged interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnOrder", reflect.TypeOf((*MockReturnUseCase)(nil).ReturnOrder), userID, orderID, damaged)
+}
+
+// SchedulePickup mocks base method.
+func (m *MockReturnUseCase) SchedulePickup(returnID int, pickupDate time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulePickup", returnID, pickupDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SchedulePickup indicates an expected call of SchedulePickup.
+func (mr *MockReturnUseCaseMockRecorder) SchedulePickup(returnID, pickupDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePickup", reflect.TypeOf((*MockReturnUseCase)(nil).SchedulePickup), returnID, pickupDate)
+}
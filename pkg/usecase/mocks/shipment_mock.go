@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/usecase/interface/shipment.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	reflect "reflect"
+
+	models "github.com/ahdaan98/pkg/utils/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockShipmentUseCase is a mock of ShipmentUseCase interface.
+type MockShipmentUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockShipmentUseCaseMockRecorder
+}
+
+// MockShipmentUseCaseMockRecorder is the mock recorder for MockShipmentUseCase.
+type MockShipmentUseCaseMockRecorder struct {
+	mock *MockShipmentUseCase
+}
+
+// NewMockShipmentUseCase creates a new mock instance.
+func NewMockShipmentUseCase(ctrl *gomock.Controller) *MockShipmentUseCase {
+	mock := &MockShipmentUseCase{ctrl: ctrl}
+	mock.recorder = &MockShipmentUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShipmentUseCase) EXPECT() *MockShipmentUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CreateShipment mocks base method.
+func (m *MockShipmentUseCase) CreateShipment(adminID, orderID int, shipment models.ShipmentRequest) (models.ShipmentDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShipment", adminID, orderID, shipment)
+	ret0, _ := ret[0].(models.ShipmentDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShipment indicates an expected call of CreateShipment.
+func (mr *MockShipmentUseCaseMockRecorder) CreateShipment(adminID, orderID, shipment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShipment", reflect.TypeOf((*MockShipmentUseCase)(nil).CreateShipment), adminID, orderID, shipment)
+}
+
+// GetShipment mocks base method.
+func (m *MockShipmentUseCase) GetShipment(orderID int) (models.ShipmentDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShipment", orderID)
+	ret0, _ := ret[0].(models.ShipmentDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShipment indicates an expected call of GetShipment.
+func (mr *MockShipmentUseCaseMockRecorder) GetShipment(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShipment", reflect.TypeOf((*MockShipmentUseCase)(nil).GetShipment), orderID)
+}
+
+// GetUserShipment mocks base method.
+func (m *MockShipmentUseCase) GetUserShipment(userID, orderID int) (models.ShipmentDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserShipment", userID, orderID)
+	ret0, _ := ret[0].(models.ShipmentDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserShipment indicates an expected call of GetUserShipment.
+func (mr *MockShipmentUseCaseMockRecorder) GetUserShipment(userID, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserShipment", reflect.TypeOf((*MockShipmentUseCase)(nil).GetUserShipment), userID, orderID)
+}
+
+// RecordTrackingEvent mocks base method.
+func (m *MockShipmentUseCase) RecordTrackingEvent(event models.ShipmentEventRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordTrackingEvent", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordTrackingEvent indicates an expected call of RecordTrackingEvent.
+func (mr *MockShipmentUseCaseMockRecorder) RecordTrackingEvent(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordTrackingEvent", reflect.TypeOf((*MockShipmentUseCase)(nil).RecordTrackingEvent), event)
+}
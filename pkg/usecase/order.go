@@ -1,155 +1,122 @@
 package usecase
 
 import (
+	"github.com/ahdaan98/pkg/config"
 	"github.com/ahdaan98/pkg/domain"
+	"github.com/ahdaan98/pkg/events"
+	"github.com/ahdaan98/pkg/pricing"
 	interfaces "github.com/ahdaan98/pkg/repository/interface"
 	services "github.com/ahdaan98/pkg/usecase/interface"
 	"github.com/ahdaan98/pkg/utils/models"
 	"errors"
 	"fmt"
-	"strconv"
+	"strings"
 	"time"
-
-	"github.com/jung-kurt/gofpdf"
 )
 
 type orderUseCase struct {
 	orderRepository  interfaces.OrderRepository
 	userUseCase      services.UserUseCase
+	cartUseCase      services.CartUseCase
 	walletRepository interfaces.WalletRepository
 	cartRepo         interfaces.CartRepository
 	couponRepository interfaces.CouponRepository
+	pinCodes         interfaces.PinCodeRepository
+	transaction      interfaces.TransactionRepository
+	calculator       *pricing.Calculator
+	events           events.Publisher
+	unpaidOrderTTL   time.Duration
 }
 
-func NewOrderUseCase(repo interfaces.OrderRepository, userUseCase services.UserUseCase, walletRepo interfaces.WalletRepository, cartRepo interfaces.CartRepository, couponRepository interfaces.CouponRepository) services.OrderUseCase {
+const defaultUnpaidOrderTTL = 30 * time.Minute
+
+func NewOrderUseCase(repo interfaces.OrderRepository, userUseCase services.UserUseCase, cartUseCase services.CartUseCase, walletRepo interfaces.WalletRepository, cartRepo interfaces.CartRepository, couponRepository interfaces.CouponRepository, pinCodes interfaces.PinCodeRepository, transaction interfaces.TransactionRepository, calculator *pricing.Calculator, publisher events.Publisher, cfg config.Config) services.OrderUseCase {
+	ttl, err := time.ParseDuration(cfg.UNPAID_ORDER_TTL)
+	if err != nil || ttl <= 0 {
+		ttl = defaultUnpaidOrderTTL
+	}
+
 	return &orderUseCase{
 		orderRepository:  repo,
 		userUseCase:      userUseCase,
+		cartUseCase:      cartUseCase,
 		walletRepository: walletRepo,
 		cartRepo:         cartRepo,
 		couponRepository: couponRepository,
+		pinCodes:         pinCodes,
+		transaction:      transaction,
+		calculator:       calculator,
+		events:           publisher,
+		unpaidOrderTTL:   ttl,
 	}
 }
-func (i *orderUseCase) OrderItemsFromCart(userID, addressID, paymentID, couponId int) error {
 
-	if userID <= 0 || addressID <= 0 || paymentID < 0 || couponId < 0 {
-		return errors.New("enter a valid number")
+func (i *orderUseCase) OrderItemsFromCart(userID, addressID, paymentID, couponId int, shippingMethod string) error {
+
+	c := &checkout{
+		UserID:         userID,
+		AddressID:      addressID,
+		PaymentID:      paymentID,
+		CouponID:       couponId,
+		ShippingMethod: shippingMethod,
 	}
 
-	cart, err := i.userUseCase.GetCart(userID)
+	steps := i.checkoutSteps(c)
+	err := i.transaction.WithTransaction(func(repos interfaces.TxRepositories) error {
+		for _, step := range steps {
+			if err := step(c, repos); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	fmt.Println("cart details at usecase", cart)
-
-	exist, err := i.cartRepo.CheckCart(userID)
+	i.events.Publish(events.Event{
+		Name:    events.OrderPlaced,
+		OrderID: c.OrderID,
+		UserID:  c.UserID,
+	})
 
-	fmt.Println("exist", exist)
+	return nil
+}
 
-	if err != nil {
-		return err
+// GetCheckoutQuote prices the user's cart the way placing the order would,
+// without placing it.
+func (i *orderUseCase) GetCheckoutQuote(userID, addressID, couponID int, shippingMethod string) (pricing.Quote, error) {
+	if addressID <= 0 || couponID < 0 {
+		return pricing.Quote{}, errors.New("enter a valid number")
 	}
 
-	if !exist {
-		return errors.New("cart is empty")
+	cart, err := checkoutCart(i.cartRepo, userID)
+	if err != nil {
+		return pricing.Quote{}, err
 	}
-
-	var total float64
-	for _, item := range cart.Data {
-		if item.Quantity > 0 && item.Price > 0 {
-			total += float64(item.Quantity) * float64(item.Price)
-		}
+	if len(cart.Data) == 0 {
+		return pricing.Quote{}, errors.New("cart is empty")
 	}
 
-	if couponId == 0 {
-		orderID, err := i.orderRepository.OrderItems(userID, addressID, paymentID, total)
-		if err != nil {
-			return err
-		}
-		if err := i.orderRepository.AddOrderProducts(orderID, cart.Data); err != nil {
-			return err
-		}
+	address, err := checkoutAddress(i.cartRepo, userID, addressID)
+	if err != nil {
+		return pricing.Quote{}, err
 	}
-	if couponId != 0 {
-
-		couponIdExist, err := i.couponRepository.CheckCouponById(couponId)
-		fmt.Println("coupon id exist bool", couponIdExist)
-		if err != nil {
-			return err
-		}
-		if !couponIdExist {
-			return errors.New("coupon does not exist")
-		}
-		if couponId < 0 {
-			return errors.New("negative values are not accepted")
-		}
-		coupon, err := i.couponRepository.GetCouponById(couponId)
-		if err != nil {
-			return errors.New("error in getting coupon")
-		}
-
-		totaldiscount := float64(coupon)
-
-		total = total - totaldiscount
-
-		orderID, err := i.orderRepository.OrderItems(userID, addressID, paymentID, total)
-		if err != nil {
-			return err
-		}
-		fmt.Println("orderid use1:......", orderID)
-		if err := i.orderRepository.AddOrderProducts(orderID, cart.Data); err != nil {
-			return err
-		}
-
-		for _, v := range cart.Data {
-			if err := i.orderRepository.ReduceInventoryQuantity(v.ProductName, v.Quantity); err != nil {
-				return err
-			}
-		}
-
-		fmt.Println("orderid use2:......", orderID)
-
-		var (
-			categoryIds  []int
-			productNames []string
-			prices       []int
-			quantities   []int
-			totalPrices  []float64
-		)
-
-		fmt.Println("orderid use3:......", orderID)
-
-		for _, item := range cart.Data {
-			categoryIds = append(categoryIds, int(item.CategoryID))
-			productNames = append(productNames, item.ProductName)
-			prices = append(prices, item.Price)
-			quantities = append(quantities, item.Quantity)
-			totalPrices = append(totalPrices, item.Total)
-		}
-
-		fmt.Println("order id at use4 ", orderID)
-
-		err = i.orderRepository.OrderItemsInv(productNames, categoryIds, prices, quantities, totalPrices, userID, orderID)
-		if err != nil {
-			return errors.New("failed to order items")
-		}
-
-		for _, v := range cart.Data {
-			if err := i.userUseCase.RemoveFromCart(cart.ID, v.ProductID); err != nil {
-				return err
-			}
-		}
+	if _, err := serviceablePin(i.pinCodes, address.Pin); err != nil {
+		return pricing.Quote{}, err
 	}
 
-	return nil
+	return i.quoteCart(userID, address, couponID, shippingMethod, cart)
 }
 
-func (i *orderUseCase) GetOrders(orderId int) (domain.OrderResponse, error) {
+func (i *orderUseCase) GetOrders(userID, orderId int) (domain.OrderResponse, error) {
 
 	if orderId <= 0 {
 		return domain.OrderResponse{}, errors.New("enter a valid number")
 	}
+	if err := authorizeOrder(i.orderRepository, userID, orderId); err != nil {
+		return domain.OrderResponse{}, err
+	}
 
 	orders, err := i.orderRepository.GetOrders(orderId)
 	if err != nil {
@@ -158,50 +125,63 @@ func (i *orderUseCase) GetOrders(orderId int) (domain.OrderResponse, error) {
 	return orders, nil
 }
 
-func (i *orderUseCase) CancelOrder(orderID int) error {
+// CancelOrder cancels everything left open on an order. refundTo is wallet,
+// original or empty for the default, see refundPayment.
+func (i *orderUseCase) CancelOrder(userID, orderID int, refundTo string) error {
 	if orderID <= 0 {
 		return errors.New("enter a valid number")
 	}
-	paymentStatus, err := i.orderRepository.CheckPaymentStatus(orderID)
+	refundTo, err := parseRefundTo(refundTo)
 	if err != nil {
 		return err
 	}
 
-	orderStatus, err := i.orderRepository.CheckOrderStatusByOrderId(orderID)
-	if err != nil {
-		return err
-	}
+	return i.cancelItems(userID, orderID, allOpenItems(), refundTo, "canceled by customer")
+}
 
-	price, err := i.orderRepository.FindFinalPrice(orderID)
+// CancelOrderItems cancels some units of an order's items. The order itself is
+// canceled once nothing is left open.
+func (i *orderUseCase) CancelOrderItems(userID, orderID int, items []models.OrderItemQuantity, refundTo string) error {
+	if orderID <= 0 {
+		return errors.New("enter a valid number")
+	}
+	if len(items) == 0 {
+		return errors.New("select the items to cancel")
+	}
+	refundTo, err := parseRefundTo(refundTo)
 	if err != nil {
 		return err
 	}
 
-	userID, err := i.orderRepository.FindUserID(orderID)
-	if err != nil {
-		return err
+	closures := make([]itemClosure, 0, len(items))
+	for _, item := range items {
+		closures = append(closures, itemClosure{OrderItemID: item.OrderItemID, Quantity: item.Quantity})
 	}
 
-	if paymentStatus == "PAID" && orderStatus == "DELIVERED" {
-		return errors.New("cannot cancel the item, kindly return it")
-	} else if paymentStatus == "PAID" && (orderStatus == "PENDING" || orderStatus == "SHIPPED") {
-		_, errWallet := i.walletRepository.AddToWallet(price, userID)
-		if errWallet != nil {
-			return errWallet
-		}
+	return i.cancelItems(userID, orderID, requestedItems(closures), refundTo, "items canceled by customer")
+}
 
-		_, err := i.orderRepository.UpdateOrder(orderID)
+func (i *orderUseCase) cancelItems(userID, orderID int, selectItems itemSelector, refundTo, reason string) error {
+	return i.transaction.WithTransaction(func(repos interfaces.TxRepositories) error {
+		state, err := repos.Order.LockOrderState(orderID)
 		if err != nil {
 			return err
 		}
-	} else {
-		err = i.orderRepository.CancelOrder(orderID)
-		if err != nil {
-			return err
+		if state.UserID != userID {
+			return domain.ErrForbidden
 		}
-	}
 
-	return nil
+		switch state.OrderStatus {
+		case domain.OrderStatusPending, domain.OrderStatusShipped:
+		case domain.OrderStatusDelivered:
+			return errors.New("cannot cancel the item, kindly return it")
+		default:
+			return errors.New("order cannot be canceled")
+		}
+
+		actor := domain.Actor{Role: domain.ActorUser, ID: userID}
+		return closeOrderItems(repos, state, domain.OrderStatusCanceled, selectItems, refundTo, actor, reason)
+	})
 }
 
 func (i *orderUseCase) GetAllOrders(userId, page, pageSize int) ([]models.OrderDetails, error) {
@@ -217,204 +197,272 @@ func (i *orderUseCase) GetAllOrders(userId, page, pageSize int) ([]models.OrderD
 	return allorder, nil
 }
 
-func (i *orderUseCase) GetAdminOrders(page int) ([]models.CombinedOrderDetails, error) {
+const (
+	defaultAdminOrderPageSize = 20
+	maxAdminOrderPageSize     = 100
+)
 
-	if page <= 0 {
-		return nil, errors.New("enter a valid number")
+// GetAdminOrders lists the orders matching filter one page at a time.
+func (i *orderUseCase) GetAdminOrders(filter models.AdminOrderFilter) (models.AdminOrderList, error) {
+	query, err := adminOrderQuery(filter)
+	if err != nil {
+		return models.AdminOrderList{}, err
 	}
 
-	orderDetails, err := i.orderRepository.GetOrderDetailsBrief(page)
+	orders, total, err := i.orderRepository.SearchOrders(query)
 	if err != nil {
-		return []models.CombinedOrderDetails{}, err
+		return models.AdminOrderList{}, err
+	}
+	if orders == nil {
+		orders = []models.AdminOrderSummary{}
 	}
-	return orderDetails, nil
+
+	return models.AdminOrderList{
+		Orders: orders,
+		Meta: models.PageMeta{
+			Page:       query.Page,
+			PageSize:   query.PageSize,
+			TotalCount: total,
+			TotalPages: int((total + int64(query.PageSize) - 1) / int64(query.PageSize)),
+		},
+	}, nil
 }
 
-func (i *orderUseCase) OrdersStatus(orderID int) error {
-	if orderID <= 0 {
-		return errors.New("enter a valid number")
+func adminOrderQuery(filter models.AdminOrderFilter) (models.AdminOrderQuery, error) {
+	query := models.AdminOrderQuery{
+		OrderStatus:     strings.ToUpper(strings.TrimSpace(filter.OrderStatus)),
+		PaymentStatus:   strings.ToUpper(strings.TrimSpace(filter.PaymentStatus)),
+		PaymentMethodID: filter.PaymentMethodID,
+		UserID:          filter.UserID,
+		MinAmount:       filter.MinAmount,
+		MaxAmount:       filter.MaxAmount,
+		Search:          strings.TrimSpace(filter.Search),
+		Sort:            strings.TrimSpace(filter.Sort),
+		Page:            filter.Page,
+		PageSize:        filter.PageSize,
 	}
 
-	orderExists, err := i.orderRepository.OrderIdStatus(orderID)
-	if err != nil {
-		return err
+	if query.Page == 0 {
+		query.Page = 1
 	}
-	if !orderExists {
-		return errors.New("no order exists")
+	if query.PageSize == 0 {
+		query.PageSize = defaultAdminOrderPageSize
 	}
-
-	status, err := i.orderRepository.CheckOrdersStatusByID(orderID)
-	if err != nil {
-		return err
+	if query.Page < 0 || query.PageSize < 0 || query.PageSize > maxAdminOrderPageSize {
+		return models.AdminOrderQuery{}, fmt.Errorf("page must be positive and page size between 1 and %d", maxAdminOrderPageSize)
+	}
+	if query.PaymentMethodID < 0 || query.UserID < 0 {
+		return models.AdminOrderQuery{}, errors.New("enter a valid number")
 	}
 
-	switch status {
-	case "CANCELED", "RETURNED", "DELIVERED":
-		return errors.New("cannot approve this order because it's in a processed or canceled state")
-	case "PENDING":
-		err := i.orderRepository.ChangeOrderStatus(orderID, "SHIPPED")
+	if filter.From != "" {
+		from, err := time.Parse("02-01-2006", filter.From)
 		if err != nil {
-			return err
+			return models.AdminOrderQuery{}, errors.New("from date should be in dd-mm-yyyy format")
 		}
-	case "SHIPPED":
-		shipmentStatus, err := i.orderRepository.GetShipmentStatus(orderID)
+		query.From = &from
+	}
+	if filter.To != "" {
+		to, err := time.Parse("02-01-2006", filter.To)
+		if err != nil {
+			return models.AdminOrderQuery{}, errors.New("to date should be in dd-mm-yyyy format")
+		}
+		// the to date is inclusive
+		before := to.AddDate(0, 0, 1)
+		query.Before = &before
+	}
+	if query.From != nil && query.Before != nil && !query.From.Before(*query.Before) {
+		return models.AdminOrderQuery{}, errors.New("from date is after to date")
+	}
+	if query.MinAmount != nil && query.MaxAmount != nil && *query.MinAmount > *query.MaxAmount {
+		return models.AdminOrderQuery{}, errors.New("minimum amount is more than maximum amount")
+	}
+
+	return query, nil
+}
+
+// OrdersStatus advances an order one step along its fulfilment path:
+// PENDING -> SHIPPED -> DELIVERED. Cash orders are marked paid on delivery.
+func (i *orderUseCase) OrdersStatus(adminID, orderID int) error {
+	if orderID <= 0 {
+		return errors.New("enter a valid number")
+	}
+
+	return i.transaction.WithTransaction(func(repos interfaces.TxRepositories) error {
+		state, err := repos.Order.LockOrderState(orderID)
 		if err != nil {
 			return err
 		}
-		if shipmentStatus == "CANCELLED" {
-			return errors.New("cannot approve this order because it's cancelled")
+
+		actor := domain.Actor{Role: domain.ActorAdmin, ID: adminID}
+
+		switch state.OrderStatus {
+		case domain.OrderStatusPending:
+			return advanceOrder(repos, state, domain.OrderStatusShipped, actor, "shipped by admin")
+		case domain.OrderStatusShipped:
+			return advanceOrder(repos, state, domain.OrderStatusDelivered, actor, "delivered")
+		default:
+			return errors.New("cannot approve this order because it's in a processed or canceled state")
 		}
-		err = i.orderRepository.ChangeOrderStatus(orderID, "DELIVERED")
+	})
+}
+
+// expiryBatchSize caps the orders ExpireUnpaidOrders cancels in one run.
+const expiryBatchSize = 100
+
+// ExpireUnpaidOrders cancels online payment orders whose payment was not
+// received before they expired, puts their stock back and releases their
+// coupon. It returns how many orders were canceled.
+func (i *orderUseCase) ExpireUnpaidOrders() (int, error) {
+	ids, err := i.orderRepository.GetExpiredUnpaidOrders(expiryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		expired int
+		errs    []error
+	)
+	for _, orderID := range ids {
+		canceled := false
+		err := i.transaction.WithTransaction(func(repos interfaces.TxRepositories) error {
+			state, err := repos.Order.LockOrderState(orderID)
+			if err != nil {
+				return err
+			}
+			// the payment may have come in since the orders were listed
+			if state.OrderStatus != domain.OrderStatusPending || state.PaymentStatus != domain.PaymentStatusNotPaid {
+				return nil
+			}
+
+			actor := domain.Actor{Role: domain.ActorSystem}
+			if err := closeOrderItems(repos, state, domain.OrderStatusCanceled, allOpenItems(), "", actor, "payment not received in time"); err != nil {
+				return err
+			}
+
+			canceled = true
+			return nil
+		})
 		if err != nil {
-			return err
+			errs = append(errs, fmt.Errorf("order %d: %w", orderID, err))
+			continue
+		}
+		if canceled {
+			expired++
 		}
 	}
-	return nil
-}
 
-func (o *orderUseCase) ReturnOrder(orderID int) error {
+	return expired, errors.Join(errs...)
+}
 
+// Reorder puts the products of a past order back into the user's cart at their
+// current price. Quantities are cut down to what is in stock now, and products
+// that are gone, out of stock or cannot be added are skipped with a reason.
+func (o *orderUseCase) Reorder(userID, orderID int) (models.ReorderResult, error) {
 	if orderID <= 0 {
-		return errors.New("enter a valid number")
+		return models.ReorderResult{}, errors.New("enter a valid order id")
 	}
 
-	shipmentStatus, err := o.orderRepository.GetOrderStatus(orderID)
-	if err != nil {
-		return err
+	if err := authorizeOrder(o.orderRepository, userID, orderID); err != nil {
+		return models.ReorderResult{}, err
 	}
 
-	userID, err := o.orderRepository.FindUserID(orderID)
+	items, err := o.orderRepository.GetReorderItems(orderID)
 	if err != nil {
-		return err
+		return models.ReorderResult{}, err
 	}
 
-	price, err := o.orderRepository.FindFinalPrice(orderID)
-	if err != nil {
-		return err
+	result := models.ReorderResult{
+		Added:          []models.ReorderLine{},
+		PartiallyAdded: []models.ReorderLine{},
+		Skipped:        []models.ReorderLine{},
 	}
+	for _, item := range items {
+		line := models.ReorderLine{
+			InventoryID:     item.InventoryID,
+			ProductName:     item.ProductName,
+			OrderedQuantity: item.Quantity,
+			Price:           item.Price,
+		}
 
-	_, errWallet := o.walletRepository.AddToWallet(price, userID)
-	if errWallet != nil {
-		return errWallet
-	}
+		switch {
+		case !item.Available:
+			line.Reason = "discontinued"
+		case item.Stock <= 0:
+			line.Reason = "out of stock"
+		default:
+			line.AddedQuantity = item.Quantity
+			if item.Stock < item.Quantity {
+				line.AddedQuantity = item.Stock
+				line.Reason = fmt.Sprintf("only %d in stock", item.Stock)
+			}
+			if err := o.cartUseCase.AddToCart(userID, item.InventoryID, line.AddedQuantity); err != nil {
+				line.AddedQuantity = 0
+				line.Reason = err.Error()
+			}
+		}
 
-	if shipmentStatus == "DELIVERED" {
-		if err := o.orderRepository.ReturnOrder("RETURNED", orderID); err != nil {
-			return err
+		switch {
+		case line.AddedQuantity == 0:
+			result.Skipped = append(result.Skipped, line)
+		case line.AddedQuantity < line.OrderedQuantity:
+			result.PartiallyAdded = append(result.PartiallyAdded, line)
+		default:
+			result.Added = append(result.Added, line)
 		}
-		return nil
 	}
 
-	return errors.New("cannot return order")
+	return result, nil
 }
 
-func (or *orderUseCase) PaymentMethodID(order_id int) (int, error) {
-	if order_id <= 0 {
-		return 0, errors.New("enter a valid number")
+func (o *orderUseCase) GetUserOrderItems(userID, orderID int) ([]models.OrderItemState, error) {
+	if orderID <= 0 {
+		return nil, errors.New("enter a valid order id")
 	}
 
-	id, err := or.orderRepository.PaymentMethodID(order_id)
-	if err != nil {
-		return 0, err
+	if err := authorizeOrder(o.orderRepository, userID, orderID); err != nil {
+		return nil, err
 	}
-	return id, nil
-}
 
-func (or *orderUseCase) PrintInvoice(orderId int) (*gofpdf.Fpdf, error) {
+	return o.orderRepository.GetOrderItemStates(orderID)
+}
 
-	if orderId < 1 {
+func (o *orderUseCase) GetOrderTimeline(orderID int) ([]domain.OrderStatusHistory, error) {
+	if orderID <= 0 {
 		return nil, errors.New("enter a valid order id")
 	}
 
-	order, err := or.orderRepository.GetDetailedOrderThroughId(orderId)
+	exist, err := o.orderRepository.OrderIdStatus(orderID)
 	if err != nil {
 		return nil, err
 	}
+	if !exist {
+		return nil, errors.New("no order exists")
+	}
 
-	items, err := or.orderRepository.GetItemsByOrderId(orderId)
-	if err != nil {
+	return o.orderRepository.GetStatusHistory(orderID)
+}
+
+func (o *orderUseCase) GetUserOrderTimeline(userID, orderID int) ([]domain.OrderStatusHistory, error) {
+	if orderID <= 0 {
+		return nil, errors.New("enter a valid order id")
+	}
+
+	if err := authorizeOrder(o.orderRepository, userID, orderID); err != nil {
 		return nil, err
 	}
 
-	fmt.Println("order details ", order)
-	fmt.Println("itemssss", items)
-	fmt.Println("order status", order.OrderStatus)
-	if order.OrderStatus != "DELIVERED" {
-		return nil, errors.New("wait for the invoice until the product is received")
-	}
-
-	pdf := gofpdf.New("P", "mm", "A4", "")
-	pdf.AddPage()
-
-	pdf.SetFont("Arial", "B", 30)
-	pdf.SetTextColor(31, 73, 125)
-	pdf.Cell(0, 20, "Invoice")
-	pdf.Ln(20)
-
-	pdf.SetFont("Arial", "I", 14)
-	pdf.SetTextColor(51, 51, 51)
-	pdf.Cell(0, 10, "Customer Details")
-	pdf.Ln(10)
-	customerDetails := []string{
-		"Name: " + order.Name,
-		"House Name: " + order.HouseName,
-		"Street: " + order.Street,
-		"State: " + order.State,
-		"City: " + order.City,
-	}
-	for _, detail := range customerDetails {
-		pdf.Cell(0, 10, detail)
-		pdf.Ln(10)
-	}
-	pdf.Ln(10)
-
-	pdf.SetFont("Arial", "B", 16)
-	pdf.SetFillColor(217, 217, 217)
-	pdf.SetTextColor(0, 0, 0)
-	pdf.CellFormat(40, 10, "Item", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(40, 10, "Price", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(40, 10, "Quantity", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(40, 10, "Total Price", "1", 0, "C", true, 0, "")
-	pdf.Ln(10)
-
-	pdf.SetFont("Arial", "", 12)
-	pdf.SetFillColor(255, 255, 255)
-	for _, item := range items {
-		pdf.CellFormat(40, 10, item.ProductName, "1", 0, "L", true, 0, "")
-		pdf.CellFormat(40, 10, "$"+strconv.FormatFloat(item.Price, 'f', 2, 64), "1", 0, "C", true, 0, "")
-		pdf.CellFormat(40, 10, strconv.Itoa(item.Quantity), "1", 0, "C", true, 0, "")
-		pdf.CellFormat(40, 10, "$"+strconv.FormatFloat(item.Total, 'f', 2, 64), "1", 0, "C", true, 0, "")
-		pdf.Ln(10)
+	return o.orderRepository.GetStatusHistory(orderID)
+}
+
+func (or *orderUseCase) PaymentMethodID(order_id int) (int, error) {
+	if order_id <= 0 {
+		return 0, errors.New("enter a valid number")
 	}
-	pdf.Ln(10)
 
-	var totalPrice float64
-	for _, item := range items {
-		totalPrice += item.Total
-	}
-
-	pdf.SetFont("Arial", "B", 16)
-	pdf.SetFillColor(217, 217, 217)
-	pdf.CellFormat(120, 10, "Total Price:", "1", 0, "R", true, 0, "")
-	pdf.CellFormat(40, 10, "$"+strconv.FormatFloat(totalPrice, 'f', 2, 64), "1", 0, "C", true, 0, "")
-	pdf.Ln(10)
-
-	offerApplied := totalPrice - order.FinalPrice
-
-	pdf.SetFont("Arial", "B", 16)
-	pdf.SetFillColor(217, 217, 217)
-	pdf.CellFormat(120, 10, "Offer Applied:", "1", 0, "R", true, 0, "")
-	pdf.CellFormat(40, 10, "$"+strconv.FormatFloat(offerApplied, 'f', 2, 64), "1", 0, "C", true, 0, "")
-	pdf.Ln(10)
-
-	pdf.SetFont("Arial", "B", 16)
-	pdf.SetFillColor(217, 217, 217)
-	pdf.CellFormat(120, 10, "Final Amount:", "1", 0, "R", true, 0, "")
-	pdf.CellFormat(40, 10, "$"+strconv.FormatFloat(order.FinalPrice, 'f', 2, 64), "1", 0, "C", true, 0, "")
-	pdf.Ln(10)
-	pdf.SetFont("Arial", "I", 12)
-	pdf.Cell(0, 10, "Generated by Leuse India Pvt Ltd. - "+time.Now().Format("2006-01-02 15:04:05"))
-	pdf.Ln(10)
-
-	return pdf, nil
-}
\ No newline at end of file
+	id, err := or.orderRepository.PaymentMethodID(order_id)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
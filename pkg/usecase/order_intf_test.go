@@ -0,0 +1,691 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ahdaan98/pkg/config"
+	"github.com/ahdaan98/pkg/domain"
+	"github.com/ahdaan98/pkg/events"
+	"github.com/ahdaan98/pkg/pricing"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	repo_mocks "github.com/ahdaan98/pkg/repository/mocks"
+	usecase_mocks "github.com/ahdaan98/pkg/usecase/mocks"
+	"github.com/ahdaan98/pkg/utils/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type orderTestMocks struct {
+	order       *repo_mocks.MockOrderRepository
+	cart        *repo_mocks.MockCartRepository
+	coupon      *repo_mocks.MockCouponRepository
+	wallet      *repo_mocks.MockWalletRepository
+	inventory   *repo_mocks.MockInventoryRepository
+	zones       *repo_mocks.MockShippingZoneRepository
+	pinCodes    *repo_mocks.MockPinCodeRepository
+	invoice     *repo_mocks.MockInvoiceRepository
+	payment     *repo_mocks.MockPaymentRepository
+	refund      *repo_mocks.MockRefundRepository
+	transaction *repo_mocks.MockTransactionRepository
+	userUseCase *usecase_mocks.MockUserUseCase
+	cartUseCase *usecase_mocks.MockCartUseCase
+}
+
+func newOrderTestUseCase(ctrl *gomock.Controller) (*orderUseCase, orderTestMocks, *[]events.Event) {
+	m := orderTestMocks{
+		order:       repo_mocks.NewMockOrderRepository(ctrl),
+		cart:        repo_mocks.NewMockCartRepository(ctrl),
+		coupon:      repo_mocks.NewMockCouponRepository(ctrl),
+		wallet:      repo_mocks.NewMockWalletRepository(ctrl),
+		inventory:   repo_mocks.NewMockInventoryRepository(ctrl),
+		zones:       repo_mocks.NewMockShippingZoneRepository(ctrl),
+		pinCodes:    repo_mocks.NewMockPinCodeRepository(ctrl),
+		invoice:     repo_mocks.NewMockInvoiceRepository(ctrl),
+		payment:     repo_mocks.NewMockPaymentRepository(ctrl),
+		refund:      repo_mocks.NewMockRefundRepository(ctrl),
+		userUseCase: usecase_mocks.NewMockUserUseCase(ctrl),
+		cartUseCase: usecase_mocks.NewMockCartUseCase(ctrl),
+	}
+
+	// the transaction hands the same mocks back as its bound repositories
+	m.transaction = newTestTransaction(ctrl, interfaces.TxRepositories{
+		Order:     m.order,
+		Cart:      m.cart,
+		Inventory: m.inventory,
+		Wallet:    m.wallet,
+		Coupon:    m.coupon,
+		Invoice:   m.invoice,
+		Payment:   m.payment,
+		Refund:    m.refund,
+	})
+
+	var published []events.Event
+	bus := events.NewBus()
+	bus.Subscribe(events.OrderPlaced, func(e events.Event) {
+		published = append(published, e)
+	})
+
+	cfg := config.Config{SELLER_STATE: "Kerala"}
+	uc := NewOrderUseCase(m.order, m.userUseCase, m.cartUseCase, m.wallet, m.cart, m.coupon, m.pinCodes, m.transaction, pricing.NewCheckoutCalculator(cfg, m.inventory, m.zones), bus, cfg).(*orderUseCase)
+	return uc, m, &published
+}
+
+func TestOrderItemsFromCart(t *testing.T) {
+	cart := models.GetCartResponse{
+		ID: 7,
+		Data: []models.GetCart{
+			{ProductID: 2, ProductName: "iPhone", CategoryID: 1, Quantity: 1, Price: 500, Total: 500},
+			{ProductID: 1, ProductName: "Case", CategoryID: 2, Quantity: 2, Price: 50, Total: 100},
+		},
+	}
+	address := domain.Address{Id: 3, UserID: 1, State: "Kerala", Pin: "682001"}
+	kochi := models.PinCode{Pin: "682001", Serviceable: true, CODAllowed: true, DeliveryDays: 3}
+	lines := []models.OrderItemLine{
+		{InventoryID: 2, Quantity: 1, UnitPrice: 500, TotalPrice: 500},
+		{InventoryID: 1, Quantity: 2, UnitPrice: 50, TotalPrice: 100},
+	}
+	// the 100 coupon is spread over the items by value
+	discountedLines := []models.OrderItemLine{
+		{InventoryID: 2, Quantity: 1, UnitPrice: 500, TotalPrice: 500, Discount: 83.33},
+		{InventoryID: 1, Quantity: 2, UnitPrice: 50, TotalPrice: 100, Discount: 16.67},
+	}
+	// shipped within the seller's state, so GST is split into CGST and SGST
+	taxClasses := []models.TaxClass{
+		{InventoryID: 2, HSNCode: "8517", GSTRate: 18},
+		{InventoryID: 1, HSNCode: "3926", GSTRate: 12},
+	}
+	taxedLines := []models.OrderItemLine{
+		{InventoryID: 2, Quantity: 1, UnitPrice: 500, TotalPrice: 500, HSNCode: "8517", TaxRate: 18, TaxAmount: 90, Taxes: []models.OrderItemTax{
+			{Component: domain.TaxCGST, Rate: 9, Amount: 45},
+			{Component: domain.TaxSGST, Rate: 9, Amount: 45},
+		}},
+		{InventoryID: 1, Quantity: 2, UnitPrice: 50, TotalPrice: 100, HSNCode: "3926", TaxRate: 12, TaxAmount: 12, Taxes: []models.OrderItemTax{
+			{Component: domain.TaxCGST, Rate: 6, Amount: 6},
+			{Component: domain.TaxSGST, Rate: 6, Amount: 6},
+		}},
+	}
+	// standard shipping is free past 500, express never is
+	zone := models.ShippingZone{ID: 2, Name: "Kerala", FreeShippingThreshold: 500, Rates: []models.ShippingRate{
+		{Method: domain.ShippingStandard, Basis: domain.RateByValue, Charge: 40},
+		{Method: domain.ShippingExpress, Basis: domain.RateByWeight, Charge: 120},
+	}}
+
+	tests := []struct {
+		name           string
+		couponID       int
+		shippingMethod string
+		stub           func(m orderTestMocks)
+		wantErr        error
+		wantEvent      bool
+	}{
+		{
+			name:     "without coupon",
+			couponID: 0,
+			stub: func(m orderTestMocks) {
+				m.cart.EXPECT().CheckCart(1).Return(true, nil)
+				m.cart.EXPECT().GetCartId(1).Return(7, nil)
+				m.cart.EXPECT().GetCartItems(7).Return(cart.Data, nil)
+				m.cart.EXPECT().GetAddress(1, 3).Return(address, nil)
+				m.pinCodes.EXPECT().GetPinCode("682001").Return(kochi, nil)
+				m.zones.EXPECT().FindShippingZone("Kerala", 682001).Return(models.ShippingZone{}, nil)
+				m.inventory.EXPECT().GetTaxClasses([]int{2, 1}).Return(nil, nil)
+				m.order.EXPECT().OrderItems(1, 3, 1, models.OrderTotals{ShippingMethod: domain.ShippingStandard, Subtotal: 600, GrandTotal: 600}).Return(10, nil)
+				m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+				m.order.EXPECT().AddOrderProducts(10, lines).Return(nil)
+				m.order.EXPECT().GetPaymentMethodByID(1).Return(models.PaymentMethodResponse{ID: 1, Payment_Name: "Cash on Delivery", Kind: domain.PaymentKindCOD}, nil)
+				gomock.InOrder(
+					m.inventory.EXPECT().LockStock(1).Return(models.CheckStockResponse{ProductName: "Case", Stock: 5}, nil),
+					m.inventory.EXPECT().ReduceStock(1, 2).Return(nil),
+					m.inventory.EXPECT().LockStock(2).Return(models.CheckStockResponse{ProductName: "iPhone", Stock: 1}, nil),
+					m.inventory.EXPECT().ReduceStock(2, 1).Return(nil),
+				)
+				m.cart.EXPECT().ClearCart(7).Return(nil)
+			},
+			wantErr:   nil,
+			wantEvent: true,
+		},
+		{
+			name:     "with coupon",
+			couponID: 4,
+			stub: func(m orderTestMocks) {
+				m.cart.EXPECT().CheckCart(1).Return(true, nil)
+				m.cart.EXPECT().GetCartId(1).Return(7, nil)
+				m.cart.EXPECT().GetCartItems(7).Return(cart.Data, nil)
+				m.cart.EXPECT().GetAddress(1, 3).Return(address, nil)
+				m.pinCodes.EXPECT().GetPinCode("682001").Return(kochi, nil)
+				m.coupon.EXPECT().CheckCouponById(4).Return(true, nil)
+				m.coupon.EXPECT().GetCouponById(4).Return(100, nil)
+				m.zones.EXPECT().FindShippingZone("Kerala", 682001).Return(models.ShippingZone{}, nil)
+				m.inventory.EXPECT().GetTaxClasses([]int{2, 1}).Return(nil, nil)
+				m.order.EXPECT().OrderItems(1, 3, 1, models.OrderTotals{ShippingMethod: domain.ShippingStandard, Subtotal: 600, Discount: 100, GrandTotal: 500}).Return(11, nil)
+				m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+				m.order.EXPECT().AddOrderProducts(11, discountedLines).Return(nil)
+				m.coupon.EXPECT().RedeemCoupon(4, 1, 11, 100.0).Return(nil)
+				m.order.EXPECT().GetPaymentMethodByID(1).Return(models.PaymentMethodResponse{ID: 1, Payment_Name: "Razorpay", Kind: domain.PaymentKindOnline}, nil)
+				m.order.EXPECT().SetPaymentExpiry(11, gomock.Any()).Return(nil)
+				m.inventory.EXPECT().LockStock(1).Return(models.CheckStockResponse{ProductName: "Case", Stock: 5}, nil)
+				m.inventory.EXPECT().ReduceStock(1, 2).Return(nil)
+				m.inventory.EXPECT().LockStock(2).Return(models.CheckStockResponse{ProductName: "iPhone", Stock: 1}, nil)
+				m.inventory.EXPECT().ReduceStock(2, 1).Return(nil)
+				m.cart.EXPECT().ClearCart(7).Return(nil)
+			},
+			wantErr:   nil,
+			wantEvent: true,
+		},
+		{
+			name:     "gst within the seller's state",
+			couponID: 0,
+			stub: func(m orderTestMocks) {
+				m.cart.EXPECT().CheckCart(1).Return(true, nil)
+				m.cart.EXPECT().GetCartId(1).Return(7, nil)
+				m.cart.EXPECT().GetCartItems(7).Return(cart.Data, nil)
+				m.cart.EXPECT().GetAddress(1, 3).Return(address, nil)
+				m.pinCodes.EXPECT().GetPinCode("682001").Return(kochi, nil)
+				m.zones.EXPECT().FindShippingZone("Kerala", 682001).Return(models.ShippingZone{}, nil)
+				m.inventory.EXPECT().GetTaxClasses([]int{2, 1}).Return(taxClasses, nil)
+				m.order.EXPECT().OrderItems(1, 3, 1, models.OrderTotals{ShippingMethod: domain.ShippingStandard, Subtotal: 600, Tax: 102, GrandTotal: 702}).Return(13, nil)
+				m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+				m.order.EXPECT().AddOrderProducts(13, taxedLines).Return(nil)
+				m.order.EXPECT().GetPaymentMethodByID(1).Return(models.PaymentMethodResponse{ID: 1, Payment_Name: "Cash on Delivery", Kind: domain.PaymentKindCOD}, nil)
+				m.inventory.EXPECT().LockStock(1).Return(models.CheckStockResponse{ProductName: "Case", Stock: 5}, nil)
+				m.inventory.EXPECT().ReduceStock(1, 2).Return(nil)
+				m.inventory.EXPECT().LockStock(2).Return(models.CheckStockResponse{ProductName: "iPhone", Stock: 1}, nil)
+				m.inventory.EXPECT().ReduceStock(2, 1).Return(nil)
+				m.cart.EXPECT().ClearCart(7).Return(nil)
+			},
+			wantErr:   nil,
+			wantEvent: true,
+		},
+		{
+			name:           "express shipping to the address's zone",
+			couponID:       0,
+			shippingMethod: domain.ShippingExpress,
+			stub: func(m orderTestMocks) {
+				m.cart.EXPECT().CheckCart(1).Return(true, nil)
+				m.cart.EXPECT().GetCartId(1).Return(7, nil)
+				m.cart.EXPECT().GetCartItems(7).Return(cart.Data, nil)
+				m.cart.EXPECT().GetAddress(1, 3).Return(address, nil)
+				m.pinCodes.EXPECT().GetPinCode("682001").Return(kochi, nil)
+				m.zones.EXPECT().FindShippingZone("Kerala", 682001).Return(zone, nil)
+				m.inventory.EXPECT().GetTaxClasses([]int{2, 1}).Return(nil, nil)
+				m.order.EXPECT().OrderItems(1, 3, 1, models.OrderTotals{ShippingMethod: domain.ShippingExpress, Subtotal: 600, Shipping: 120, GrandTotal: 720}).Return(14, nil)
+				m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+				m.order.EXPECT().AddOrderProducts(14, lines).Return(nil)
+				m.order.EXPECT().GetPaymentMethodByID(1).Return(models.PaymentMethodResponse{ID: 1, Payment_Name: "Cash on Delivery", Kind: domain.PaymentKindCOD}, nil)
+				m.inventory.EXPECT().LockStock(1).Return(models.CheckStockResponse{ProductName: "Case", Stock: 5}, nil)
+				m.inventory.EXPECT().ReduceStock(1, 2).Return(nil)
+				m.inventory.EXPECT().LockStock(2).Return(models.CheckStockResponse{ProductName: "iPhone", Stock: 1}, nil)
+				m.inventory.EXPECT().ReduceStock(2, 1).Return(nil)
+				m.cart.EXPECT().ClearCart(7).Return(nil)
+			},
+			wantErr:   nil,
+			wantEvent: true,
+		},
+		{
+			name:           "express shipping not offered",
+			couponID:       0,
+			shippingMethod: domain.ShippingExpress,
+			stub: func(m orderTestMocks) {
+				m.cart.EXPECT().CheckCart(1).Return(true, nil)
+				m.cart.EXPECT().GetCartId(1).Return(7, nil)
+				m.cart.EXPECT().GetCartItems(7).Return(cart.Data, nil)
+				m.cart.EXPECT().GetAddress(1, 3).Return(address, nil)
+				m.pinCodes.EXPECT().GetPinCode("682001").Return(kochi, nil)
+				m.order.EXPECT().GetPaymentMethodByID(1).Return(models.PaymentMethodResponse{ID: 1, Payment_Name: "Cash on Delivery", Kind: domain.PaymentKindCOD}, nil)
+				m.zones.EXPECT().FindShippingZone("Kerala", 682001).Return(models.ShippingZone{ID: 2, Rates: zone.Rates[:1]}, nil)
+			},
+			wantErr: errors.New("express shipping is not available for this address"),
+		},
+		{
+			name:     "address of someone else",
+			couponID: 0,
+			stub: func(m orderTestMocks) {
+				m.cart.EXPECT().CheckCart(1).Return(true, nil)
+				m.cart.EXPECT().GetCartId(1).Return(7, nil)
+				m.cart.EXPECT().GetCartItems(7).Return(cart.Data, nil)
+				// address 3 belongs to another user, so it is not found
+				m.cart.EXPECT().GetAddress(1, 3).Return(domain.Address{}, nil)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name:     "pin code not serviceable",
+			couponID: 0,
+			stub: func(m orderTestMocks) {
+				m.cart.EXPECT().CheckCart(1).Return(true, nil)
+				m.cart.EXPECT().GetCartId(1).Return(7, nil)
+				m.cart.EXPECT().GetCartItems(7).Return(cart.Data, nil)
+				m.cart.EXPECT().GetAddress(1, 3).Return(address, nil)
+				m.pinCodes.EXPECT().GetPinCode("682001").Return(models.PinCode{}, nil)
+			},
+			wantErr: errNotServiceable,
+		},
+		{
+			name:     "cash on delivery not allowed",
+			couponID: 0,
+			stub: func(m orderTestMocks) {
+				m.cart.EXPECT().CheckCart(1).Return(true, nil)
+				m.cart.EXPECT().GetCartId(1).Return(7, nil)
+				m.cart.EXPECT().GetCartItems(7).Return(cart.Data, nil)
+				m.cart.EXPECT().GetAddress(1, 3).Return(address, nil)
+				m.pinCodes.EXPECT().GetPinCode("682001").Return(models.PinCode{Pin: "682001", Serviceable: true, DeliveryDays: 5}, nil)
+				m.order.EXPECT().GetPaymentMethodByID(1).Return(models.PaymentMethodResponse{ID: 1, Payment_Name: "Cash on Delivery", Kind: domain.PaymentKindCOD}, nil)
+			},
+			wantErr: errors.New("cash on delivery is not available for this pin code"),
+		},
+		{
+			name:     "coupon does not exist",
+			couponID: 4,
+			stub: func(m orderTestMocks) {
+				m.cart.EXPECT().CheckCart(1).Return(true, nil)
+				m.cart.EXPECT().GetCartId(1).Return(7, nil)
+				m.cart.EXPECT().GetCartItems(7).Return(cart.Data, nil)
+				m.cart.EXPECT().GetAddress(1, 3).Return(address, nil)
+				m.pinCodes.EXPECT().GetPinCode("682001").Return(kochi, nil)
+				m.order.EXPECT().GetPaymentMethodByID(1).Return(models.PaymentMethodResponse{ID: 1, Payment_Name: "Cash on Delivery", Kind: domain.PaymentKindCOD}, nil)
+				m.coupon.EXPECT().CheckCouponById(4).Return(false, nil)
+			},
+			wantErr: errors.New("coupon does not exist"),
+		},
+		{
+			name:     "insufficient stock",
+			couponID: 0,
+			stub: func(m orderTestMocks) {
+				m.cart.EXPECT().CheckCart(1).Return(true, nil)
+				m.cart.EXPECT().GetCartId(1).Return(7, nil)
+				m.cart.EXPECT().GetCartItems(7).Return(cart.Data, nil)
+				m.cart.EXPECT().GetAddress(1, 3).Return(address, nil)
+				m.pinCodes.EXPECT().GetPinCode("682001").Return(kochi, nil)
+				m.zones.EXPECT().FindShippingZone("Kerala", 682001).Return(models.ShippingZone{}, nil)
+				m.inventory.EXPECT().GetTaxClasses([]int{2, 1}).Return(nil, nil)
+				m.order.EXPECT().OrderItems(1, 3, 1, models.OrderTotals{ShippingMethod: domain.ShippingStandard, Subtotal: 600, GrandTotal: 600}).Return(12, nil)
+				m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+				m.order.EXPECT().AddOrderProducts(12, lines).Return(nil)
+				m.order.EXPECT().GetPaymentMethodByID(1).Return(models.PaymentMethodResponse{ID: 1, Payment_Name: "Cash on Delivery", Kind: domain.PaymentKindCOD}, nil)
+				m.inventory.EXPECT().LockStock(1).Return(models.CheckStockResponse{ProductName: "Case", Stock: 5}, nil)
+				m.inventory.EXPECT().ReduceStock(1, 2).Return(nil)
+				m.inventory.EXPECT().LockStock(2).Return(models.CheckStockResponse{ProductName: "iPhone", Stock: 0}, nil)
+			},
+			wantErr: &domain.InsufficientStockError{ProductID: 2, ProductName: "iPhone", Requested: 1, Available: 0},
+		},
+		{
+			name:     "empty cart",
+			couponID: 0,
+			stub: func(m orderTestMocks) {
+				m.cart.EXPECT().CheckCart(1).Return(false, errors.New("no cart found"))
+			},
+			wantErr: errors.New("no cart found"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, m, published := newOrderTestUseCase(ctrl)
+			tc.stub(m)
+
+			err := uc.OrderItemsFromCart(1, 3, 1, tc.couponID, tc.shippingMethod)
+			assert.Equal(t, tc.wantErr, err)
+			if tc.wantEvent {
+				assert.Len(t, *published, 1)
+				assert.Equal(t, events.OrderPlaced, (*published)[0].Name)
+			} else {
+				assert.Empty(t, *published)
+			}
+		})
+	}
+}
+
+func TestCancelOrderItems(t *testing.T) {
+	// 1000 worth of items bought for 900 after a 100 coupon
+	items := func() []models.OrderItemState {
+		return []models.OrderItemState{
+			{ID: 20, InventoryID: 1, Quantity: 2, TotalPrice: 400, Status: domain.OrderStatusPending},
+			{ID: 21, InventoryID: 2, Quantity: 1, TotalPrice: 600, Status: domain.OrderStatusPending},
+		}
+	}
+	paid := models.OrderState{OrderID: 5, UserID: 1, OrderStatus: domain.OrderStatusPending, PaymentStatus: domain.PaymentStatusPaid, FinalPrice: 900}
+
+	tests := []struct {
+		name     string
+		items    []models.OrderItemQuantity
+		refundTo string
+		stub     func(m orderTestMocks)
+		wantErr  error
+	}{
+		{
+			name:  "one unit is refunded with its share of the coupon",
+			items: []models.OrderItemQuantity{{OrderItemID: 20, Quantity: 1}},
+			stub: func(m orderTestMocks) {
+				m.order.EXPECT().LockOrderState(5).Return(paid, nil)
+				m.order.EXPECT().LockOrderItems(5).Return(items(), nil)
+				m.order.EXPECT().UpdateOrderItem(models.OrderItemState{ID: 20, InventoryID: 1, Quantity: 2, TotalPrice: 400, Status: domain.OrderStatusPending, CanceledQuantity: 1, RefundAmount: 180}).Return(nil)
+				m.inventory.EXPECT().RestoreStock(1, 1).Return(nil)
+				m.order.EXPECT().UpdateOrderState(5, domain.OrderStatusPending, domain.PaymentStatusPartiallyRefunded).Return(nil)
+				m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+				// paid by cash, so the wallet is the only way back
+				m.payment.EXPECT().GetCapturedPayment(5).Return(models.PaymentDetails{}, nil)
+				m.wallet.EXPECT().AddToWallet(180.0, 1).Return(models.WalletAmount{}, nil)
+				m.refund.EXPECT().AddRefund(models.RefundDetails{OrderID: 5, Method: domain.RefundToWallet, Amount: 180, Status: domain.RefundProcessed, Reason: "items canceled by customer"}).Return(1, nil)
+				m.refund.EXPECT().CountPendingRefunds(5).Return(0, nil)
+				m.invoice.EXPECT().GetInvoiceByOrder(5).Return(models.Invoice{}, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "the wallet is credited the same paise as the refund",
+			items: []models.OrderItemQuantity{{OrderItemID: 20, Quantity: 1}},
+			stub: func(m orderTestMocks) {
+				state := paid
+				state.FinalPrice = 900.45
+				m.order.EXPECT().LockOrderState(5).Return(state, nil)
+				m.order.EXPECT().LockOrderItems(5).Return(items(), nil)
+				m.order.EXPECT().UpdateOrderItem(models.OrderItemState{ID: 20, InventoryID: 1, Quantity: 2, TotalPrice: 400, Status: domain.OrderStatusPending, CanceledQuantity: 1, RefundAmount: 180.09}).Return(nil)
+				m.inventory.EXPECT().RestoreStock(1, 1).Return(nil)
+				m.order.EXPECT().UpdateOrderState(5, domain.OrderStatusPending, domain.PaymentStatusPartiallyRefunded).Return(nil)
+				m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+				m.payment.EXPECT().GetCapturedPayment(5).Return(models.PaymentDetails{}, nil)
+				m.wallet.EXPECT().AddToWallet(180.09, 1).Return(models.WalletAmount{}, nil)
+				m.refund.EXPECT().AddRefund(models.RefundDetails{OrderID: 5, Method: domain.RefundToWallet, Amount: 180.09, Status: domain.RefundProcessed, Reason: "items canceled by customer"}).Return(1, nil)
+				m.refund.EXPECT().CountPendingRefunds(5).Return(0, nil)
+				m.invoice.EXPECT().GetInvoiceByOrder(5).Return(models.Invoice{}, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "canceling the rest cancels the order",
+			items: []models.OrderItemQuantity{{OrderItemID: 20, Quantity: 1}, {OrderItemID: 21, Quantity: 1}},
+			stub: func(m orderTestMocks) {
+				state := paid
+				state.PaymentStatus = domain.PaymentStatusPartiallyRefunded
+				locked := items()
+				locked[0].CanceledQuantity = 1
+				locked[0].RefundAmount = 180
+				m.order.EXPECT().LockOrderState(5).Return(state, nil)
+				m.order.EXPECT().LockOrderItems(5).Return(locked, nil)
+				m.order.EXPECT().UpdateOrderItem(models.OrderItemState{ID: 20, InventoryID: 1, Quantity: 2, TotalPrice: 400, Status: domain.OrderStatusCanceled, CanceledQuantity: 2, RefundAmount: 360}).Return(nil)
+				m.order.EXPECT().UpdateOrderItem(models.OrderItemState{ID: 21, InventoryID: 2, Quantity: 1, TotalPrice: 600, Status: domain.OrderStatusCanceled, CanceledQuantity: 1, RefundAmount: 540}).Return(nil)
+				m.inventory.EXPECT().RestoreStock(1, 1).Return(nil)
+				m.inventory.EXPECT().RestoreStock(2, 1).Return(nil)
+				m.order.EXPECT().UpdateOrderState(5, domain.OrderStatusCanceled, domain.PaymentStatusReturnedToWallet).Return(nil)
+				m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+				m.payment.EXPECT().GetCapturedPayment(5).Return(models.PaymentDetails{}, nil)
+				m.wallet.EXPECT().AddToWallet(720.0, 1).Return(models.WalletAmount{}, nil)
+				m.refund.EXPECT().AddRefund(gomock.Any()).Return(2, nil)
+				m.refund.EXPECT().CountPendingRefunds(5).Return(0, nil)
+				m.invoice.EXPECT().GetInvoiceByOrder(5).Return(models.Invoice{}, nil)
+				// nothing is left open, so the coupon is given back
+				m.coupon.EXPECT().ReleaseCouponRedemption(5).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "an unpaid order owes less instead of being refunded",
+			items: []models.OrderItemQuantity{{OrderItemID: 20, Quantity: 1}},
+			stub: func(m orderTestMocks) {
+				state := paid
+				state.PaymentStatus = domain.PaymentStatusNotPaid
+				m.order.EXPECT().LockOrderState(5).Return(state, nil)
+				m.order.EXPECT().LockOrderItems(5).Return(items(), nil)
+				m.order.EXPECT().UpdateOrderItem(models.OrderItemState{ID: 20, InventoryID: 1, Quantity: 2, TotalPrice: 400, Status: domain.OrderStatusPending, CanceledQuantity: 1}).Return(nil)
+				m.inventory.EXPECT().RestoreStock(1, 1).Return(nil)
+				m.order.EXPECT().ReduceFinalPrice(5, 180.0).Return(nil)
+				m.invoice.EXPECT().GetInvoiceByOrder(5).Return(models.Invoice{}, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "canceling the rest of an unpaid order takes what is still due",
+			items: []models.OrderItemQuantity{{OrderItemID: 20, Quantity: 1}, {OrderItemID: 21, Quantity: 1}},
+			stub: func(m orderTestMocks) {
+				state := paid
+				state.PaymentStatus = domain.PaymentStatusNotPaid
+				state.FinalPrice = 720
+				locked := items()
+				locked[0].CanceledQuantity = 1
+				m.order.EXPECT().LockOrderState(5).Return(state, nil)
+				m.order.EXPECT().LockOrderItems(5).Return(locked, nil)
+				m.order.EXPECT().UpdateOrderItem(models.OrderItemState{ID: 20, InventoryID: 1, Quantity: 2, TotalPrice: 400, Status: domain.OrderStatusCanceled, CanceledQuantity: 2}).Return(nil)
+				m.order.EXPECT().UpdateOrderItem(models.OrderItemState{ID: 21, InventoryID: 2, Quantity: 1, TotalPrice: 600, Status: domain.OrderStatusCanceled, CanceledQuantity: 1}).Return(nil)
+				m.inventory.EXPECT().RestoreStock(1, 1).Return(nil)
+				m.inventory.EXPECT().RestoreStock(2, 1).Return(nil)
+				m.order.EXPECT().ReduceFinalPrice(5, 720.0).Return(nil)
+				m.order.EXPECT().UpdateOrderState(5, domain.OrderStatusCanceled, domain.PaymentStatusNotPaid).Return(nil)
+				m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+				m.coupon.EXPECT().ReleaseCouponRedemption(5).Return(nil)
+				m.invoice.EXPECT().GetInvoiceByOrder(5).Return(models.Invoice{}, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "paid online, refunded through the gateway",
+			items: []models.OrderItemQuantity{{OrderItemID: 20, Quantity: 1}},
+			stub: func(m orderTestMocks) {
+				m.order.EXPECT().LockOrderState(5).Return(paid, nil)
+				m.order.EXPECT().LockOrderItems(5).Return(items(), nil)
+				m.order.EXPECT().UpdateOrderItem(gomock.Any()).Return(nil)
+				m.inventory.EXPECT().RestoreStock(1, 1).Return(nil)
+				m.payment.EXPECT().GetCapturedPayment(5).Return(models.PaymentDetails{ID: 3, OrderID: 5, PaymentID: "pay_P1", Status: domain.PaymentCaptured}, nil)
+				m.refund.EXPECT().AddRefund(models.RefundDetails{OrderID: 5, Method: domain.RefundToOriginal, Amount: 180, PaymentID: "pay_P1", Status: domain.RefundInitiated, Reason: "items canceled by customer"}).Return(1, nil)
+				m.refund.EXPECT().CountPendingRefunds(5).Return(1, nil)
+				m.order.EXPECT().UpdateOrderState(5, domain.OrderStatusPending, domain.PaymentStatusRefundInProgress).Return(nil)
+				m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+				m.invoice.EXPECT().GetInvoiceByOrder(5).Return(models.Invoice{}, nil)
+			},
+		},
+		{
+			name:     "paid online, customer asks for the wallet",
+			items:    []models.OrderItemQuantity{{OrderItemID: 20, Quantity: 1}},
+			refundTo: "wallet",
+			stub: func(m orderTestMocks) {
+				m.order.EXPECT().LockOrderState(5).Return(paid, nil)
+				m.order.EXPECT().LockOrderItems(5).Return(items(), nil)
+				m.order.EXPECT().UpdateOrderItem(gomock.Any()).Return(nil)
+				m.inventory.EXPECT().RestoreStock(1, 1).Return(nil)
+				m.wallet.EXPECT().AddToWallet(180.0, 1).Return(models.WalletAmount{}, nil)
+				m.refund.EXPECT().AddRefund(gomock.Any()).Return(1, nil)
+				m.refund.EXPECT().CountPendingRefunds(5).Return(0, nil)
+				m.order.EXPECT().UpdateOrderState(5, domain.OrderStatusPending, domain.PaymentStatusPartiallyRefunded).Return(nil)
+				m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+				m.invoice.EXPECT().GetInvoiceByOrder(5).Return(models.Invoice{}, nil)
+			},
+		},
+		{
+			name:     "cash order cannot go back to the original payment",
+			items:    []models.OrderItemQuantity{{OrderItemID: 20, Quantity: 1}},
+			refundTo: "original",
+			stub: func(m orderTestMocks) {
+				m.order.EXPECT().LockOrderState(5).Return(paid, nil)
+				m.order.EXPECT().LockOrderItems(5).Return(items(), nil)
+				m.order.EXPECT().UpdateOrderItem(gomock.Any()).Return(nil)
+				m.inventory.EXPECT().RestoreStock(1, 1).Return(nil)
+				m.payment.EXPECT().GetCapturedPayment(5).Return(models.PaymentDetails{}, nil)
+			},
+			wantErr: errors.New("only orders paid online can be refunded to the original payment method"),
+		},
+		{
+			name:     "unknown refund destination",
+			items:    []models.OrderItemQuantity{{OrderItemID: 20, Quantity: 1}},
+			refundTo: "bank",
+			stub:     func(m orderTestMocks) {},
+			wantErr:  errors.New("refund_to should be wallet or original"),
+		},
+		{
+			name:  "quantity exceeds what is left",
+			items: []models.OrderItemQuantity{{OrderItemID: 21, Quantity: 2}},
+			stub: func(m orderTestMocks) {
+				m.order.EXPECT().LockOrderState(5).Return(paid, nil)
+				m.order.EXPECT().LockOrderItems(5).Return(items(), nil)
+			},
+			wantErr: errors.New("quantity exceeds what is left to cancel"),
+		},
+		{
+			name:  "item of another order",
+			items: []models.OrderItemQuantity{{OrderItemID: 99, Quantity: 1}},
+			stub: func(m orderTestMocks) {
+				m.order.EXPECT().LockOrderState(5).Return(paid, nil)
+				m.order.EXPECT().LockOrderItems(5).Return(items(), nil)
+			},
+			wantErr: errors.New("item does not belong to this order"),
+		},
+		{
+			name:  "order of another user",
+			items: []models.OrderItemQuantity{{OrderItemID: 20, Quantity: 1}},
+			stub: func(m orderTestMocks) {
+				state := paid
+				state.UserID = 2
+				m.order.EXPECT().LockOrderState(5).Return(state, nil)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, m, _ := newOrderTestUseCase(ctrl)
+			tc.stub(m)
+
+			err := uc.CancelOrderItems(1, 5, tc.items, tc.refundTo)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestExpireUnpaidOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, m, _ := newOrderTestUseCase(ctrl)
+
+	m.order.EXPECT().GetExpiredUnpaidOrders(expiryBatchSize).Return([]int{5, 6}, nil)
+
+	// order 5 is still unpaid and is canceled
+	m.order.EXPECT().LockOrderState(5).Return(models.OrderState{OrderID: 5, UserID: 1, OrderStatus: domain.OrderStatusPending, PaymentStatus: domain.PaymentStatusNotPaid, FinalPrice: 500}, nil)
+	m.order.EXPECT().LockOrderItems(5).Return([]models.OrderItemState{{ID: 20, InventoryID: 1, Quantity: 2, TotalPrice: 500, Status: domain.OrderStatusPending}}, nil)
+	m.order.EXPECT().UpdateOrderItem(models.OrderItemState{ID: 20, InventoryID: 1, Quantity: 2, TotalPrice: 500, Status: domain.OrderStatusCanceled, CanceledQuantity: 2}).Return(nil)
+	m.inventory.EXPECT().RestoreStock(1, 2).Return(nil)
+	m.order.EXPECT().ReduceFinalPrice(5, 500.0).Return(nil)
+	m.order.EXPECT().UpdateOrderState(5, domain.OrderStatusCanceled, domain.PaymentStatusNotPaid).Return(nil)
+	m.order.EXPECT().AddStatusHistory(gomock.Any()).DoAndReturn(func(h domain.OrderStatusHistory) error {
+		assert.Equal(t, domain.ActorSystem, h.ActorRole)
+		assert.Equal(t, "payment not received in time", h.Reason)
+		return nil
+	})
+	m.invoice.EXPECT().GetInvoiceByOrder(5).Return(models.Invoice{}, nil)
+	m.coupon.EXPECT().ReleaseCouponRedemption(5).Return(nil)
+
+	// order 6 was paid after it was listed and is left alone
+	m.order.EXPECT().LockOrderState(6).Return(models.OrderState{OrderID: 6, UserID: 2, OrderStatus: domain.OrderStatusPending, PaymentStatus: domain.PaymentStatusPaid, FinalPrice: 300}, nil)
+
+	expired, err := uc.ExpireUnpaidOrders()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+}
+
+func TestReorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, m, _ := newOrderTestUseCase(ctrl)
+
+	m.order.EXPECT().FindUserID(5).Return(1, nil).Times(2)
+	m.order.EXPECT().GetReorderItems(5).Return([]models.ReorderItem{
+		{InventoryID: 1, ProductName: "Soap", Quantity: 2, Stock: 10, Price: 40, Available: true},
+		{InventoryID: 2, ProductName: "Shampoo", Quantity: 3, Stock: 1, Price: 250, Available: true},
+		{InventoryID: 3, ProductName: "Comb", Quantity: 1, Stock: 0, Price: 30, Available: true},
+		{InventoryID: 4, Quantity: 1},
+		{InventoryID: 6, ProductName: "Towel", Quantity: 1, Stock: 4, Price: 300, Available: true},
+	}, nil)
+	m.cartUseCase.EXPECT().AddToCart(1, 1, 2).Return(nil)
+	m.cartUseCase.EXPECT().AddToCart(1, 2, 1).Return(nil)
+	m.cartUseCase.EXPECT().AddToCart(1, 6, 1).Return(errors.New("item already exists in cart"))
+
+	result, err := uc.Reorder(1, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ReorderResult{
+		Added: []models.ReorderLine{
+			{InventoryID: 1, ProductName: "Soap", OrderedQuantity: 2, AddedQuantity: 2, Price: 40},
+		},
+		PartiallyAdded: []models.ReorderLine{
+			{InventoryID: 2, ProductName: "Shampoo", OrderedQuantity: 3, AddedQuantity: 1, Price: 250, Reason: "only 1 in stock"},
+		},
+		Skipped: []models.ReorderLine{
+			{InventoryID: 3, ProductName: "Comb", OrderedQuantity: 1, Price: 30, Reason: "out of stock"},
+			{InventoryID: 4, OrderedQuantity: 1, Reason: "discontinued"},
+			{InventoryID: 6, ProductName: "Towel", OrderedQuantity: 1, Price: 300, Reason: "item already exists in cart"},
+		},
+	}, result)
+
+	_, err = uc.Reorder(2, 5)
+	assert.Equal(t, domain.ErrForbidden, err)
+}
+
+func TestGetAdminOrders(t *testing.T) {
+	min, max := 500.0, 100.0
+
+	tests := []struct {
+		name     string
+		filter   models.AdminOrderFilter
+		stub     func(m orderTestMocks)
+		wantMeta models.PageMeta
+		wantErr  error
+	}{
+		{
+			name:   "filters are normalised and pages counted",
+			filter: models.AdminOrderFilter{OrderStatus: "pending", From: "01-03-2024", To: "31-03-2024", Search: " anu ", Page: 2, PageSize: 10},
+			stub: func(m orderTestMocks) {
+				m.order.EXPECT().SearchOrders(gomock.Any()).DoAndReturn(func(q models.AdminOrderQuery) ([]models.AdminOrderSummary, int64, error) {
+					assert.Equal(t, domain.OrderStatusPending, q.OrderStatus)
+					assert.Equal(t, "anu", q.Search)
+					assert.Equal(t, "2024-03-01", q.From.Format("2006-01-02"))
+					assert.Equal(t, "2024-04-01", q.Before.Format("2006-01-02"))
+					return []models.AdminOrderSummary{{OrderID: 9}}, 21, nil
+				})
+			},
+			wantMeta: models.PageMeta{Page: 2, PageSize: 10, TotalCount: 21, TotalPages: 3},
+		},
+		{
+			name:   "defaults",
+			filter: models.AdminOrderFilter{},
+			stub: func(m orderTestMocks) {
+				m.order.EXPECT().SearchOrders(gomock.Any()).Return(nil, int64(0), nil)
+			},
+			wantMeta: models.PageMeta{Page: 1, PageSize: 20, TotalCount: 0, TotalPages: 0},
+		},
+		{
+			name:    "bad date",
+			filter:  models.AdminOrderFilter{From: "2024-03-01"},
+			stub:    func(m orderTestMocks) {},
+			wantErr: errors.New("from date should be in dd-mm-yyyy format"),
+		},
+		{
+			name:    "amount range upside down",
+			filter:  models.AdminOrderFilter{MinAmount: &min, MaxAmount: &max},
+			stub:    func(m orderTestMocks) {},
+			wantErr: errors.New("minimum amount is more than maximum amount"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, m, _ := newOrderTestUseCase(ctrl)
+			tc.stub(m)
+
+			list, err := uc.GetAdminOrders(tc.filter)
+			assert.Equal(t, tc.wantErr, err)
+			if tc.wantErr == nil {
+				assert.Equal(t, tc.wantMeta, list.Meta)
+				assert.NotNil(t, list.Orders)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/ahdaan98/pkg/domain"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+)
+
+// itemClosure cancels or returns Quantity units of one order item, Damaged of
+// which came back unsellable.
+type itemClosure struct {
+	OrderItemID int
+	Quantity    int
+	Damaged     int
+}
+
+// itemSelector picks the units to close out of the locked items of an order.
+type itemSelector func(items []models.OrderItemState) ([]itemClosure, error)
+
+// allOpenItems selects every unit that is not canceled or returned yet.
+func allOpenItems() itemSelector {
+	return func(items []models.OrderItemState) ([]itemClosure, error) {
+		var closures []itemClosure
+		for _, item := range items {
+			if item.OpenQuantity() > 0 {
+				closures = append(closures, itemClosure{OrderItemID: item.ID, Quantity: item.OpenQuantity()})
+			}
+		}
+		return closures, nil
+	}
+}
+
+func requestedItems(closures []itemClosure) itemSelector {
+	return func(items []models.OrderItemState) ([]itemClosure, error) {
+		return closures, nil
+	}
+}
+
+// closeOrderItems cancels or returns units of an order's items, puts them back
+// into stock, refunds their pro rata share of the final price when the order
+// was paid or takes it off the final price when it was not, credits them
+// against the order's invoice and moves the order to the status derived from
+// its items, releasing the coupon once the whole order is canceled. state must have been read with LockOrderState in the same
+// transaction and closed is either CANCELED or RETURNED. refundTo is where the
+// customer asked for the refund to go, see refundPayment.
+func closeOrderItems(repos interfaces.TxRepositories, state models.OrderState, closed string, selectItems itemSelector, refundTo string, actor domain.Actor, reason string) error {
+	items, err := repos.Order.LockOrderItems(state.OrderID)
+	if err != nil {
+		return err
+	}
+
+	closures, err := selectItems(items)
+	if err != nil {
+		return err
+	}
+	if len(closures) == 0 {
+		return errors.New("no items left to " + itemAction(closed))
+	}
+
+	requested := make(map[int]itemClosure, len(closures))
+	for _, c := range closures {
+		if c.Quantity <= 0 || c.Damaged < 0 {
+			return errors.New("enter a valid quantity")
+		}
+		if c.Damaged > 0 && closed != domain.OrderStatusReturned {
+			return errors.New("only returned items can be marked damaged")
+		}
+		r := requested[c.OrderItemID]
+		r.OrderItemID = c.OrderItemID
+		r.Quantity += c.Quantity
+		r.Damaged += c.Damaged
+		requested[c.OrderItemID] = r
+	}
+
+	// what is still owed for the open units: the final price less what was
+	// refunded for a paid order, the final price itself for an unpaid one
+	var openValue, refunded float64
+	open := make(map[int]int, len(items))
+	for _, item := range items {
+		openValue += item.TotalPrice / float64(item.Quantity) * float64(item.OpenQuantity())
+		refunded += item.RefundAmount
+		open[item.ID] = item.OpenQuantity()
+	}
+	outstanding := state.FinalPrice - refunded
+
+	for _, r := range requested {
+		left, ok := open[r.OrderItemID]
+		if !ok {
+			return errors.New("item does not belong to this order")
+		}
+		if r.Quantity > left {
+			return errors.New("quantity exceeds what is left to " + itemAction(closed))
+		}
+		if r.Damaged > r.Quantity {
+			return errors.New("damaged quantity exceeds returned quantity")
+		}
+	}
+
+	paid := false
+	switch state.PaymentStatus {
+	case domain.PaymentStatusPaid, domain.PaymentStatusPartiallyRefunded, domain.PaymentStatusRefundInProgress:
+		paid = true
+	}
+
+	var (
+		refund   float64
+		lines    []restockLine
+		changed  []int
+		credited []models.CreditNoteItem
+	)
+	for i := range items {
+		item := &items[i]
+		r, ok := requested[item.ID]
+		if !ok {
+			continue
+		}
+
+		// the coupon discount is spread over the items in proportion to
+		// their value, so every unit carries its share of it
+		share := item.TotalPrice / float64(item.Quantity) * float64(r.Quantity)
+		if openValue > 0 {
+			share = share * outstanding / openValue
+		}
+		share = math.Round(share*100) / 100
+
+		if closed == domain.OrderStatusCanceled {
+			item.CanceledQuantity += r.Quantity
+		} else {
+			item.ReturnedQuantity += r.Quantity
+			item.DamagedQuantity += r.Damaged
+		}
+		if paid {
+			item.RefundAmount += share
+		}
+		refund += share
+
+		if item.OpenQuantity() == 0 {
+			item.Status = domain.OrderStatusCanceled
+			if item.ReturnedQuantity > 0 {
+				item.Status = domain.OrderStatusReturned
+			}
+		}
+
+		lines = append(lines, restockLine{InventoryID: item.InventoryID, Quantity: r.Quantity, Damaged: r.Damaged})
+		changed = append(changed, i)
+		credited = append(credited, models.CreditNoteItem{OrderItemID: item.ID, ProductName: item.ProductName, Quantity: r.Quantity, Amount: share})
+	}
+
+	allClosed := true
+	for _, item := range items {
+		if item.OpenQuantity() > 0 {
+			allClosed = false
+		}
+	}
+
+	// once nothing is left open the shares must add up to what was owed
+	// exactly, so the last closure absorbs any rounding difference
+	if allClosed {
+		diff := outstanding - refund
+		if paid {
+			items[changed[len(changed)-1]].RefundAmount += diff
+		}
+		credited[len(credited)-1].Amount += diff
+		refund += diff
+	}
+
+	for _, i := range changed {
+		if err := repos.Order.UpdateOrderItem(items[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := restockItems(repos, lines); err != nil {
+		return err
+	}
+
+	change := orderStateChange{
+		OrderStatus: deriveOrderStatus(items, state.OrderStatus),
+		Actor:       actor,
+		Reason:      reason,
+	}
+
+	// nothing was taken for an unpaid order, so the units are simply no
+	// longer due, which also lowers what cash on delivery collects
+	if paid {
+		change.PaymentStatus, err = refundPayment(repos, state, refund, refundTo, allClosed, reason)
+		if err != nil {
+			return err
+		}
+	} else if err := repos.Order.ReduceFinalPrice(state.OrderID, refund); err != nil {
+		return err
+	}
+
+	if err := changeOrderState(repos.Order, state, change); err != nil {
+		return err
+	}
+
+	// an order canceled outright gives its coupon back
+	if change.OrderStatus == domain.OrderStatusCanceled {
+		if err := repos.Coupon.ReleaseCouponRedemption(state.OrderID); err != nil {
+			return err
+		}
+	}
+
+	return issueCreditNote(repos.Invoice, state.OrderID, credited, reason, time.Now())
+}
+
+// deriveOrderStatus works out the order status from its items. While any unit
+// is still open the order keeps its fulfilment status; once every unit is
+// closed it is RETURNED if anything was returned and CANCELED otherwise.
+func deriveOrderStatus(items []models.OrderItemState, current string) string {
+	returned := false
+	for _, item := range items {
+		if item.OpenQuantity() > 0 {
+			return current
+		}
+		if item.ReturnedQuantity > 0 {
+			returned = true
+		}
+	}
+
+	if returned {
+		return domain.OrderStatusReturned
+	}
+	return domain.OrderStatusCanceled
+}
+
+func itemAction(closed string) string {
+	if closed == domain.OrderStatusReturned {
+		return "return"
+	}
+	return "cancel"
+}
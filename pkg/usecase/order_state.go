@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/ahdaan98/pkg/domain"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+)
+
+// orderStateChange is a single move of an order through the order state
+// machine. An empty status leaves that side of the order as it is.
+type orderStateChange struct {
+	OrderStatus   string
+	PaymentStatus string
+	Actor         domain.Actor
+	Reason        string
+}
+
+// changeOrderState checks change against the allowed transitions, updates the
+// order and records the move in order_status_history. current must have been
+// read with LockOrderState inside the same transaction.
+func changeOrderState(repo interfaces.OrderRepository, current models.OrderState, change orderStateChange) error {
+	next := current
+
+	if change.OrderStatus != "" && change.OrderStatus != current.OrderStatus {
+		if !domain.CanChangeOrderStatus(current.OrderStatus, change.OrderStatus) {
+			return &domain.InvalidTransitionError{Field: "order status", From: current.OrderStatus, To: change.OrderStatus}
+		}
+		next.OrderStatus = change.OrderStatus
+	}
+
+	if change.PaymentStatus != "" && change.PaymentStatus != current.PaymentStatus {
+		if !domain.CanChangePaymentStatus(current.PaymentStatus, change.PaymentStatus) {
+			return &domain.InvalidTransitionError{Field: "payment status", From: current.PaymentStatus, To: change.PaymentStatus}
+		}
+		next.PaymentStatus = change.PaymentStatus
+	}
+
+	if next == current {
+		return nil
+	}
+
+	if err := repo.UpdateOrderState(current.OrderID, next.OrderStatus, next.PaymentStatus); err != nil {
+		return err
+	}
+
+	return repo.AddStatusHistory(domain.OrderStatusHistory{
+		OrderID:               uint(current.OrderID),
+		PreviousOrderStatus:   current.OrderStatus,
+		OrderStatus:           next.OrderStatus,
+		PreviousPaymentStatus: current.PaymentStatus,
+		PaymentStatus:         next.PaymentStatus,
+		ActorRole:             change.Actor.Role,
+		ActorID:               change.Actor.ID,
+		Reason:                change.Reason,
+	})
+}
+
+// advanceOrder moves an order and its open items along the fulfilment path to
+// SHIPPED or DELIVERED. Cash orders are marked paid on delivery, and every
+// order is invoiced on delivery.
+func advanceOrder(repos interfaces.TxRepositories, state models.OrderState, status string, actor domain.Actor, reason string) error {
+	change := orderStateChange{
+		OrderStatus: status,
+		Actor:       actor,
+		Reason:      reason,
+	}
+	if status == domain.OrderStatusDelivered && state.PaymentStatus == domain.PaymentStatusNotPaid {
+		change.PaymentStatus = domain.PaymentStatusPaid
+	}
+
+	if err := changeOrderState(repos.Order, state, change); err != nil {
+		return err
+	}
+
+	if err := repos.Order.SetOpenItemsStatus(state.OrderID, status); err != nil {
+		return err
+	}
+
+	if status == domain.OrderStatusDelivered {
+		return issueInvoice(repos, state.OrderID, time.Now())
+	}
+	return nil
+}
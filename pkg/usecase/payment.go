@@ -1,24 +1,40 @@
 package usecase
 
 import (
+	"github.com/ahdaan98/pkg/config"
+	"github.com/ahdaan98/pkg/domain"
+	"github.com/ahdaan98/pkg/gateway"
 	usecase "github.com/ahdaan98/pkg/repository/interface"
 	"github.com/ahdaan98/pkg/usecase/interface"
 	"github.com/ahdaan98/pkg/utils/models"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
-	"fmt"
-
-	"github.com/razorpay/razorpay-go"
+	"log"
+	"math"
+	"strconv"
+	"strings"
 )
 
 type paymentUsecaseImpl struct {
 	paymentRepo     usecase.PaymentRepository
 	orderRepository usecase.OrderRepository
+	refundRepo      usecase.RefundRepository
+	transaction     usecase.TransactionRepository
+	gateway         gateway.PaymentGateway
+	webhookSecret   []byte
 }
 
-func NewPaymentUseCase(repo usecase.OrderRepository, payment usecase.PaymentRepository) interfaces.PaymentUseCase {
+func NewPaymentUseCase(repo usecase.OrderRepository, payment usecase.PaymentRepository, refunds usecase.RefundRepository, transaction usecase.TransactionRepository, paymentGateway gateway.PaymentGateway, cfg config.Config) interfaces.PaymentUseCase {
 	return &paymentUsecaseImpl{
 		orderRepository: repo,
 		paymentRepo:     payment,
+		refundRepo:      refunds,
+		transaction:     transaction,
+		gateway:         paymentGateway,
+		webhookSecret:   []byte(cfg.RAZORPAY_WEBHOOK_SECRET),
 	}
 }
 
@@ -30,65 +46,474 @@ func (repo *paymentUsecaseImpl) MakePaymentRazorpay(orderId, userId int) (models
 		return models.CombinedOrderDetails{}, "", errors.New("please provide valid IDs")
 	}
 
-	fmt.Println("Order iddddddd inside usecase", orderId)
-
 	order, err := repo.orderRepository.GetOrder(orderId)
 	if err != nil {
 		err = errors.New("error in getting order details through order id" + err.Error())
 		return models.CombinedOrderDetails{}, "", err
 	}
+	if order.ID == 0 {
+		return models.CombinedOrderDetails{}, "", errors.New("no order exists")
+	}
+	if int(order.UserID) != userId {
+		return models.CombinedOrderDetails{}, "", domain.ErrForbidden
+	}
 
-	client := razorpay.NewClient("rzp_test_aueoCaNaL72guF", "XRRVDHevQn9sx7ajTz0LXBHT")
-
-	fmt.Println("order amount", order.FinalPrice)
-	data := map[string]interface{}{
-		"amount":   int(order.FinalPrice) * 100,
-		"currency": "INR",
-		"receipt":  "some_receipt_id",
+	attempt, err := repo.startPaymentAttempt(order)
+	if err != nil {
+		return models.CombinedOrderDetails{}, "", err
 	}
+	razorPayOrderId := attempt.GatewayOrderID
 
-	body, err := client.Order.Create(data, nil)
+	body2, err := repo.orderRepository.GetDetailedOrderThroughId(int(order.ID))
 	if err != nil {
 		return models.CombinedOrderDetails{}, "", err
 	}
 
-	fmt.Println("body usecase", body)
-	razorPayOrderId := body["id"].(string)
+	return body2, razorPayOrderId, nil
+}
 
-	err = repo.paymentRepo.AddRazorPayDetails(orderId, razorPayOrderId)
+// RetryPayment starts a fresh payment attempt for one of the user's unpaid
+// orders, for when an earlier attempt failed or was abandoned. Earlier
+// attempts are kept and a late payment on one of them still pays the order;
+// whichever payment is captured second is refunded.
+func (repo *paymentUsecaseImpl) RetryPayment(userID, orderID int) (models.PaymentDetails, error) {
+	if err := authorizeOrder(repo.orderRepository, userID, orderID); err != nil {
+		return models.PaymentDetails{}, err
+	}
+
+	order, err := repo.orderRepository.GetOrder(orderID)
 	if err != nil {
-		return models.CombinedOrderDetails{}, "", err
+		return models.PaymentDetails{}, err
 	}
-	body2, err := repo.orderRepository.GetDetailedOrderThroughId(int(order.ID))
+	method, err := repo.orderRepository.GetPaymentMethodByID(int(order.PaymentMethodID))
 	if err != nil {
-		return models.CombinedOrderDetails{}, "", err
+		return models.PaymentDetails{}, err
+	}
+	if method.Kind != domain.PaymentKindOnline {
+		return models.PaymentDetails{}, errors.New("order is not paid online")
 	}
-	fmt.Println("body 2 usecase", body2.OrderId)
 
-	return body2, razorPayOrderId, nil
+	return repo.startPaymentAttempt(order)
+}
+
+// startPaymentAttempt makes a gateway order for the order's total and records
+// it as a new attempt.
+func (repo *paymentUsecaseImpl) startPaymentAttempt(order domain.Order) (models.PaymentDetails, error) {
+	if order.OrderStatus == domain.OrderStatusCanceled {
+		return models.PaymentDetails{}, errors.New("order is canceled, the payment time may have expired")
+	}
+	if order.PaymentStatus != domain.PaymentStatusNotPaid {
+		return models.PaymentDetails{}, errors.New("order is already paid")
+	}
+
+	gatewayOrder, err := repo.gateway.CreateOrder(toPaise(order.FinalPrice), "order_"+strconv.Itoa(int(order.ID)))
+	if err != nil {
+		return models.PaymentDetails{}, err
+	}
+
+	id, err := repo.paymentRepo.AddRazorPayDetails(int(order.ID), gatewayOrder.ID, gatewayOrder.Amount)
+	if err != nil {
+		return models.PaymentDetails{}, err
+	}
+
+	return models.PaymentDetails{
+		ID:             id,
+		OrderID:        int(order.ID),
+		GatewayOrderID: gatewayOrder.ID,
+		Amount:         gatewayOrder.Amount,
+		Status:         domain.PaymentCreated,
+	}, nil
+}
+
+func (repo *paymentUsecaseImpl) GetPaymentAttempts(orderID int) ([]models.PaymentDetails, error) {
+	if orderID <= 0 {
+		return nil, errors.New("enter a valid order id")
+	}
+	return repo.paymentRepo.GetPaymentAttempts(orderID)
 }
 
 // ------------------------------------------------- verify payment razor pay ------------------------------------ \\
 
-func (repo *paymentUsecaseImpl) SavePaymentDetails(paymentId, razorId, orderId string) error {
+// SavePaymentDetails confirms a payment from the checkout callback. The
+// callback is only trusted when the gateway's signature matches and the
+// gateway reports the payment captured; authorized payments are captured
+// here. The gateway is asked before the transaction is opened, so rows are
+// never locked while waiting on it. The callback is not authenticated, so a
+// bad signature is refused without touching the attempt; a payment the
+// gateway failed is recorded as a failed attempt and a captured payment the
+// order cannot take is refunded.
+func (repo *paymentUsecaseImpl) SavePaymentDetails(paymentId, razorId, orderId, signature string) error {
+
+	orderID, err := strconv.Atoi(orderId)
+	if err != nil {
+		return errors.New("invalid order id")
+	}
+
+	payment, err := repo.paymentRepo.GetPayment(razorId)
+	if err != nil {
+		return err
+	}
+	if payment.ID == 0 || payment.OrderID != orderID {
+		return errors.New("payment does not belong to this order")
+	}
+	if payment.Status == domain.PaymentCaptured && payment.PaymentID == paymentId {
+		// the webhook confirmed it first
+		return nil
+	}
+
+	if !repo.gateway.VerifySignature(razorId, paymentId, signature) {
+		return errors.New("payment signature does not match")
+	}
 
-	status, err := repo.paymentRepo.GetPaymentStatus(orderId)
+	// the gateway has the last word on whether the money was taken
+	paid, err := repo.gateway.PaymentStatus(paymentId)
 	if err != nil {
 		return err
 	}
-	fmt.Println("status", status)
-	if !status {
-		err = repo.paymentRepo.UpdatePaymentDetails(razorId, paymentId)
+	if paid.OrderID != razorId {
+		return errors.New("payment does not belong to this order")
+	}
+	switch paid.Status {
+	case gateway.StatusCaptured:
+	case gateway.StatusAuthorized:
+		// an authorization the order cannot take is left to lapse
+		order, err := repo.orderRepository.GetOrder(orderID)
 		if err != nil {
 			return err
 		}
+		state := models.OrderState{OrderID: orderID, OrderStatus: order.OrderStatus, PaymentStatus: order.PaymentStatus, FinalPrice: order.FinalPrice}
+		if reason := unappliedReason(state, paid.Amount); reason != "" {
+			return errors.New(reason)
+		}
+		if _, err := repo.gateway.Capture(paymentId, paid.Amount); err != nil {
+			return err
+		}
+	case gateway.StatusFailed:
+		return repo.rejectPayment(razorId, paymentId, errors.New("payment failed: "+paid.FailureReason))
+	default:
+		return errors.New("payment is not complete yet")
+	}
 
-		err = repo.paymentRepo.UpdatePaymentStatus(true, orderId)
+	var unapplied error
+	err = repo.transaction.WithTransaction(func(repos usecase.TxRepositories) error {
+		payment, err := repos.Payment.LockPayment(razorId)
 		if err != nil {
 			return err
 		}
+		if payment.Status == domain.PaymentCaptured || payment.Status == domain.PaymentRefunded {
+			// the webhook got there first
+			return nil
+		}
+
+		state, err := repos.Order.LockOrderState(orderID)
+		if err != nil {
+			return err
+		}
+		if reason := unappliedReason(state, paid.Amount); reason != "" {
+			unapplied = errors.New(reason + ", the payment will be refunded")
+			return refundUnappliedPayment(repos, payment, paymentId, paid.Amount, reason)
+		}
+
+		if err := repos.Payment.UpdatePaymentDetails(razorId, paymentId); err != nil {
+			return err
+		}
+
+		return changeOrderState(repos.Order, state, orderStateChange{
+			PaymentStatus: domain.PaymentStatusPaid,
+			Actor:         domain.Actor{Role: domain.ActorSystem},
+			Reason:        "payment " + paymentId + " verified with razorpay",
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return unapplied
+}
+
+// rejectPayment records a failed attempt at paying for a razorpay order and
+// returns why it failed. An attempt already captured or refunded is left as
+// it is.
+func (repo *paymentUsecaseImpl) rejectPayment(razorId, paymentId string, reason error) error {
+	if err := repo.paymentRepo.MarkPaymentFailed(razorId, paymentId, reason.Error()); err != nil {
+		return err
+	}
+	return reason
+}
+
+// toPaise converts rupees to the paise razorpay amounts are given in.
+func toPaise(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// ------------------------------------------------- razorpay webhook ------------------------------------------- \\
+
+// HandleRazorpayWebhook applies an event razorpay posted to the webhook. The
+// body must be signed with the webhook secret. Razorpay delivers an event
+// more than once when it is not acknowledged, so events that were already
+// applied are accepted without changing anything.
+func (repo *paymentUsecaseImpl) HandleRazorpayWebhook(body []byte, signature string) error {
+	if !validWebhookSignature(repo.webhookSecret, body, signature) {
+		return domain.ErrInvalidSignature
+	}
+
+	var event models.RazorpayEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return errors.New("could not read the webhook event")
+	}
+
+	return repo.transaction.WithTransaction(func(repos usecase.TxRepositories) error {
+		switch event.Event {
+		case "payment.captured":
+			return paymentCaptured(repos, event.Payload.Payment.Entity)
+		case "payment.failed":
+			return paymentFailed(repos, event.Payload.Payment.Entity)
+		case "refund.processed":
+			return refundSettled(repos, event.Payload.Refund.Entity, domain.RefundProcessed)
+		case "refund.failed":
+			return refundSettled(repos, event.Payload.Refund.Entity, domain.RefundFailed)
+		}
+		// razorpay sends every event the webhook subscribes to
+		return nil
+	})
+}
+
+// validWebhookSignature checks the hex HMAC-SHA256 of the body razorpay sends
+// in X-Razorpay-Signature.
+func validWebhookSignature(secret, body []byte, signature string) bool {
+	if len(secret) == 0 {
+		return false
+	}
+
+	given, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(given, mac.Sum(nil))
+}
+
+// paymentCaptured marks the order paid, unless the payment callback or an
+// earlier delivery of the event got there first. A payment the order cannot
+// take is refunded.
+func paymentCaptured(repos usecase.TxRepositories, entity models.RazorpayPayment) error {
+	payment, err := repos.Payment.LockPayment(entity.OrderID)
+	if err != nil {
+		return err
+	}
+	if payment.ID == 0 {
+		return errors.New("no payment exists for razorpay order " + entity.OrderID)
+	}
+	if payment.Status == domain.PaymentCaptured || payment.Status == domain.PaymentRefunded {
+		return nil
+	}
+
+	state, err := repos.Order.LockOrderState(payment.OrderID)
+	if err != nil {
+		return err
+	}
+	if reason := unappliedReason(state, entity.Amount); reason != "" {
+		return refundUnappliedPayment(repos, payment, entity.ID, entity.Amount, reason)
+	}
+
+	if err := repos.Payment.MarkPaymentCaptured(entity.OrderID, entity.ID); err != nil {
+		return err
+	}
+
+	return changeOrderState(repos.Order, state, orderStateChange{
+		PaymentStatus: domain.PaymentStatusPaid,
+		Actor:         domain.Actor{Role: domain.ActorSystem},
+		Reason:        "payment " + entity.ID + " captured by razorpay",
+	})
+}
+
+// unappliedReason says why a payment of amount paise cannot pay for an order,
+// or returns "" when it can.
+func unappliedReason(state models.OrderState, amount int64) string {
+	switch {
+	case state.OrderStatus == domain.OrderStatusCanceled:
+		return "order was canceled before the payment was received"
+	case state.PaymentStatus != domain.PaymentStatusNotPaid:
+		return "order is already paid"
+	case amount != toPaise(state.FinalPrice):
+		return "amount paid does not match the order total"
+	}
+	return ""
+}
+
+// paymentFailed records why a payment failed. The order stays unpaid so the
+// user can pay again until it expires.
+func paymentFailed(repos usecase.TxRepositories, entity models.RazorpayPayment) error {
+	payment, err := repos.Payment.LockPayment(entity.OrderID)
+	if err != nil {
+		return err
+	}
+	if payment.ID == 0 {
+		return errors.New("no payment exists for razorpay order " + entity.OrderID)
+	}
+	if payment.Status == domain.PaymentCaptured || payment.Status == domain.PaymentRefunded {
+		return nil
+	}
+
+	return repos.Payment.MarkPaymentFailed(entity.OrderID, entity.ID, entity.ErrorDescription)
+}
+
+// refundSettled applies a refund outcome razorpay reported. Refunds sent by
+// SendPendingRefunds are found by their gateway id, or by their receipt when
+// the webhook overtook the recording of that id. A processed refund the store
+// never asked for, made from the razorpay dashboard, is only recorded.
+func refundSettled(repos usecase.TxRepositories, entity models.RazorpayRefund, status string) error {
+	refund, err := repos.Refund.LockGatewayRefund(entity.ID)
+	if err != nil {
+		return err
+	}
+	if refund.ID == 0 {
+		if id, ok := refundReceiptID(entity.Receipt); ok {
+			if refund, err = repos.Refund.LockRefund(id); err != nil {
+				return err
+			}
+			if refund.ID != 0 && refund.GatewayRefundID == "" {
+				if err := repos.Refund.SetGatewayRefund(refund.ID, entity.ID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if refund.ID != 0 {
+		reason := ""
+		if status == domain.RefundFailed {
+			reason = "razorpay could not process the refund"
+		}
+		return settleRefund(repos, refund, status, reason)
+	}
+	if status != domain.RefundProcessed {
+		return nil
+	}
+
+	payment, err := repos.Payment.GetPaymentByPaymentID(entity.PaymentID)
+	if err != nil {
+		return err
+	}
+	if payment.ID == 0 {
 		return nil
 	}
-	return errors.New("already paid")
+	_, err = repos.Refund.AddRefund(models.RefundDetails{
+		OrderID:         payment.OrderID,
+		Method:          domain.RefundToOriginal,
+		Amount:          float64(entity.Amount) / 100,
+		PaymentID:       entity.PaymentID,
+		GatewayRefundID: entity.ID,
+		Status:          domain.RefundProcessed,
+		Reason:          "refunded from the razorpay dashboard",
+	})
+	return err
+}
+
+const (
+	refundBatchSize   = 50
+	maxRefundAttempts = 5
+)
+
+// SendPendingRefunds sends refunds to the original payment method to the
+// gateway. A refund the gateway keeps rejecting is failed after
+// maxRefundAttempts tries and credited to the wallet. A refund that cannot be
+// sent is logged and left for the next run. It returns how many refunds were
+// sent.
+func (repo *paymentUsecaseImpl) SendPendingRefunds() (int, error) {
+	ids, err := repo.refundRepo.GetPendingGatewayRefunds(refundBatchSize)
+	if err != nil {
+		return 0, err
+	}
 
-}
\ No newline at end of file
+	sent := 0
+	for _, id := range ids {
+		ok, err := repo.sendRefund(id)
+		if err != nil {
+			log.Printf("refund %d: %v", id, err)
+		}
+		if ok {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// sendRefund sends one refund to the gateway. The refund is claimed as SENDING
+// before the gateway is asked, so a second server cannot send it as well and
+// no transaction is held open across the call. It reports whether the gateway
+// refund was recorded.
+func (repo *paymentUsecaseImpl) sendRefund(id int) (bool, error) {
+	refund, err := repo.refundRepo.ClaimRefund(id)
+	if err != nil || refund.ID == 0 {
+		return false, err
+	}
+
+	result, err := repo.gateway.Refund(refund.PaymentID, toPaise(refund.Amount), refundReceipt(refund.ID))
+	if err != nil {
+		reason := err.Error()
+		return false, repo.transaction.WithTransaction(func(repos usecase.TxRepositories) error {
+			refund, err := repos.Refund.LockRefund(id)
+			if err != nil {
+				return err
+			}
+			if refund.Status != domain.RefundSending {
+				return nil
+			}
+			if err := repos.Refund.AddRefundAttempt(refund.ID, reason); err != nil {
+				return err
+			}
+			if refund.Attempts+1 < maxRefundAttempts {
+				return repos.Refund.UpdateRefundStatus(refund.ID, domain.RefundInitiated, reason)
+			}
+			return settleRefund(repos, refund, domain.RefundFailed, reason)
+		})
+	}
+
+	// the gateway refund is recorded before anything else, so it is never
+	// sent twice even if settling it fails; a refund left SENDING here is
+	// settled by the webhook through its receipt
+	if err := repo.refundRepo.SetGatewayRefund(refund.ID, result.ID); err != nil {
+		return false, err
+	}
+	if result.Status != gateway.RefundProcessed {
+		return true, nil
+	}
+
+	return true, repo.transaction.WithTransaction(func(repos usecase.TxRepositories) error {
+		refund, err := repos.Refund.LockRefund(id)
+		if err != nil {
+			return err
+		}
+		return settleRefund(repos, refund, domain.RefundProcessed, "")
+	})
+}
+
+// refundReceipt is the reference a refund is sent to the gateway with.
+func refundReceipt(refundID int) string {
+	return "refund_" + strconv.Itoa(refundID)
+}
+
+func refundReceiptID(receipt string) (int, bool) {
+	id, err := strconv.Atoi(strings.TrimPrefix(receipt, "refund_"))
+	if err != nil || !strings.HasPrefix(receipt, "refund_") {
+		return 0, false
+	}
+	return id, true
+}
+
+func (repo *paymentUsecaseImpl) GetOrderRefunds(orderID int) ([]models.RefundDetails, error) {
+	if orderID <= 0 {
+		return nil, errors.New("enter a valid order id")
+	}
+	return repo.refundRepo.GetOrderRefunds(orderID)
+}
+
+func (repo *paymentUsecaseImpl) GetUserRefunds(userID, orderID int) ([]models.RefundDetails, error) {
+	if err := authorizeOrder(repo.orderRepository, userID, orderID); err != nil {
+		return nil, err
+	}
+	return repo.refundRepo.GetOrderRefunds(orderID)
+}
@@ -0,0 +1,602 @@
+package usecase
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ahdaan98/pkg/config"
+	"github.com/ahdaan98/pkg/domain"
+	"github.com/ahdaan98/pkg/gateway"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	repo_mocks "github.com/ahdaan98/pkg/repository/mocks"
+	"github.com/ahdaan98/pkg/utils/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+const testWebhookSecret = "whsec_test"
+
+type paymentTestMocks struct {
+	payment     *repo_mocks.MockPaymentRepository
+	order       *repo_mocks.MockOrderRepository
+	refund      *repo_mocks.MockRefundRepository
+	wallet      *repo_mocks.MockWalletRepository
+	transaction *repo_mocks.MockTransactionRepository
+}
+
+func newPaymentTestMocks(ctrl *gomock.Controller) paymentTestMocks {
+	m := paymentTestMocks{
+		payment: repo_mocks.NewMockPaymentRepository(ctrl),
+		order:   repo_mocks.NewMockOrderRepository(ctrl),
+		refund:  repo_mocks.NewMockRefundRepository(ctrl),
+		wallet:  repo_mocks.NewMockWalletRepository(ctrl),
+	}
+
+	// the transaction hands the same mocks back as its bound repositories
+	m.transaction = newTestTransaction(ctrl, interfaces.TxRepositories{Order: m.order, Payment: m.payment, Refund: m.refund, Wallet: m.wallet})
+
+	return m
+}
+
+// razorpayFixture reads a webhook payload recorded from razorpay and signs it
+// the way razorpay does.
+func razorpayFixture(t *testing.T, name string) ([]byte, string) {
+	body, err := os.ReadFile(filepath.Join("testdata", "razorpay", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(testWebhookSecret))
+	mac.Write(body)
+	return body, hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestHandleRazorpayWebhook(t *testing.T) {
+	unpaid := models.OrderState{OrderID: 5, UserID: 1, OrderStatus: domain.OrderStatusPending, PaymentStatus: domain.PaymentStatusNotPaid, FinalPrice: 500}
+
+	tests := []struct {
+		name    string
+		fixture string
+		tamper  func(body []byte, signature string) ([]byte, string)
+		stub    func(m paymentTestMocks)
+		wantErr error
+	}{
+		{
+			name:    "captured payment marks the order paid",
+			fixture: "payment_captured.json",
+			stub: func(m paymentTestMocks) {
+				m.payment.EXPECT().LockPayment("order_DESlLckIVRkHWj").Return(models.PaymentDetails{ID: 3, OrderID: 5, Status: domain.PaymentCreated}, nil)
+				m.payment.EXPECT().MarkPaymentCaptured("order_DESlLckIVRkHWj", "pay_DESlfW9H8K9uqM").Return(nil)
+				m.order.EXPECT().LockOrderState(5).Return(unpaid, nil)
+				m.order.EXPECT().UpdateOrderState(5, domain.OrderStatusPending, domain.PaymentStatusPaid).Return(nil)
+				m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "captured payment delivered again",
+			fixture: "payment_captured.json",
+			stub: func(m paymentTestMocks) {
+				m.payment.EXPECT().LockPayment("order_DESlLckIVRkHWj").Return(models.PaymentDetails{ID: 3, OrderID: 5, Status: domain.PaymentCaptured}, nil)
+			},
+		},
+		{
+			name:    "payment on an order paid with another attempt is refunded",
+			fixture: "payment_captured.json",
+			stub: func(m paymentTestMocks) {
+				paid := unpaid
+				paid.PaymentStatus = domain.PaymentStatusPaid
+				m.payment.EXPECT().LockPayment("order_DESlLckIVRkHWj").Return(models.PaymentDetails{ID: 3, OrderID: 5, GatewayOrderID: "order_DESlLckIVRkHWj", Status: domain.PaymentCreated}, nil)
+				m.order.EXPECT().LockOrderState(5).Return(paid, nil)
+				m.payment.EXPECT().MarkPaymentRefunded("order_DESlLckIVRkHWj", "pay_DESlfW9H8K9uqM", "order is already paid").Return(nil)
+				m.refund.EXPECT().AddRefund(models.RefundDetails{OrderID: 5, Method: domain.RefundToOriginal, Amount: 500, PaymentID: "pay_DESlfW9H8K9uqM", Status: domain.RefundInitiated, Reason: "order is already paid", Unapplied: true}).Return(12, nil)
+			},
+		},
+		{
+			name:    "payment on a canceled order is refunded",
+			fixture: "payment_captured.json",
+			stub: func(m paymentTestMocks) {
+				expired := unpaid
+				expired.OrderStatus = domain.OrderStatusCanceled
+				m.payment.EXPECT().LockPayment("order_DESlLckIVRkHWj").Return(models.PaymentDetails{ID: 3, OrderID: 5, GatewayOrderID: "order_DESlLckIVRkHWj", Status: domain.PaymentCreated}, nil)
+				m.order.EXPECT().LockOrderState(5).Return(expired, nil)
+				m.payment.EXPECT().MarkPaymentRefunded("order_DESlLckIVRkHWj", "pay_DESlfW9H8K9uqM", "order was canceled before the payment was received").Return(nil)
+				m.refund.EXPECT().AddRefund(models.RefundDetails{OrderID: 5, Method: domain.RefundToOriginal, Amount: 500, PaymentID: "pay_DESlfW9H8K9uqM", Status: domain.RefundInitiated, Reason: "order was canceled before the payment was received", Unapplied: true}).Return(12, nil)
+			},
+		},
+		{
+			name:    "refunded payment delivered again",
+			fixture: "payment_captured.json",
+			stub: func(m paymentTestMocks) {
+				m.payment.EXPECT().LockPayment("order_DESlLckIVRkHWj").Return(models.PaymentDetails{ID: 3, OrderID: 5, Status: domain.PaymentRefunded}, nil)
+			},
+		},
+		{
+			name:    "failed payment is recorded with its reason",
+			fixture: "payment_failed.json",
+			stub: func(m paymentTestMocks) {
+				m.payment.EXPECT().LockPayment("order_DESlLckIVRkHWj").Return(models.PaymentDetails{ID: 3, OrderID: 5, Status: domain.PaymentCreated}, nil)
+				m.payment.EXPECT().MarkPaymentFailed("order_DESlLckIVRkHWj", "pay_DESmSCLkw2dqGv", "Payment failed because the card was declined").Return(nil)
+			},
+		},
+		{
+			name:    "failure reported after the capture is ignored",
+			fixture: "payment_failed.json",
+			stub: func(m paymentTestMocks) {
+				m.payment.EXPECT().LockPayment("order_DESlLckIVRkHWj").Return(models.PaymentDetails{ID: 3, OrderID: 5, Status: domain.PaymentCaptured}, nil)
+			},
+		},
+		{
+			name:    "processed refund settles the order",
+			fixture: "refund_processed.json",
+			stub: func(m paymentTestMocks) {
+				returned := models.OrderState{OrderID: 5, UserID: 1, OrderStatus: domain.OrderStatusCanceled, PaymentStatus: domain.PaymentStatusRefundInProgress, FinalPrice: 250}
+				m.refund.EXPECT().LockGatewayRefund("rfnd_DGH7sZlUMRJlYx").Return(models.RefundDetails{ID: 12, OrderID: 5, Method: domain.RefundToOriginal, Amount: 250, GatewayRefundID: "rfnd_DGH7sZlUMRJlYx", Status: domain.RefundInitiated}, nil)
+				m.refund.EXPECT().UpdateRefundStatus(12, domain.RefundProcessed, "").Return(nil)
+				m.order.EXPECT().LockOrderState(5).Return(returned, nil)
+				m.refund.EXPECT().CountPendingRefunds(5).Return(0, nil)
+				m.order.EXPECT().UpdateOrderState(5, domain.OrderStatusCanceled, domain.PaymentStatusRefunded).Return(nil)
+				m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "processed refund found by its receipt",
+			fixture: "refund_processed.json",
+			stub: func(m paymentTestMocks) {
+				// already settled when the gateway answered the refund request
+				m.refund.EXPECT().LockGatewayRefund("rfnd_DGH7sZlUMRJlYx").Return(models.RefundDetails{}, nil)
+				m.refund.EXPECT().LockRefund(12).Return(models.RefundDetails{ID: 12, OrderID: 5, Status: domain.RefundProcessed}, nil)
+				m.refund.EXPECT().SetGatewayRefund(12, "rfnd_DGH7sZlUMRJlYx").Return(nil)
+			},
+		},
+		{
+			name:    "processed unapplied refund leaves the order alone",
+			fixture: "refund_processed.json",
+			stub: func(m paymentTestMocks) {
+				expired := models.OrderState{OrderID: 5, UserID: 1, OrderStatus: domain.OrderStatusCanceled, PaymentStatus: domain.PaymentStatusNotPaid, FinalPrice: 250}
+				m.refund.EXPECT().LockGatewayRefund("rfnd_DGH7sZlUMRJlYx").Return(models.RefundDetails{ID: 12, OrderID: 5, Method: domain.RefundToOriginal, Amount: 250, GatewayRefundID: "rfnd_DGH7sZlUMRJlYx", Status: domain.RefundInitiated, Unapplied: true}, nil)
+				m.refund.EXPECT().UpdateRefundStatus(12, domain.RefundProcessed, "").Return(nil)
+				m.order.EXPECT().LockOrderState(5).Return(expired, nil)
+			},
+		},
+		{
+			name:    "failed refund is credited to the wallet",
+			fixture: "refund_failed.json",
+			stub: func(m paymentTestMocks) {
+				partial := models.OrderState{OrderID: 5, UserID: 1, OrderStatus: domain.OrderStatusDelivered, PaymentStatus: domain.PaymentStatusRefundInProgress, FinalPrice: 800}
+				m.refund.EXPECT().LockGatewayRefund("rfnd_DGH7sZlUMRJlYx").Return(models.RefundDetails{ID: 12, OrderID: 5, Method: domain.RefundToOriginal, Amount: 250, GatewayRefundID: "rfnd_DGH7sZlUMRJlYx", Status: domain.RefundInitiated}, nil)
+				m.refund.EXPECT().UpdateRefundStatus(12, domain.RefundFailed, "razorpay could not process the refund").Return(nil)
+				m.order.EXPECT().LockOrderState(5).Return(partial, nil)
+				m.wallet.EXPECT().AddToWallet(250.0, 1).Return(models.WalletAmount{}, nil)
+				m.refund.EXPECT().AddRefund(models.RefundDetails{OrderID: 5, Method: domain.RefundToWallet, Amount: 250, Status: domain.RefundProcessed, Reason: "refund 12 failed at the gateway"}).Return(13, nil)
+				m.refund.EXPECT().CountPendingRefunds(5).Return(0, nil)
+				m.order.EXPECT().UpdateOrderState(5, domain.OrderStatusDelivered, domain.PaymentStatusPartiallyRefunded).Return(nil)
+				m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "refund made from the razorpay dashboard is recorded",
+			fixture: "refund_processed.json",
+			stub: func(m paymentTestMocks) {
+				m.refund.EXPECT().LockGatewayRefund("rfnd_DGH7sZlUMRJlYx").Return(models.RefundDetails{}, nil)
+				m.refund.EXPECT().LockRefund(12).Return(models.RefundDetails{}, nil)
+				m.payment.EXPECT().GetPaymentByPaymentID("pay_DESlfW9H8K9uqM").Return(models.PaymentDetails{ID: 3, OrderID: 5}, nil)
+				m.refund.EXPECT().AddRefund(models.RefundDetails{OrderID: 5, Method: domain.RefundToOriginal, Amount: 250, PaymentID: "pay_DESlfW9H8K9uqM", GatewayRefundID: "rfnd_DGH7sZlUMRJlYx", Status: domain.RefundProcessed, Reason: "refunded from the razorpay dashboard"}).Return(14, nil)
+			},
+		},
+		{
+			name:    "unknown razorpay order",
+			fixture: "payment_captured.json",
+			stub: func(m paymentTestMocks) {
+				m.payment.EXPECT().LockPayment("order_DESlLckIVRkHWj").Return(models.PaymentDetails{}, nil)
+			},
+			wantErr: errors.New("no payment exists for razorpay order order_DESlLckIVRkHWj"),
+		},
+		{
+			name:    "body changed after signing",
+			fixture: "payment_captured.json",
+			tamper: func(body []byte, signature string) ([]byte, string) {
+				return bytes.Replace(body, []byte("50000"), []byte("100"), 1), signature
+			},
+			stub:    func(m paymentTestMocks) {},
+			wantErr: domain.ErrInvalidSignature,
+		},
+		{
+			name:    "signed with another secret",
+			fixture: "payment_captured.json",
+			tamper: func(body []byte, signature string) ([]byte, string) {
+				mac := hmac.New(sha256.New, []byte("someone else"))
+				mac.Write(body)
+				return body, hex.EncodeToString(mac.Sum(nil))
+			},
+			stub:    func(m paymentTestMocks) {},
+			wantErr: domain.ErrInvalidSignature,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newPaymentTestMocks(ctrl)
+			tc.stub(m)
+
+			body, signature := razorpayFixture(t, tc.fixture)
+			if tc.tamper != nil {
+				body, signature = tc.tamper(body, signature)
+			}
+
+			uc := NewPaymentUseCase(m.order, m.payment, m.refund, m.transaction, gateway.NewFake(gateway.FakeSuccess, 0), config.Config{RAZORPAY_WEBHOOK_SECRET: testWebhookSecret})
+			err := uc.HandleRazorpayWebhook(body, signature)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestSavePaymentDetails(t *testing.T) {
+	unpaid := models.OrderState{OrderID: 5, UserID: 1, OrderStatus: domain.OrderStatusPending, PaymentStatus: domain.PaymentStatusNotPaid, FinalPrice: 499.5}
+	canceled := unpaid
+	canceled.OrderStatus = domain.OrderStatusCanceled
+
+	tests := []struct {
+		name    string
+		mode    string
+		orderID string
+		// forge replaces the signature the checkout handed back
+		forge   bool
+		stub    func(m paymentTestMocks, created models.PaymentDetails, paymentId string)
+		wantErr error
+		// wantStatus is where the payment ends up at the gateway
+		wantStatus string
+	}{
+		{
+			name:    "signed callback for the order total",
+			mode:    gateway.FakeSuccess,
+			orderID: "5",
+			stub: func(m paymentTestMocks, created models.PaymentDetails, paymentId string) {
+				m.payment.EXPECT().GetPayment(created.GatewayOrderID).Return(created, nil)
+				m.payment.EXPECT().LockPayment(created.GatewayOrderID).Return(created, nil)
+				m.order.EXPECT().LockOrderState(5).Return(unpaid, nil)
+				m.payment.EXPECT().UpdatePaymentDetails(created.GatewayOrderID, paymentId).Return(nil)
+				m.order.EXPECT().UpdateOrderState(5, domain.OrderStatusPending, domain.PaymentStatusPaid).Return(nil)
+				m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+			},
+			wantStatus: gateway.StatusCaptured,
+		},
+		{
+			name:    "authorized payment is captured",
+			mode:    gateway.FakeDelayed,
+			orderID: "5",
+			stub: func(m paymentTestMocks, created models.PaymentDetails, paymentId string) {
+				m.payment.EXPECT().GetPayment(created.GatewayOrderID).Return(created, nil)
+				m.order.EXPECT().GetOrder(5).Return(domain.Order{Model: gorm.Model{ID: 5}, OrderStatus: domain.OrderStatusPending, PaymentStatus: domain.PaymentStatusNotPaid, FinalPrice: 499.5}, nil)
+				m.payment.EXPECT().LockPayment(created.GatewayOrderID).Return(created, nil)
+				m.order.EXPECT().LockOrderState(5).Return(unpaid, nil)
+				m.payment.EXPECT().UpdatePaymentDetails(created.GatewayOrderID, paymentId).Return(nil)
+				m.order.EXPECT().UpdateOrderState(5, domain.OrderStatusPending, domain.PaymentStatusPaid).Return(nil)
+				m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+			},
+			wantStatus: gateway.StatusCaptured,
+		},
+		{
+			name:    "authorized payment for a canceled order is not captured",
+			mode:    gateway.FakeDelayed,
+			orderID: "5",
+			stub: func(m paymentTestMocks, created models.PaymentDetails, paymentId string) {
+				m.payment.EXPECT().GetPayment(created.GatewayOrderID).Return(created, nil)
+				m.order.EXPECT().GetOrder(5).Return(domain.Order{Model: gorm.Model{ID: 5}, OrderStatus: domain.OrderStatusCanceled, PaymentStatus: domain.PaymentStatusNotPaid, FinalPrice: 499.5}, nil)
+			},
+			wantErr:    errors.New("order was canceled before the payment was received"),
+			wantStatus: gateway.StatusAuthorized,
+		},
+		{
+			name:    "payment failed at the gateway",
+			mode:    gateway.FakeFailure,
+			orderID: "5",
+			stub: func(m paymentTestMocks, created models.PaymentDetails, paymentId string) {
+				m.payment.EXPECT().GetPayment(created.GatewayOrderID).Return(created, nil)
+				m.payment.EXPECT().MarkPaymentFailed(created.GatewayOrderID, paymentId, "payment failed: payment declined by the fake gateway").Return(nil)
+			},
+			wantErr: errors.New("payment failed: payment declined by the fake gateway"),
+		},
+		{
+			name:    "webhook confirmed the payment first",
+			mode:    gateway.FakeSuccess,
+			orderID: "5",
+			stub: func(m paymentTestMocks, created models.PaymentDetails, paymentId string) {
+				created.Status = domain.PaymentCaptured
+				created.PaymentID = paymentId
+				m.payment.EXPECT().GetPayment(created.GatewayOrderID).Return(created, nil)
+			},
+		},
+		{
+			name:    "webhook confirmed the payment while the gateway was asked",
+			mode:    gateway.FakeSuccess,
+			orderID: "5",
+			stub: func(m paymentTestMocks, created models.PaymentDetails, paymentId string) {
+				m.payment.EXPECT().GetPayment(created.GatewayOrderID).Return(created, nil)
+				created.Status = domain.PaymentCaptured
+				created.PaymentID = paymentId
+				m.payment.EXPECT().LockPayment(created.GatewayOrderID).Return(created, nil)
+			},
+		},
+		{
+			name:    "forged signature is refused without touching the attempt",
+			mode:    gateway.FakeSuccess,
+			orderID: "5",
+			forge:   true,
+			stub: func(m paymentTestMocks, created models.PaymentDetails, paymentId string) {
+				m.payment.EXPECT().GetPayment(created.GatewayOrderID).Return(created, nil)
+			},
+			wantErr: errors.New("payment signature does not match"),
+		},
+		{
+			name:    "forged callback for a captured payment leaves it captured",
+			mode:    gateway.FakeSuccess,
+			orderID: "5",
+			forge:   true,
+			stub: func(m paymentTestMocks, created models.PaymentDetails, paymentId string) {
+				created.Status = domain.PaymentCaptured
+				created.PaymentID = "pay_paid"
+				m.payment.EXPECT().GetPayment(created.GatewayOrderID).Return(created, nil)
+			},
+			wantErr: errors.New("payment signature does not match"),
+		},
+		{
+			name:    "captured payment for a canceled order is refunded",
+			mode:    gateway.FakeSuccess,
+			orderID: "5",
+			stub: func(m paymentTestMocks, created models.PaymentDetails, paymentId string) {
+				m.payment.EXPECT().GetPayment(created.GatewayOrderID).Return(created, nil)
+				m.payment.EXPECT().LockPayment(created.GatewayOrderID).Return(created, nil)
+				m.order.EXPECT().LockOrderState(5).Return(canceled, nil)
+				m.payment.EXPECT().MarkPaymentRefunded(created.GatewayOrderID, paymentId, "order was canceled before the payment was received").Return(nil)
+				m.refund.EXPECT().AddRefund(models.RefundDetails{OrderID: 5, Method: domain.RefundToOriginal, Amount: 499.5, PaymentID: paymentId, Status: domain.RefundInitiated, Reason: "order was canceled before the payment was received", Unapplied: true}).Return(12, nil)
+			},
+			wantErr:    errors.New("order was canceled before the payment was received, the payment will be refunded"),
+			wantStatus: gateway.StatusCaptured,
+		},
+		{
+			name:    "amount differs from the order total",
+			mode:    gateway.FakeSuccess,
+			orderID: "5",
+			stub: func(m paymentTestMocks, created models.PaymentDetails, paymentId string) {
+				state := unpaid
+				state.FinalPrice = 899.5
+				m.payment.EXPECT().GetPayment(created.GatewayOrderID).Return(created, nil)
+				m.payment.EXPECT().LockPayment(created.GatewayOrderID).Return(created, nil)
+				m.order.EXPECT().LockOrderState(5).Return(state, nil)
+				m.payment.EXPECT().MarkPaymentRefunded(created.GatewayOrderID, paymentId, "amount paid does not match the order total").Return(nil)
+				m.refund.EXPECT().AddRefund(gomock.Any()).Return(12, nil)
+			},
+			wantErr: errors.New("amount paid does not match the order total, the payment will be refunded"),
+		},
+		{
+			name:    "gateway order of another order",
+			mode:    gateway.FakeSuccess,
+			orderID: "6",
+			stub: func(m paymentTestMocks, created models.PaymentDetails, paymentId string) {
+				m.payment.EXPECT().GetPayment(created.GatewayOrderID).Return(created, nil)
+			},
+			wantErr: errors.New("payment does not belong to this order"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newPaymentTestMocks(ctrl)
+
+			// the customer pays through the fake checkout
+			fake := gateway.NewFake(tc.mode, time.Hour)
+			order, err := fake.CreateOrder(49950, "order_5")
+			assert.NoError(t, err)
+			paid, signature, err := fake.Pay(order.ID)
+			assert.NoError(t, err)
+			if tc.forge {
+				_, signature, _ = fake.Pay(order.ID)
+			}
+
+			created := models.PaymentDetails{ID: 3, OrderID: 5, GatewayOrderID: order.ID, Amount: 49950, Status: domain.PaymentCreated}
+			tc.stub(m, created, paid.ID)
+
+			uc := NewPaymentUseCase(m.order, m.payment, m.refund, m.transaction, fake, config.Config{})
+			err = uc.SavePaymentDetails(paid.ID, order.ID, tc.orderID, signature)
+			assert.Equal(t, tc.wantErr, err)
+			if tc.wantStatus != "" {
+				status, _ := fake.PaymentStatus(paid.ID)
+				assert.Equal(t, tc.wantStatus, status.Status)
+			}
+		})
+	}
+}
+
+func TestSendPendingRefunds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newPaymentTestMocks(ctrl)
+	fake := gateway.NewFake(gateway.FakeSuccess, 0)
+	order, _ := fake.CreateOrder(90000, "order_5")
+	paid, _, _ := fake.Pay(order.ID)
+	other, _ := fake.CreateOrder(20000, "order_8")
+	otherPaid, _, _ := fake.Pay(other.ID)
+
+	m.refund.EXPECT().GetPendingGatewayRefunds(refundBatchSize).Return([]int{11, 12, 13, 14, 16, 17}, nil)
+
+	// another server claimed it first
+	m.refund.EXPECT().ClaimRefund(11).Return(models.RefundDetails{}, nil)
+
+	// the gateway refunds at once, which settles the order
+	sending := models.RefundDetails{ID: 12, OrderID: 5, Method: domain.RefundToOriginal, Amount: 900, PaymentID: paid.ID, Status: domain.RefundSending}
+	m.refund.EXPECT().ClaimRefund(12).Return(sending, nil)
+	m.refund.EXPECT().SetGatewayRefund(12, gomock.Any()).Return(nil)
+	m.refund.EXPECT().LockRefund(12).Return(sending, nil)
+	m.refund.EXPECT().UpdateRefundStatus(12, domain.RefundProcessed, "").Return(nil)
+	m.order.EXPECT().LockOrderState(5).Return(models.OrderState{OrderID: 5, UserID: 1, OrderStatus: domain.OrderStatusCanceled, PaymentStatus: domain.PaymentStatusRefundInProgress, FinalPrice: 900}, nil)
+	m.refund.EXPECT().CountPendingRefunds(5).Return(0, nil)
+	m.order.EXPECT().UpdateOrderState(5, domain.OrderStatusCanceled, domain.PaymentStatusRefunded).Return(nil)
+	m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+
+	// a rejected refund is released and tried again on the next run
+	rejected := models.RefundDetails{ID: 13, OrderID: 6, Method: domain.RefundToOriginal, Amount: 100, PaymentID: "pay_unknown", Status: domain.RefundSending}
+	m.refund.EXPECT().ClaimRefund(13).Return(rejected, nil)
+	m.refund.EXPECT().LockRefund(13).Return(rejected, nil)
+	m.refund.EXPECT().AddRefundAttempt(13, "payment does not exist").Return(nil)
+	m.refund.EXPECT().UpdateRefundStatus(13, domain.RefundInitiated, "payment does not exist").Return(nil)
+
+	// until it has used up its attempts and goes to the wallet instead
+	exhausted := models.RefundDetails{ID: 14, OrderID: 7, Method: domain.RefundToOriginal, Amount: 100, PaymentID: "pay_unknown", Status: domain.RefundSending, Attempts: maxRefundAttempts - 1}
+	m.refund.EXPECT().ClaimRefund(14).Return(exhausted, nil)
+	m.refund.EXPECT().LockRefund(14).Return(exhausted, nil)
+	m.refund.EXPECT().AddRefundAttempt(14, "payment does not exist").Return(nil)
+	m.refund.EXPECT().UpdateRefundStatus(14, domain.RefundFailed, "payment does not exist").Return(nil)
+	m.order.EXPECT().LockOrderState(7).Return(models.OrderState{OrderID: 7, UserID: 2, OrderStatus: domain.OrderStatusReturned, PaymentStatus: domain.PaymentStatusRefundInProgress, FinalPrice: 100}, nil)
+	m.wallet.EXPECT().AddToWallet(100.0, 2).Return(models.WalletAmount{}, nil)
+	m.refund.EXPECT().AddRefund(gomock.Any()).Return(15, nil)
+	m.refund.EXPECT().CountPendingRefunds(7).Return(0, nil)
+	m.order.EXPECT().UpdateOrderState(7, domain.OrderStatusReturned, domain.PaymentStatusReturnedToWallet).Return(nil)
+	m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+
+	// a refund that cannot be claimed is skipped without stopping the run
+	m.refund.EXPECT().ClaimRefund(16).Return(models.RefundDetails{}, errors.New("connection reset"))
+
+	// nor is a refund the gateway made but that could not be recorded counted
+	// as sent; it stays SENDING for the webhook to settle
+	m.refund.EXPECT().ClaimRefund(17).Return(models.RefundDetails{ID: 17, OrderID: 8, Method: domain.RefundToOriginal, Amount: 200, PaymentID: otherPaid.ID, Status: domain.RefundSending}, nil)
+	m.refund.EXPECT().SetGatewayRefund(17, gomock.Any()).Return(errors.New("connection reset"))
+
+	uc := NewPaymentUseCase(m.order, m.payment, m.refund, m.transaction, fake, config.Config{})
+	sent, err := uc.SendPendingRefunds()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	status, _ := fake.PaymentStatus(paid.ID)
+	assert.Equal(t, gateway.StatusRefunded, status.Status)
+}
+
+func TestRetryPayment(t *testing.T) {
+	unpaid := domain.Order{Model: gorm.Model{ID: 5}, UserID: 1, PaymentMethodID: 2, FinalPrice: 499.5, OrderStatus: domain.OrderStatusPending, PaymentStatus: domain.PaymentStatusNotPaid}
+
+	tests := []struct {
+		name    string
+		userID  int
+		stub    func(m paymentTestMocks)
+		want    models.PaymentDetails
+		wantErr error
+	}{
+		{
+			name:   "unpaid order gets a fresh attempt",
+			userID: 1,
+			stub: func(m paymentTestMocks) {
+				m.order.EXPECT().FindUserID(5).Return(1, nil)
+				m.order.EXPECT().GetOrder(5).Return(unpaid, nil)
+				m.order.EXPECT().GetPaymentMethodByID(2).Return(models.PaymentMethodResponse{ID: 2, Payment_Name: "RazorPay", Kind: domain.PaymentKindOnline}, nil)
+				m.payment.EXPECT().AddRazorPayDetails(5, gomock.Any(), int64(49950)).Return(4, nil)
+			},
+			want: models.PaymentDetails{ID: 4, OrderID: 5, Amount: 49950, Status: domain.PaymentCreated},
+		},
+		{
+			name:   "order already paid",
+			userID: 1,
+			stub: func(m paymentTestMocks) {
+				paid := unpaid
+				paid.PaymentStatus = domain.PaymentStatusPaid
+				m.order.EXPECT().FindUserID(5).Return(1, nil)
+				m.order.EXPECT().GetOrder(5).Return(paid, nil)
+				m.order.EXPECT().GetPaymentMethodByID(2).Return(models.PaymentMethodResponse{ID: 2, Payment_Name: "RazorPay", Kind: domain.PaymentKindOnline}, nil)
+			},
+			wantErr: errors.New("order is already paid"),
+		},
+		{
+			name:   "order paid in cash",
+			userID: 1,
+			stub: func(m paymentTestMocks) {
+				m.order.EXPECT().FindUserID(5).Return(1, nil)
+				m.order.EXPECT().GetOrder(5).Return(unpaid, nil)
+				m.order.EXPECT().GetPaymentMethodByID(2).Return(models.PaymentMethodResponse{ID: 2, Payment_Name: "Cash on Delivery", Kind: domain.PaymentKindCOD}, nil)
+			},
+			wantErr: errors.New("order is not paid online"),
+		},
+		{
+			name:   "order of another user",
+			userID: 2,
+			stub: func(m paymentTestMocks) {
+				m.order.EXPECT().FindUserID(5).Return(1, nil)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newPaymentTestMocks(ctrl)
+			tc.stub(m)
+
+			uc := NewPaymentUseCase(m.order, m.payment, m.refund, m.transaction, gateway.NewFake(gateway.FakeSuccess, 0), config.Config{})
+			got, err := uc.RetryPayment(tc.userID, 5)
+			assert.Equal(t, tc.wantErr, err)
+			if tc.wantErr == nil {
+				assert.NotEmpty(t, got.GatewayOrderID)
+				got.GatewayOrderID = ""
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSecondCapturedAttemptIsRefunded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newPaymentTestMocks(ctrl)
+	fake := gateway.NewFake(gateway.FakeSuccess, 0)
+
+	// the customer retried the payment and then paid both attempts
+	first, _ := fake.CreateOrder(49950, "order_5")
+	second, _ := fake.CreateOrder(49950, "order_5")
+	firstPaid, _, _ := fake.Pay(first.ID)
+	secondPaid, signature, _ := fake.Pay(second.ID)
+
+	attempt := models.PaymentDetails{ID: 4, OrderID: 5, GatewayOrderID: second.ID, Amount: 49950, Status: domain.PaymentCreated}
+	paid := models.OrderState{OrderID: 5, UserID: 1, OrderStatus: domain.OrderStatusPending, PaymentStatus: domain.PaymentStatusPaid, FinalPrice: 499.5}
+	refund := models.RefundDetails{ID: 12, OrderID: 5, Method: domain.RefundToOriginal, Amount: 499.5, PaymentID: secondPaid.ID, Status: domain.RefundSending, Reason: "order is already paid", Unapplied: true}
+
+	m.payment.EXPECT().GetPayment(second.ID).Return(attempt, nil)
+	m.payment.EXPECT().LockPayment(second.ID).Return(attempt, nil)
+	m.order.EXPECT().LockOrderState(5).Return(paid, nil).Times(2)
+	m.payment.EXPECT().MarkPaymentRefunded(second.ID, secondPaid.ID, "order is already paid").Return(nil)
+	m.refund.EXPECT().AddRefund(models.RefundDetails{OrderID: 5, Method: domain.RefundToOriginal, Amount: 499.5, PaymentID: secondPaid.ID, Status: domain.RefundInitiated, Reason: "order is already paid", Unapplied: true}).Return(12, nil)
+
+	m.refund.EXPECT().GetPendingGatewayRefunds(refundBatchSize).Return([]int{12}, nil)
+	m.refund.EXPECT().ClaimRefund(12).Return(refund, nil)
+	m.refund.EXPECT().SetGatewayRefund(12, gomock.Any()).Return(nil)
+	m.refund.EXPECT().LockRefund(12).Return(refund, nil)
+	m.refund.EXPECT().UpdateRefundStatus(12, domain.RefundProcessed, "").Return(nil)
+
+	uc := NewPaymentUseCase(m.order, m.payment, m.refund, m.transaction, fake, config.Config{})
+	err := uc.SavePaymentDetails(secondPaid.ID, second.ID, "5", signature)
+	assert.Equal(t, errors.New("order is already paid, the payment will be refunded"), err)
+
+	sent, err := uc.SendPendingRefunds()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	status, _ := fake.PaymentStatus(secondPaid.ID)
+	assert.Equal(t, gateway.StatusRefunded, status.Status)
+	status, _ = fake.PaymentStatus(firstPaid.ID)
+	assert.Equal(t, gateway.StatusCaptured, status.Status)
+}
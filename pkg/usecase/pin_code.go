@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	helper "github.com/ahdaan98/pkg/helper/interfaces"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	services "github.com/ahdaan98/pkg/usecase/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+)
+
+// pinCodeBatch is how many imported rows are saved per insert.
+const pinCodeBatch = 500
+
+var errNotServiceable = errors.New("we do not deliver to this pin code yet")
+
+type pinCodeUseCase struct {
+	pinCodeRepository   interfaces.PinCodeRepository
+	inventoryRepository interfaces.InventoryRepository
+	helper              helper.Helper
+}
+
+func NewPinCodeUseCase(repo interfaces.PinCodeRepository, inventoryRepo interfaces.InventoryRepository, h helper.Helper) services.PinCodeUseCase {
+	return &pinCodeUseCase{
+		pinCodeRepository:   repo,
+		inventoryRepository: inventoryRepo,
+		helper:              h,
+	}
+}
+
+// ImportPinCodes reads a CSV with a header row naming the columns pin,
+// serviceable, cod_allowed and delivery_days. Rows that do not parse are
+// skipped and reported; the rest are saved.
+func (p *pinCodeUseCase) ImportPinCodes(file io.Reader) (models.PinCodeImport, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return models.PinCodeImport{}, errors.New("could not read the csv header")
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"pin", "serviceable", "cod_allowed", "delivery_days"} {
+		if _, ok := columns[name]; !ok {
+			return models.PinCodeImport{}, fmt.Errorf("csv is missing the %s column", name)
+		}
+	}
+
+	var result models.PinCodeImport
+	var batch []models.PinCode
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+
+		pin, err := p.parsePinCode(record, columns)
+		if err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+
+		batch = append(batch, pin)
+		if len(batch) == pinCodeBatch {
+			if err := p.pinCodeRepository.SavePinCodes(batch); err != nil {
+				return result, err
+			}
+			result.Imported += len(batch)
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := p.pinCodeRepository.SavePinCodes(batch); err != nil {
+			return result, err
+		}
+		result.Imported += len(batch)
+	}
+
+	return result, nil
+}
+
+func (p *pinCodeUseCase) parsePinCode(record []string, columns map[string]int) (models.PinCode, error) {
+	field := func(name string) string {
+		if i := columns[name]; i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	serviceable, err := parseYesNo(field("serviceable"))
+	if err != nil {
+		return models.PinCode{}, errors.New("serviceable must be yes or no")
+	}
+	cod, err := parseYesNo(field("cod_allowed"))
+	if err != nil {
+		return models.PinCode{}, errors.New("cod_allowed must be yes or no")
+	}
+	days := 0
+	if d := field("delivery_days"); d != "" {
+		if days, err = strconv.Atoi(d); err != nil {
+			return models.PinCode{}, errors.New("delivery_days must be a number")
+		}
+	}
+
+	pin := models.PinCode{Pin: field("pin"), Serviceable: serviceable, CODAllowed: cod, DeliveryDays: days}
+	if err := p.validatePinCode(&pin); err != nil {
+		return models.PinCode{}, err
+	}
+
+	return pin, nil
+}
+
+func parseYesNo(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes", "y":
+		return true, nil
+	case "no", "n", "":
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+func (p *pinCodeUseCase) SetPinCode(pin models.PinCode) (models.PinCode, error) {
+	if err := p.validatePinCode(&pin); err != nil {
+		return models.PinCode{}, err
+	}
+
+	if err := p.pinCodeRepository.SavePinCodes([]models.PinCode{pin}); err != nil {
+		return models.PinCode{}, err
+	}
+
+	return pin, nil
+}
+
+// validatePinCode checks a pin code's format and delivery terms. Cash on
+// delivery is turned off where there is no delivery.
+func (p *pinCodeUseCase) validatePinCode(pin *models.PinCode) error {
+	pin.Pin = strings.TrimSpace(pin.Pin)
+	if !p.helper.ValidatePin(pin.Pin) {
+		return errors.New("invalid pin code")
+	}
+	if pin.DeliveryDays < 0 {
+		return errors.New("delivery days cannot be negative")
+	}
+	if pin.Serviceable && pin.DeliveryDays == 0 {
+		return errors.New("delivery days are required for a serviceable pin code")
+	}
+	if !pin.Serviceable {
+		pin.CODAllowed = false
+	}
+
+	return nil
+}
+
+func (p *pinCodeUseCase) GetPinCodes(page, count int) ([]models.PinCode, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if count <= 0 {
+		count = 50
+	}
+
+	return p.pinCodeRepository.GetPinCodes(page, count)
+}
+
+func (p *pinCodeUseCase) DeletePinCode(pin string) error {
+	if !p.helper.ValidatePin(pin) {
+		return errors.New("invalid pin code")
+	}
+
+	return p.pinCodeRepository.DeletePinCode(pin)
+}
+
+// CheckDelivery tells whether a product can be delivered to a pin code and
+// by which date.
+func (p *pinCodeUseCase) CheckDelivery(productID int, pin string) (models.DeliveryEstimate, error) {
+	if productID <= 0 {
+		return models.DeliveryEstimate{}, errors.New("enter a valid product id")
+	}
+	pin = strings.TrimSpace(pin)
+	if !p.helper.ValidatePin(pin) {
+		return models.DeliveryEstimate{}, errors.New("invalid pin code")
+	}
+
+	exist, err := p.inventoryRepository.CheckInventoryExistByID(productID)
+	if err != nil {
+		return models.DeliveryEstimate{}, err
+	}
+	if !exist {
+		return models.DeliveryEstimate{}, errors.New("product does not exist with this id")
+	}
+
+	stock, err := p.inventoryRepository.CheckStock(productID)
+	if err != nil {
+		return models.DeliveryEstimate{}, err
+	}
+
+	pinCode, err := p.pinCodeRepository.GetPinCode(pin)
+	if err != nil {
+		return models.DeliveryEstimate{}, err
+	}
+
+	estimate := models.DeliveryEstimate{
+		ProductID:   productID,
+		Pin:         pin,
+		Serviceable: pinCode.Serviceable,
+		CODAllowed:  pinCode.CODAllowed,
+		InStock:     stock.Stock > 0,
+	}
+	if pinCode.Serviceable {
+		estimate.DeliveryDays = pinCode.DeliveryDays
+		by := deliveryDate(time.Now(), pinCode.DeliveryDays)
+		estimate.EstimatedDelivery = &by
+	}
+
+	return estimate, nil
+}
+
+// deliveryDate is the day an order placed at from arrives in days.
+func deliveryDate(from time.Time, days int) time.Time {
+	y, m, d := from.AddDate(0, 0, days).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, from.Location())
+}
+
+// serviceablePin looks up the delivery terms of a pin code and fails when
+// orders cannot be delivered there.
+func serviceablePin(repo interfaces.PinCodeRepository, pin string) (models.PinCode, error) {
+	pinCode, err := repo.GetPinCode(strings.TrimSpace(pin))
+	if err != nil {
+		return models.PinCode{}, err
+	}
+	if !pinCode.Serviceable {
+		return models.PinCode{}, errNotServiceable
+	}
+
+	return pinCode, nil
+}
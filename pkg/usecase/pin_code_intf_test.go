@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	helper_mocks "github.com/ahdaan98/pkg/helper/mocks"
+	repo_mocks "github.com/ahdaan98/pkg/repository/mocks"
+	"github.com/ahdaan98/pkg/utils/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportPinCodes(t *testing.T) {
+	tests := []struct {
+		name       string
+		csv        string
+		stub       func(pins *repo_mocks.MockPinCodeRepository)
+		wantResult models.PinCodeImport
+		wantErr    error
+	}{
+		{
+			name: "rows saved in any column order and bad rows skipped",
+			csv: "delivery_days,pin,serviceable,cod_allowed\n" +
+				"3,682001,yes,yes\n" +
+				"0,110001,no,yes\n" +
+				"2,68200x,yes,no\n" +
+				"0,560001,yes,no\n" +
+				"4,400001,TRUE,maybe\n",
+			stub: func(pins *repo_mocks.MockPinCodeRepository) {
+				pins.EXPECT().SavePinCodes([]models.PinCode{
+					{Pin: "682001", Serviceable: true, CODAllowed: true, DeliveryDays: 3},
+					{Pin: "110001"},
+				}).Return(nil)
+			},
+			wantResult: models.PinCodeImport{Imported: 2, Skipped: []string{
+				"line 4: invalid pin code",
+				"line 5: delivery days are required for a serviceable pin code",
+				"line 6: cod_allowed must be yes or no",
+			}},
+		},
+		{
+			name:    "missing column",
+			csv:     "pin,serviceable,delivery_days\n682001,yes,3\n",
+			stub:    func(pins *repo_mocks.MockPinCodeRepository) {},
+			wantErr: errors.New("csv is missing the cod_allowed column"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			pins := repo_mocks.NewMockPinCodeRepository(ctrl)
+			h := helper_mocks.NewMockHelper(ctrl)
+			h.EXPECT().ValidatePin(gomock.Any()).DoAndReturn(func(pin string) bool {
+				return len(pin) == 6 && strings.Trim(pin, "0123456789") == ""
+			}).AnyTimes()
+			tc.stub(pins)
+
+			uc := NewPinCodeUseCase(pins, repo_mocks.NewMockInventoryRepository(ctrl), h)
+			result, err := uc.ImportPinCodes(strings.NewReader(tc.csv))
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantResult, result)
+		})
+	}
+}
+
+func TestCheckDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pins := repo_mocks.NewMockPinCodeRepository(ctrl)
+	inventory := repo_mocks.NewMockInventoryRepository(ctrl)
+	h := helper_mocks.NewMockHelper(ctrl)
+	h.EXPECT().ValidatePin("682001").Return(true).Times(2)
+	inventory.EXPECT().CheckInventoryExistByID(5).Return(true, nil).Times(2)
+	inventory.EXPECT().CheckStock(5).Return(models.CheckStockResponse{Stock: 2}, nil).Times(2)
+
+	uc := NewPinCodeUseCase(pins, inventory, h)
+
+	pins.EXPECT().GetPinCode("682001").Return(models.PinCode{Pin: "682001", Serviceable: true, DeliveryDays: 3}, nil)
+	estimate, err := uc.CheckDelivery(5, " 682001 ")
+	assert.NoError(t, err)
+	assert.True(t, estimate.Serviceable)
+	assert.True(t, estimate.InStock)
+	assert.False(t, estimate.CODAllowed)
+	assert.Equal(t, 3, estimate.DeliveryDays)
+	assert.NotNil(t, estimate.EstimatedDelivery)
+
+	// pin codes missing from the table cannot be delivered to
+	pins.EXPECT().GetPinCode("682001").Return(models.PinCode{}, nil)
+	estimate, err = uc.CheckDelivery(5, "682001")
+	assert.NoError(t, err)
+	assert.False(t, estimate.Serviceable)
+	assert.Nil(t, estimate.EstimatedDelivery)
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ahdaan98/pkg/domain"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+)
+
+// parseRefundTo reads where the customer wants a refund paid to. An empty
+// choice leaves it to refundPayment.
+func parseRefundTo(choice string) (string, error) {
+	switch strings.ToUpper(strings.TrimSpace(choice)) {
+	case "":
+		return "", nil
+	case domain.RefundToWallet:
+		return domain.RefundToWallet, nil
+	case domain.RefundToOriginal:
+		return domain.RefundToOriginal, nil
+	}
+	return "", errors.New("refund_to should be wallet or original")
+}
+
+// refundPayment refunds amount of a paid order and returns the payment status
+// that leaves the order in. Orders paid through the gateway are refunded to
+// the original payment method unless the customer asked for the wallet; the
+// rest can only be refunded to the wallet. Wallet refunds are credited at
+// once, gateway refunds are recorded as INITIATED for SendPendingRefunds.
+func refundPayment(repos interfaces.TxRepositories, state models.OrderState, amount float64, refundTo string, allClosed bool, reason string) (string, error) {
+	method := domain.RefundToWallet
+	var payment models.PaymentDetails
+	if refundTo != domain.RefundToWallet {
+		var err error
+		payment, err = repos.Payment.GetCapturedPayment(state.OrderID)
+		if err != nil {
+			return "", err
+		}
+		if payment.ID != 0 {
+			method = domain.RefundToOriginal
+		} else if refundTo == domain.RefundToOriginal {
+			return "", errors.New("only orders paid online can be refunded to the original payment method")
+		}
+	}
+
+	if amount > 0 {
+		refund := models.RefundDetails{
+			OrderID: state.OrderID,
+			Method:  method,
+			Amount:  amount,
+			Status:  domain.RefundInitiated,
+			Reason:  reason,
+		}
+		if method == domain.RefundToOriginal {
+			refund.PaymentID = payment.PaymentID
+		} else {
+			if _, err := repos.Wallet.AddToWallet(amount, state.UserID); err != nil {
+				return "", err
+			}
+			refund.Status = domain.RefundProcessed
+		}
+		if _, err := repos.Refund.AddRefund(refund); err != nil {
+			return "", err
+		}
+	}
+
+	return refundedPaymentStatus(repos.Refund, state.OrderID, allClosed, method)
+}
+
+// refundUnappliedPayment gives back amount paise the gateway captured for a
+// payment that did not pay for the order, see unappliedReason. The refund is
+// recorded as INITIATED for SendPendingRefunds.
+func refundUnappliedPayment(repos interfaces.TxRepositories, payment models.PaymentDetails, paymentID string, amount int64, reason string) error {
+	if err := repos.Payment.MarkPaymentRefunded(payment.GatewayOrderID, paymentID, reason); err != nil {
+		return err
+	}
+
+	_, err := repos.Refund.AddRefund(models.RefundDetails{
+		OrderID:   payment.OrderID,
+		Method:    domain.RefundToOriginal,
+		Amount:    float64(amount) / 100,
+		PaymentID: paymentID,
+		Status:    domain.RefundInitiated,
+		Reason:    reason,
+		Unapplied: true,
+	})
+	return err
+}
+
+// settleRefund applies the outcome the gateway reported for a refund to the
+// original payment method. A failed refund is credited to the wallet instead
+// so the customer still gets the money back. A refund the gateway settled while
+// it was still SENDING is settled all the same.
+func settleRefund(repos interfaces.TxRepositories, refund models.RefundDetails, status, failureReason string) error {
+	if refund.Status != domain.RefundInitiated && refund.Status != domain.RefundSending {
+		return nil
+	}
+	if err := repos.Refund.UpdateRefundStatus(refund.ID, status, failureReason); err != nil {
+		return err
+	}
+
+	state, err := repos.Order.LockOrderState(refund.OrderID)
+	if err != nil {
+		return err
+	}
+
+	method := refund.Method
+	if status == domain.RefundFailed {
+		if _, err := repos.Wallet.AddToWallet(refund.Amount, state.UserID); err != nil {
+			return err
+		}
+		_, err := repos.Refund.AddRefund(models.RefundDetails{
+			OrderID:   refund.OrderID,
+			Method:    domain.RefundToWallet,
+			Amount:    refund.Amount,
+			Status:    domain.RefundProcessed,
+			Reason:    fmt.Sprintf("refund %d failed at the gateway", refund.ID),
+			Unapplied: refund.Unapplied,
+		})
+		if err != nil {
+			return err
+		}
+		method = domain.RefundToWallet
+	}
+	if refund.Unapplied {
+		return nil
+	}
+
+	allClosed := state.OrderStatus == domain.OrderStatusCanceled || state.OrderStatus == domain.OrderStatusReturned
+	paymentStatus, err := refundedPaymentStatus(repos.Refund, refund.OrderID, allClosed, method)
+	if err != nil {
+		return err
+	}
+
+	return changeOrderState(repos.Order, state, orderStateChange{
+		PaymentStatus: paymentStatus,
+		Actor:         domain.Actor{Role: domain.ActorSystem},
+		Reason:        fmt.Sprintf("refund %d %s", refund.ID, strings.ToLower(status)),
+	})
+}
+
+// refundedPaymentStatus works out the payment status of an order after a
+// refund. It is REFUND IN PROGRESS while the gateway still owes the customer
+// anything and PARTIALLY REFUNDED while units are left open. Once everything
+// is refunded it shows where the last refund went.
+func refundedPaymentStatus(refunds interfaces.RefundRepository, orderID int, allClosed bool, method string) (string, error) {
+	pending, err := refunds.CountPendingRefunds(orderID)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case pending > 0:
+		return domain.PaymentStatusRefundInProgress, nil
+	case !allClosed:
+		return domain.PaymentStatusPartiallyRefunded, nil
+	case method == domain.RefundToOriginal:
+		return domain.PaymentStatusRefunded, nil
+	}
+	return domain.PaymentStatusReturnedToWallet, nil
+}
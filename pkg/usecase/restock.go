@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+)
+
+// restockLine is a number of units of one order item going back into
+// inventory. Damaged units are counted as damaged stock instead of being made
+// sellable again.
+type restockLine struct {
+	InventoryID int
+	Quantity    int
+	Damaged     int
+}
+
+// restockItems puts units of canceled or returned order items back into
+// inventory. It must run in the same transaction as the status change, with
+// lines ordered by inventory id so that concurrent restocks and checkouts take
+// the inventory row locks in the same order.
+func restockItems(repos interfaces.TxRepositories, lines []restockLine) error {
+	for _, line := range lines {
+		if sellable := line.Quantity - line.Damaged; sellable > 0 {
+			if err := repos.Inventory.RestoreStock(line.InventoryID, sellable); err != nil {
+				return err
+			}
+		}
+
+		if line.Damaged > 0 {
+			if err := repos.Inventory.AddDamagedStock(line.InventoryID, line.Damaged); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
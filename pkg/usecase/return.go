@@ -0,0 +1,366 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ahdaan98/pkg/domain"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	services "github.com/ahdaan98/pkg/usecase/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+)
+
+type returnUseCase struct {
+	returnRepository interfaces.ReturnRepository
+	transaction      interfaces.TransactionRepository
+}
+
+func NewReturnUseCase(repo interfaces.ReturnRepository, transaction interfaces.TransactionRepository) services.ReturnUseCase {
+	return &returnUseCase{
+		returnRepository: repo,
+		transaction:      transaction,
+	}
+}
+
+// RequestReturn opens a return request for units of a delivered order. Nothing
+// is refunded until the goods have been received and inspected.
+func (r *returnUseCase) RequestReturn(userID int, request models.ReturnRequest) (models.ReturnRequestDetails, error) {
+	if request.OrderID <= 0 {
+		return models.ReturnRequestDetails{}, errors.New("enter a valid order id")
+	}
+	if strings.TrimSpace(request.Reason) == "" {
+		return models.ReturnRequestDetails{}, errors.New("give a reason for the return")
+	}
+	if len(request.Items) == 0 {
+		return models.ReturnRequestDetails{}, errors.New("select the items to return")
+	}
+	refundTo, err := parseRefundTo(request.RefundTo)
+	if err != nil {
+		return models.ReturnRequestDetails{}, err
+	}
+
+	requested := make(map[int]int, len(request.Items))
+	damaged := make(map[int]int, len(request.Items))
+	for _, item := range request.Items {
+		if item.Quantity <= 0 || item.DamagedQuantity < 0 {
+			return models.ReturnRequestDetails{}, errors.New("enter a valid quantity")
+		}
+		if item.DamagedQuantity > item.Quantity {
+			return models.ReturnRequestDetails{}, errors.New("damaged quantity exceeds returned quantity")
+		}
+		requested[item.OrderItemID] += item.Quantity
+		damaged[item.OrderItemID] += item.DamagedQuantity
+	}
+
+	return r.openReturn(userID, request.OrderID, request.Reason, refundTo, request.Photos, func(left map[int]int) ([]itemClosure, error) {
+		closures := make([]itemClosure, 0, len(requested))
+		for itemID, quantity := range requested {
+			open, ok := left[itemID]
+			if !ok {
+				return nil, errors.New("item does not belong to this order")
+			}
+			if quantity > open {
+				return nil, errors.New("quantity exceeds what is left to return")
+			}
+			closures = append(closures, itemClosure{OrderItemID: itemID, Quantity: quantity, Damaged: damaged[itemID]})
+		}
+		return closures, nil
+	})
+}
+
+// ReturnOrder opens a return request for every unit of a delivered order that
+// is neither returned nor in another return yet. damaged lists the units the
+// customer says arrived damaged.
+func (r *returnUseCase) ReturnOrder(userID, orderID int, damaged []models.DamagedItem) (models.ReturnRequestDetails, error) {
+	if orderID <= 0 {
+		return models.ReturnRequestDetails{}, errors.New("enter a valid order id")
+	}
+
+	reported := make(map[int]int, len(damaged))
+	for _, item := range damaged {
+		if item.Quantity <= 0 {
+			return models.ReturnRequestDetails{}, errors.New("enter a valid quantity")
+		}
+		reported[item.OrderItemID] += item.Quantity
+	}
+
+	return r.openReturn(userID, orderID, "order returned by customer", "", nil, func(left map[int]int) ([]itemClosure, error) {
+		for itemID, quantity := range reported {
+			open, ok := left[itemID]
+			if !ok {
+				return nil, errors.New("item does not belong to this order")
+			}
+			if quantity > open {
+				return nil, errors.New("damaged quantity exceeds returned quantity")
+			}
+		}
+
+		var closures []itemClosure
+		for itemID, open := range left {
+			if open > 0 {
+				closures = append(closures, itemClosure{OrderItemID: itemID, Quantity: open, Damaged: reported[itemID]})
+			}
+		}
+		return closures, nil
+	})
+}
+
+// returnSelector picks the units to return given how many units of each order
+// item are neither returned nor in another return.
+type returnSelector func(left map[int]int) ([]itemClosure, error)
+
+// openReturn records a return request for the units selectUnits picks out of
+// a delivered order of the user.
+func (r *returnUseCase) openReturn(userID, orderID int, reason, refundTo string, photos []string, selectUnits returnSelector) (models.ReturnRequestDetails, error) {
+	var returnID int
+	err := r.transaction.WithTransaction(func(repos interfaces.TxRepositories) error {
+		state, err := repos.Order.LockOrderState(orderID)
+		if err != nil {
+			return err
+		}
+		if state.UserID != userID {
+			return domain.ErrForbidden
+		}
+		if state.OrderStatus != domain.OrderStatusDelivered {
+			return errors.New("only delivered orders can be returned")
+		}
+
+		items, err := repos.Order.GetOrderItemStates(orderID)
+		if err != nil {
+			return err
+		}
+		pending, err := repos.Return.PendingReturnQuantities(orderID)
+		if err != nil {
+			return err
+		}
+
+		left := make(map[int]int, len(items))
+		for _, item := range items {
+			left[item.ID] = item.OpenQuantity()
+		}
+		for _, p := range pending {
+			left[p.OrderItemID] -= p.Quantity
+		}
+
+		closures, err := selectUnits(left)
+		if err != nil {
+			return err
+		}
+		if len(closures) == 0 {
+			return errors.New("no items left to return")
+		}
+		selected := make(map[int]itemClosure, len(closures))
+		for _, c := range closures {
+			selected[c.OrderItemID] = c
+		}
+
+		returnID, err = repos.Return.CreateReturnRequest(orderID, userID, reason, refundTo)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if c, ok := selected[item.ID]; ok {
+				if err := repos.Return.AddReturnItem(returnID, item.ID, c.Quantity, c.Damaged); err != nil {
+					return err
+				}
+			}
+		}
+		for _, photo := range photos {
+			if err := repos.Return.AddReturnImage(returnID, photo); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.ReturnRequestDetails{}, err
+	}
+
+	return r.GetReturn(returnID)
+}
+
+func (r *returnUseCase) GetUserReturns(userID int) ([]models.ReturnRequestDetails, error) {
+	return r.returnRepository.GetUserReturnRequests(userID)
+}
+
+func (r *returnUseCase) GetUserReturn(userID, returnID int) (models.ReturnRequestDetails, error) {
+	request, err := r.GetReturn(returnID)
+	if err != nil {
+		return models.ReturnRequestDetails{}, err
+	}
+	if request.UserID != userID {
+		return models.ReturnRequestDetails{}, domain.ErrForbidden
+	}
+
+	return request, nil
+}
+
+func (r *returnUseCase) CancelReturn(userID, returnID int) error {
+	return r.changeReturnStatus(returnID, domain.ReturnStatusCanceled, func(request models.ReturnRequestDetails) error {
+		if request.UserID != userID {
+			return domain.ErrForbidden
+		}
+		return nil
+	}, func(repos interfaces.TxRepositories, request models.ReturnRequestDetails) error {
+		return repos.Return.UpdateReturnStatus(returnID, domain.ReturnStatusCanceled, "")
+	})
+}
+
+func (r *returnUseCase) GetReturns(status string, page int) ([]models.ReturnRequestDetails, error) {
+	if page <= 0 {
+		return nil, errors.New("enter a valid number")
+	}
+
+	return r.returnRepository.GetReturnRequests(strings.ToUpper(status), page)
+}
+
+func (r *returnUseCase) GetReturn(returnID int) (models.ReturnRequestDetails, error) {
+	if returnID <= 0 {
+		return models.ReturnRequestDetails{}, errors.New("enter a valid return id")
+	}
+
+	request, err := r.returnRepository.GetReturnRequest(returnID)
+	if err != nil {
+		return models.ReturnRequestDetails{}, err
+	}
+
+	request.Items, err = r.returnRepository.GetReturnItems(returnID)
+	if err != nil {
+		return models.ReturnRequestDetails{}, err
+	}
+
+	request.Photos, err = r.returnRepository.GetReturnImages(returnID)
+	if err != nil {
+		return models.ReturnRequestDetails{}, err
+	}
+
+	return request, nil
+}
+
+func (r *returnUseCase) ApproveReturn(returnID int, note string) error {
+	return r.changeReturnStatus(returnID, domain.ReturnStatusApproved, nil, func(repos interfaces.TxRepositories, request models.ReturnRequestDetails) error {
+		return repos.Return.UpdateReturnStatus(returnID, domain.ReturnStatusApproved, note)
+	})
+}
+
+func (r *returnUseCase) RejectReturn(returnID int, note string) error {
+	if strings.TrimSpace(note) == "" {
+		return errors.New("give a reason for rejecting the return")
+	}
+
+	return r.changeReturnStatus(returnID, domain.ReturnStatusRejected, nil, func(repos interfaces.TxRepositories, request models.ReturnRequestDetails) error {
+		return repos.Return.UpdateReturnStatus(returnID, domain.ReturnStatusRejected, note)
+	})
+}
+
+func (r *returnUseCase) SchedulePickup(returnID int, pickupDate time.Time) error {
+	if pickupDate.IsZero() {
+		return errors.New("enter a valid pickup date")
+	}
+
+	return r.changeReturnStatus(returnID, domain.ReturnStatusPickupScheduled, nil, func(repos interfaces.TxRepositories, request models.ReturnRequestDetails) error {
+		return repos.Return.SchedulePickup(returnID, pickupDate)
+	})
+}
+
+func (r *returnUseCase) ReceiveReturn(returnID int) error {
+	return r.changeReturnStatus(returnID, domain.ReturnStatusReceived, nil, func(repos interfaces.TxRepositories, request models.ReturnRequestDetails) error {
+		return repos.Return.UpdateReturnStatus(returnID, domain.ReturnStatusReceived, "")
+	})
+}
+
+// InspectReturn records how many of the received units are damaged. Items that
+// are not listed keep the damage the customer reported.
+func (r *returnUseCase) InspectReturn(returnID int, inspected []models.InspectedItem) error {
+	return r.changeReturnStatus(returnID, domain.ReturnStatusInspected, nil, func(repos interfaces.TxRepositories, request models.ReturnRequestDetails) error {
+		items, err := repos.Return.GetReturnItems(returnID)
+		if err != nil {
+			return err
+		}
+
+		for _, i := range inspected {
+			found := false
+			for _, item := range items {
+				if item.OrderItemID != i.OrderItemID {
+					continue
+				}
+				found = true
+				if i.DamagedQuantity < 0 || i.DamagedQuantity > item.Quantity {
+					return errors.New("damaged quantity exceeds returned quantity")
+				}
+				if err := repos.Return.SetReturnItemDamage(item.ID, i.DamagedQuantity); err != nil {
+					return err
+				}
+			}
+			if !found {
+				return errors.New("item is not part of this return")
+			}
+		}
+
+		return repos.Return.UpdateReturnStatus(returnID, domain.ReturnStatusInspected, "")
+	})
+}
+
+// RefundReturn closes the inspected units on the order, puts the sellable ones
+// back into stock and refunds their share of the order the way the customer
+// asked when requesting the return.
+func (r *returnUseCase) RefundReturn(adminID, returnID int) error {
+	return r.changeReturnStatus(returnID, domain.ReturnStatusRefunded, nil, func(repos interfaces.TxRepositories, request models.ReturnRequestDetails) error {
+		state, err := repos.Order.LockOrderState(request.OrderID)
+		if err != nil {
+			return err
+		}
+		if state.OrderStatus != domain.OrderStatusDelivered {
+			return errors.New("cannot return order")
+		}
+
+		items, err := repos.Return.GetReturnItems(returnID)
+		if err != nil {
+			return err
+		}
+
+		closures := make([]itemClosure, 0, len(items))
+		for _, item := range items {
+			closures = append(closures, itemClosure{OrderItemID: item.OrderItemID, Quantity: item.Quantity, Damaged: item.DamagedQuantity})
+		}
+
+		actor := domain.Actor{Role: domain.ActorAdmin, ID: adminID}
+		reason := fmt.Sprintf("return request %d refunded", returnID)
+		if err := closeOrderItems(repos, state, domain.OrderStatusReturned, requestedItems(closures), request.RefundTo, actor, reason); err != nil {
+			return err
+		}
+
+		return repos.Return.UpdateReturnStatus(returnID, domain.ReturnStatusRefunded, "")
+	})
+}
+
+// changeReturnStatus locks a return request, checks that it may move to
+// status and runs apply in the same transaction. check, when given, can
+// reject the request before anything is changed.
+func (r *returnUseCase) changeReturnStatus(returnID int, status string, check func(models.ReturnRequestDetails) error, apply func(repos interfaces.TxRepositories, request models.ReturnRequestDetails) error) error {
+	if returnID <= 0 {
+		return errors.New("enter a valid return id")
+	}
+
+	return r.transaction.WithTransaction(func(repos interfaces.TxRepositories) error {
+		request, err := repos.Return.LockReturnRequest(returnID)
+		if err != nil {
+			return err
+		}
+
+		if check != nil {
+			if err := check(request); err != nil {
+				return err
+			}
+		}
+
+		if !domain.CanChangeReturnStatus(request.Status, status) {
+			return &domain.InvalidTransitionError{Field: "return status", From: request.Status, To: status}
+		}
+
+		return apply(repos, request)
+	})
+}
@@ -0,0 +1,289 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ahdaan98/pkg/domain"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	repo_mocks "github.com/ahdaan98/pkg/repository/mocks"
+	"github.com/ahdaan98/pkg/utils/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type returnTestMocks struct {
+	ret       *repo_mocks.MockReturnRepository
+	order     *repo_mocks.MockOrderRepository
+	inventory *repo_mocks.MockInventoryRepository
+	wallet    *repo_mocks.MockWalletRepository
+	invoice   *repo_mocks.MockInvoiceRepository
+	payment   *repo_mocks.MockPaymentRepository
+	refund    *repo_mocks.MockRefundRepository
+}
+
+func newReturnTestUseCase(ctrl *gomock.Controller) (*returnUseCase, returnTestMocks) {
+	m := returnTestMocks{
+		ret:       repo_mocks.NewMockReturnRepository(ctrl),
+		order:     repo_mocks.NewMockOrderRepository(ctrl),
+		inventory: repo_mocks.NewMockInventoryRepository(ctrl),
+		wallet:    repo_mocks.NewMockWalletRepository(ctrl),
+		invoice:   repo_mocks.NewMockInvoiceRepository(ctrl),
+		payment:   repo_mocks.NewMockPaymentRepository(ctrl),
+		refund:    repo_mocks.NewMockRefundRepository(ctrl),
+	}
+
+	transaction := newTestTransaction(ctrl, interfaces.TxRepositories{
+		Order:     m.order,
+		Inventory: m.inventory,
+		Wallet:    m.wallet,
+		Return:    m.ret,
+		Invoice:   m.invoice,
+		Payment:   m.payment,
+		Refund:    m.refund,
+	})
+
+	return NewReturnUseCase(m.ret, transaction).(*returnUseCase), m
+}
+
+func TestRequestReturn(t *testing.T) {
+	delivered := models.OrderState{OrderID: 5, UserID: 1, OrderStatus: domain.OrderStatusDelivered, PaymentStatus: domain.PaymentStatusPaid, FinalPrice: 600}
+	items := []models.OrderItemState{
+		{ID: 20, InventoryID: 1, Quantity: 2, TotalPrice: 100, Status: domain.OrderStatusDelivered},
+		{ID: 21, InventoryID: 2, Quantity: 1, TotalPrice: 500, Status: domain.OrderStatusDelivered},
+	}
+
+	tests := []struct {
+		name    string
+		request models.ReturnRequest
+		stub    func(m returnTestMocks)
+		wantErr error
+	}{
+		{
+			name:    "request is recorded without a refund",
+			request: models.ReturnRequest{OrderID: 5, Reason: "wrong size", Items: []models.ReturnRequestItem{{OrderItemID: 20, Quantity: 1}}, Photos: []string{"box.jpg"}},
+			stub: func(m returnTestMocks) {
+				m.order.EXPECT().LockOrderState(5).Return(delivered, nil)
+				m.order.EXPECT().GetOrderItemStates(5).Return(items, nil)
+				m.ret.EXPECT().PendingReturnQuantities(5).Return(nil, nil)
+				m.ret.EXPECT().CreateReturnRequest(5, 1, "wrong size", "").Return(3, nil)
+				m.ret.EXPECT().AddReturnItem(3, 20, 1, 0).Return(nil)
+				m.ret.EXPECT().AddReturnImage(3, "box.jpg").Return(nil)
+				m.ret.EXPECT().GetReturnRequest(3).Return(models.ReturnRequestDetails{ID: 3, OrderID: 5, UserID: 1, Status: domain.ReturnStatusRequested}, nil)
+				m.ret.EXPECT().GetReturnItems(3).Return([]models.ReturnItemDetails{{ID: 1, OrderItemID: 20, Quantity: 1}}, nil)
+				m.ret.EXPECT().GetReturnImages(3).Return([]string{"box.jpg"}, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "damage the customer reports is recorded",
+			request: models.ReturnRequest{OrderID: 5, Reason: "arrived broken", Items: []models.ReturnRequestItem{{OrderItemID: 20, Quantity: 2, DamagedQuantity: 1}}},
+			stub: func(m returnTestMocks) {
+				m.order.EXPECT().LockOrderState(5).Return(delivered, nil)
+				m.order.EXPECT().GetOrderItemStates(5).Return(items, nil)
+				m.ret.EXPECT().PendingReturnQuantities(5).Return(nil, nil)
+				m.ret.EXPECT().CreateReturnRequest(5, 1, "arrived broken", "").Return(3, nil)
+				m.ret.EXPECT().AddReturnItem(3, 20, 2, 1).Return(nil)
+				m.ret.EXPECT().GetReturnRequest(3).Return(models.ReturnRequestDetails{ID: 3, OrderID: 5, UserID: 1, Status: domain.ReturnStatusRequested}, nil)
+				m.ret.EXPECT().GetReturnItems(3).Return([]models.ReturnItemDetails{{ID: 1, OrderItemID: 20, Quantity: 2, DamagedQuantity: 1}}, nil)
+				m.ret.EXPECT().GetReturnImages(3).Return(nil, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "more damaged than returned",
+			request: models.ReturnRequest{OrderID: 5, Reason: "arrived broken", Items: []models.ReturnRequestItem{{OrderItemID: 20, Quantity: 1, DamagedQuantity: 2}}},
+			stub:    func(m returnTestMocks) {},
+			wantErr: errors.New("damaged quantity exceeds returned quantity"),
+		},
+		{
+			name:    "units already in another return",
+			request: models.ReturnRequest{OrderID: 5, Reason: "wrong size", Items: []models.ReturnRequestItem{{OrderItemID: 21, Quantity: 1}}},
+			stub: func(m returnTestMocks) {
+				m.order.EXPECT().LockOrderState(5).Return(delivered, nil)
+				m.order.EXPECT().GetOrderItemStates(5).Return(items, nil)
+				m.ret.EXPECT().PendingReturnQuantities(5).Return([]models.OrderItemQuantity{{OrderItemID: 21, Quantity: 1}}, nil)
+			},
+			wantErr: errors.New("quantity exceeds what is left to return"),
+		},
+		{
+			name:    "order not delivered",
+			request: models.ReturnRequest{OrderID: 5, Reason: "wrong size", Items: []models.ReturnRequestItem{{OrderItemID: 20, Quantity: 1}}},
+			stub: func(m returnTestMocks) {
+				state := delivered
+				state.OrderStatus = domain.OrderStatusShipped
+				m.order.EXPECT().LockOrderState(5).Return(state, nil)
+			},
+			wantErr: errors.New("only delivered orders can be returned"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, m := newReturnTestUseCase(ctrl)
+			tc.stub(m)
+
+			_, err := uc.RequestReturn(1, tc.request)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestReturnOrder(t *testing.T) {
+	delivered := models.OrderState{OrderID: 5, UserID: 1, OrderStatus: domain.OrderStatusDelivered, PaymentStatus: domain.PaymentStatusPaid, FinalPrice: 600}
+	items := []models.OrderItemState{
+		{ID: 20, InventoryID: 1, Quantity: 2, TotalPrice: 100, Status: domain.OrderStatusDelivered},
+		{ID: 21, InventoryID: 2, Quantity: 1, TotalPrice: 500, Status: domain.OrderStatusDelivered},
+	}
+
+	tests := []struct {
+		name    string
+		damaged []models.DamagedItem
+		stub    func(m returnTestMocks)
+		wantErr error
+	}{
+		{
+			name:    "every unit not already in a return is requested",
+			damaged: []models.DamagedItem{{OrderItemID: 20, Quantity: 1}},
+			stub: func(m returnTestMocks) {
+				m.order.EXPECT().LockOrderState(5).Return(delivered, nil)
+				m.order.EXPECT().GetOrderItemStates(5).Return(items, nil)
+				m.ret.EXPECT().PendingReturnQuantities(5).Return([]models.OrderItemQuantity{{OrderItemID: 21, Quantity: 1}}, nil)
+				m.ret.EXPECT().CreateReturnRequest(5, 1, "order returned by customer", "").Return(3, nil)
+				m.ret.EXPECT().AddReturnItem(3, 20, 2, 1).Return(nil)
+				m.ret.EXPECT().GetReturnRequest(3).Return(models.ReturnRequestDetails{ID: 3, OrderID: 5, UserID: 1, Status: domain.ReturnStatusRequested}, nil)
+				m.ret.EXPECT().GetReturnItems(3).Return([]models.ReturnItemDetails{{ID: 1, OrderItemID: 20, Quantity: 2, DamagedQuantity: 1}}, nil)
+				m.ret.EXPECT().GetReturnImages(3).Return(nil, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "nothing left to return",
+			stub: func(m returnTestMocks) {
+				m.order.EXPECT().LockOrderState(5).Return(delivered, nil)
+				m.order.EXPECT().GetOrderItemStates(5).Return(items, nil)
+				m.ret.EXPECT().PendingReturnQuantities(5).Return([]models.OrderItemQuantity{{OrderItemID: 20, Quantity: 2}, {OrderItemID: 21, Quantity: 1}}, nil)
+			},
+			wantErr: errors.New("no items left to return"),
+		},
+		{
+			name:    "more damaged than left to return",
+			damaged: []models.DamagedItem{{OrderItemID: 21, Quantity: 2}},
+			stub: func(m returnTestMocks) {
+				m.order.EXPECT().LockOrderState(5).Return(delivered, nil)
+				m.order.EXPECT().GetOrderItemStates(5).Return(items, nil)
+				m.ret.EXPECT().PendingReturnQuantities(5).Return(nil, nil)
+			},
+			wantErr: errors.New("damaged quantity exceeds returned quantity"),
+		},
+		{
+			name: "another user's order",
+			stub: func(m returnTestMocks) {
+				state := delivered
+				state.UserID = 2
+				m.order.EXPECT().LockOrderState(5).Return(state, nil)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, m := newReturnTestUseCase(ctrl)
+			tc.stub(m)
+
+			_, err := uc.ReturnOrder(1, 5, tc.damaged)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestRefundReturn(t *testing.T) {
+	delivered := models.OrderState{OrderID: 5, UserID: 1, OrderStatus: domain.OrderStatusDelivered, PaymentStatus: domain.PaymentStatusPaid, FinalPrice: 600}
+	items := func() []models.OrderItemState {
+		return []models.OrderItemState{
+			{ID: 20, InventoryID: 1, Quantity: 2, TotalPrice: 100, Status: domain.OrderStatusDelivered},
+			{ID: 21, InventoryID: 2, Quantity: 1, TotalPrice: 500, Status: domain.OrderStatusDelivered},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		stub    func(m returnTestMocks)
+		wantErr error
+	}{
+		{
+			name: "inspected units are refunded and damaged ones kept out of stock",
+			stub: func(m returnTestMocks) {
+				// the customer asked for the wallet when requesting the return
+				m.ret.EXPECT().LockReturnRequest(3).Return(models.ReturnRequestDetails{ID: 3, OrderID: 5, UserID: 1, Status: domain.ReturnStatusInspected, RefundTo: domain.RefundToWallet}, nil)
+				m.order.EXPECT().LockOrderState(5).Return(delivered, nil)
+				m.ret.EXPECT().GetReturnItems(3).Return([]models.ReturnItemDetails{
+					{ID: 1, OrderItemID: 20, Quantity: 2, DamagedQuantity: 1},
+					{ID: 2, OrderItemID: 21, Quantity: 1, DamagedQuantity: 1},
+				}, nil)
+				m.order.EXPECT().LockOrderItems(5).Return(items(), nil)
+				m.order.EXPECT().UpdateOrderItem(models.OrderItemState{ID: 20, InventoryID: 1, Quantity: 2, TotalPrice: 100, Status: domain.OrderStatusReturned, ReturnedQuantity: 2, DamagedQuantity: 1, RefundAmount: 100}).Return(nil)
+				m.order.EXPECT().UpdateOrderItem(models.OrderItemState{ID: 21, InventoryID: 2, Quantity: 1, TotalPrice: 500, Status: domain.OrderStatusReturned, ReturnedQuantity: 1, DamagedQuantity: 1, RefundAmount: 500}).Return(nil)
+				m.inventory.EXPECT().RestoreStock(1, 1).Return(nil)
+				m.inventory.EXPECT().AddDamagedStock(1, 1).Return(nil)
+				m.inventory.EXPECT().AddDamagedStock(2, 1).Return(nil)
+				m.order.EXPECT().UpdateOrderState(5, domain.OrderStatusReturned, domain.PaymentStatusReturnedToWallet).Return(nil)
+				m.order.EXPECT().AddStatusHistory(domain.OrderStatusHistory{
+					OrderID:               5,
+					PreviousOrderStatus:   domain.OrderStatusDelivered,
+					OrderStatus:           domain.OrderStatusReturned,
+					PreviousPaymentStatus: domain.PaymentStatusPaid,
+					PaymentStatus:         domain.PaymentStatusReturnedToWallet,
+					ActorRole:             domain.ActorAdmin,
+					ActorID:               9,
+					Reason:                "return request 3 refunded",
+				}).Return(nil)
+				m.wallet.EXPECT().AddToWallet(600.0, 1).Return(models.WalletAmount{}, nil)
+				m.refund.EXPECT().AddRefund(models.RefundDetails{OrderID: 5, Method: domain.RefundToWallet, Amount: 600, Status: domain.RefundProcessed, Reason: "return request 3 refunded"}).Return(1, nil)
+				m.refund.EXPECT().CountPendingRefunds(5).Return(0, nil)
+				// the invoiced order is credited on a credit note of its own series
+				m.invoice.EXPECT().GetInvoiceByOrder(5).Return(models.Invoice{ID: 4, OrderID: 5, Number: "INV/2024-25/000004"}, nil)
+				m.invoice.EXPECT().NextDocumentNumber(domain.SeriesCreditNote, gomock.Any()).Return(2, nil)
+				m.invoice.EXPECT().CreateCreditNote(gomock.Any()).DoAndReturn(func(note models.CreditNote) (int, error) {
+					assert.Equal(t, 4, note.InvoiceID)
+					assert.Equal(t, domain.DocumentNumber(domain.SeriesCreditNote, note.FinancialYear, 2), note.Number)
+					assert.Equal(t, 600.0, note.Amount)
+					assert.Equal(t, []models.CreditNoteItem{
+						{OrderItemID: 20, Quantity: 2, Amount: 100},
+						{OrderItemID: 21, Quantity: 1, Amount: 500},
+					}, note.Items)
+					return 1, nil
+				})
+				m.ret.EXPECT().UpdateReturnStatus(3, domain.ReturnStatusRefunded, "").Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "refund before inspection",
+			stub: func(m returnTestMocks) {
+				m.ret.EXPECT().LockReturnRequest(3).Return(models.ReturnRequestDetails{ID: 3, OrderID: 5, UserID: 1, Status: domain.ReturnStatusReceived}, nil)
+			},
+			wantErr: &domain.InvalidTransitionError{Field: "return status", From: domain.ReturnStatusReceived, To: domain.ReturnStatusRefunded},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, m := newReturnTestUseCase(ctrl)
+			tc.stub(m)
+
+			err := uc.RefundReturn(9, 3)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ahdaan98/pkg/domain"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	services "github.com/ahdaan98/pkg/usecase/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+)
+
+type shipmentUseCase struct {
+	shipmentRepository interfaces.ShipmentRepository
+	orderRepository    interfaces.OrderRepository
+	transaction        interfaces.TransactionRepository
+}
+
+func NewShipmentUseCase(repo interfaces.ShipmentRepository, orderRepo interfaces.OrderRepository, transaction interfaces.TransactionRepository) services.ShipmentUseCase {
+	return &shipmentUseCase{
+		shipmentRepository: repo,
+		orderRepository:    orderRepo,
+		transaction:        transaction,
+	}
+}
+
+// CreateShipment hands a pending order to a courier and marks it shipped.
+func (s *shipmentUseCase) CreateShipment(adminID, orderID int, shipment models.ShipmentRequest) (models.ShipmentDetails, error) {
+	if orderID <= 0 {
+		return models.ShipmentDetails{}, errors.New("enter a valid order id")
+	}
+
+	shipment.Carrier = strings.ToLower(strings.TrimSpace(shipment.Carrier))
+	shipment.TrackingNumber = strings.TrimSpace(shipment.TrackingNumber)
+	if shipment.Carrier == "" || shipment.TrackingNumber == "" {
+		return models.ShipmentDetails{}, errors.New("enter the carrier and tracking number")
+	}
+	if shipment.Packages == 0 {
+		shipment.Packages = 1
+	}
+	if shipment.Packages < 0 || shipment.WeightKg < 0 {
+		return models.ShipmentDetails{}, errors.New("enter a valid number of packages and weight")
+	}
+
+	err := s.transaction.WithTransaction(func(repos interfaces.TxRepositories) error {
+		state, err := repos.Order.LockOrderState(orderID)
+		if err != nil {
+			return err
+		}
+		if state.OrderStatus != domain.OrderStatusPending {
+			return errors.New("only pending orders can be shipped")
+		}
+
+		shipmentID, err := repos.Shipment.CreateShipment(orderID, shipment)
+		if err != nil {
+			return err
+		}
+
+		event := models.ShipmentEventState{
+			Status:      domain.ShipmentStatusDispatched,
+			Description: "handed over to " + shipment.Carrier,
+			OccurredAt:  time.Now(),
+		}
+		if _, err := repos.Shipment.AddShipmentEvent(shipmentID, event); err != nil {
+			return err
+		}
+
+		actor := domain.Actor{Role: domain.ActorAdmin, ID: adminID}
+		reason := fmt.Sprintf("shipped with %s, tracking number %s", shipment.Carrier, shipment.TrackingNumber)
+		return advanceOrder(repos, state, domain.OrderStatusShipped, actor, reason)
+	})
+	if err != nil {
+		return models.ShipmentDetails{}, err
+	}
+
+	return s.GetShipment(orderID)
+}
+
+func (s *shipmentUseCase) GetShipment(orderID int) (models.ShipmentDetails, error) {
+	if orderID <= 0 {
+		return models.ShipmentDetails{}, errors.New("enter a valid order id")
+	}
+
+	shipment, err := s.shipmentRepository.GetShipmentByOrder(orderID)
+	if err != nil {
+		return models.ShipmentDetails{}, err
+	}
+
+	shipment.Events, err = s.shipmentRepository.GetShipmentEvents(shipment.ID)
+	if err != nil {
+		return models.ShipmentDetails{}, err
+	}
+
+	return shipment, nil
+}
+
+func (s *shipmentUseCase) GetUserShipment(userID, orderID int) (models.ShipmentDetails, error) {
+	if orderID <= 0 {
+		return models.ShipmentDetails{}, errors.New("enter a valid order id")
+	}
+
+	if err := authorizeOrder(s.orderRepository, userID, orderID); err != nil {
+		return models.ShipmentDetails{}, err
+	}
+
+	return s.GetShipment(orderID)
+}
+
+// RecordTrackingEvent stores a scan posted by the courier and updates the
+// shipment. A delivery scan also delivers the order. Scans the courier posts
+// again are ignored.
+func (s *shipmentUseCase) RecordTrackingEvent(event models.ShipmentEventRequest) error {
+	status := strings.ToUpper(strings.TrimSpace(event.Status))
+	if !domain.IsShipmentStatus(status) {
+		return errors.New("unknown shipment status")
+	}
+	if event.OccurredAt.IsZero() {
+		return errors.New("enter when the event occurred")
+	}
+
+	shipment, err := s.shipmentRepository.GetShipmentByTracking(strings.ToLower(strings.TrimSpace(event.Carrier)), strings.TrimSpace(event.TrackingNumber))
+	if err != nil {
+		return err
+	}
+
+	return s.transaction.WithTransaction(func(repos interfaces.TxRepositories) error {
+		// the order row lock keeps scans for the same shipment in sequence
+		state, err := repos.Order.LockOrderState(shipment.OrderID)
+		if err != nil {
+			return err
+		}
+
+		added, err := repos.Shipment.AddShipmentEvent(shipment.ID, models.ShipmentEventState{
+			Status:      status,
+			Location:    event.Location,
+			Description: event.Description,
+			OccurredAt:  event.OccurredAt,
+		})
+		if err != nil {
+			return err
+		}
+		if !added {
+			return nil
+		}
+
+		current, err := repos.Shipment.RefreshShipmentStatus(shipment.ID)
+		if err != nil {
+			return err
+		}
+
+		// an order canceled in transit keeps its status whatever the courier reports
+		if current != domain.ShipmentStatusDelivered || state.OrderStatus != domain.OrderStatusShipped {
+			return nil
+		}
+
+		actor := domain.Actor{Role: domain.ActorSystem}
+		return advanceOrder(repos, state, domain.OrderStatusDelivered, actor, "delivered by "+shipment.Carrier)
+	})
+}
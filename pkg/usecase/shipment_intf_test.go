@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ahdaan98/pkg/domain"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	repo_mocks "github.com/ahdaan98/pkg/repository/mocks"
+	"github.com/ahdaan98/pkg/utils/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type shipmentTestMocks struct {
+	shipment *repo_mocks.MockShipmentRepository
+	order    *repo_mocks.MockOrderRepository
+	invoice  *repo_mocks.MockInvoiceRepository
+}
+
+func newShipmentTestUseCase(ctrl *gomock.Controller) (*shipmentUseCase, shipmentTestMocks) {
+	m := shipmentTestMocks{
+		shipment: repo_mocks.NewMockShipmentRepository(ctrl),
+		order:    repo_mocks.NewMockOrderRepository(ctrl),
+		invoice:  repo_mocks.NewMockInvoiceRepository(ctrl),
+	}
+
+	transaction := newTestTransaction(ctrl, interfaces.TxRepositories{
+		Order:    m.order,
+		Shipment: m.shipment,
+		Invoice:  m.invoice,
+	})
+
+	return NewShipmentUseCase(m.shipment, m.order, transaction).(*shipmentUseCase), m
+}
+
+func TestRecordTrackingEvent(t *testing.T) {
+	scannedAt := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	shipment := models.ShipmentDetails{ID: 9, OrderID: 5, Carrier: "bluedart", TrackingNumber: "BD123", Status: domain.ShipmentStatusOutForDelivery}
+	scan := models.ShipmentEventState{Status: domain.ShipmentStatusDelivered, Location: "Kochi", OccurredAt: scannedAt}
+
+	tests := []struct {
+		name    string
+		event   models.ShipmentEventRequest
+		stub    func(m shipmentTestMocks)
+		wantErr error
+	}{
+		{
+			name:  "delivery scan delivers the order and collects cash",
+			event: models.ShipmentEventRequest{Carrier: "BlueDart", TrackingNumber: "BD123", Status: "delivered", Location: "Kochi", OccurredAt: scannedAt},
+			stub: func(m shipmentTestMocks) {
+				m.shipment.EXPECT().GetShipmentByTracking("bluedart", "BD123").Return(shipment, nil)
+				m.order.EXPECT().LockOrderState(5).Return(models.OrderState{OrderID: 5, OrderStatus: domain.OrderStatusShipped, PaymentStatus: domain.PaymentStatusNotPaid}, nil)
+				m.shipment.EXPECT().AddShipmentEvent(9, scan).Return(true, nil)
+				m.shipment.EXPECT().RefreshShipmentStatus(9).Return(domain.ShipmentStatusDelivered, nil)
+				m.order.EXPECT().UpdateOrderState(5, domain.OrderStatusDelivered, domain.PaymentStatusPaid).Return(nil)
+				m.order.EXPECT().AddStatusHistory(gomock.Any()).Return(nil)
+				m.order.EXPECT().SetOpenItemsStatus(5, domain.OrderStatusDelivered).Return(nil)
+				// delivery invoices the order with the next number of the year
+				m.invoice.EXPECT().GetInvoiceByOrder(5).Return(models.Invoice{}, nil)
+				m.invoice.EXPECT().NextDocumentNumber(domain.SeriesInvoice, domain.FinancialYear(time.Now())).Return(7, nil)
+				// the buyer is copied so later profile or address edits leave the invoice alone
+				m.order.EXPECT().GetDetailedOrderThroughId(5).Return(models.CombinedOrderDetails{OrderId: "5", Name: "Asha", Email: "asha@example.com", Phone: "9876543210", HouseName: "Rose Villa", Street: "MG Road", City: "Kochi", State: "Kerala", Pin: "682001"}, nil)
+				m.invoice.EXPECT().CreateInvoice(gomock.Any()).DoAndReturn(func(invoice models.Invoice) (int, error) {
+					assert.Equal(t, 5, invoice.OrderID)
+					assert.Equal(t, domain.DocumentNumber(domain.SeriesInvoice, invoice.FinancialYear, 7), invoice.Number)
+					assert.Equal(t, models.InvoiceBuyer{Name: "Asha", Email: "asha@example.com", Phone: "9876543210", HouseName: "Rose Villa", Street: "MG Road", City: "Kochi", State: "Kerala", Pin: "682001"}, invoice.Buyer)
+					return 1, nil
+				})
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "repeated scan is ignored",
+			event: models.ShipmentEventRequest{Carrier: "bluedart", TrackingNumber: "BD123", Status: "DELIVERED", Location: "Kochi", OccurredAt: scannedAt},
+			stub: func(m shipmentTestMocks) {
+				m.shipment.EXPECT().GetShipmentByTracking("bluedart", "BD123").Return(shipment, nil)
+				m.order.EXPECT().LockOrderState(5).Return(models.OrderState{OrderID: 5, OrderStatus: domain.OrderStatusDelivered, PaymentStatus: domain.PaymentStatusPaid}, nil)
+				m.shipment.EXPECT().AddShipmentEvent(9, scan).Return(false, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "order canceled in transit keeps its status",
+			event: models.ShipmentEventRequest{Carrier: "bluedart", TrackingNumber: "BD123", Status: "DELIVERED", Location: "Kochi", OccurredAt: scannedAt},
+			stub: func(m shipmentTestMocks) {
+				m.shipment.EXPECT().GetShipmentByTracking("bluedart", "BD123").Return(shipment, nil)
+				m.order.EXPECT().LockOrderState(5).Return(models.OrderState{OrderID: 5, OrderStatus: domain.OrderStatusCanceled, PaymentStatus: domain.PaymentStatusReturnedToWallet}, nil)
+				m.shipment.EXPECT().AddShipmentEvent(9, scan).Return(true, nil)
+				m.shipment.EXPECT().RefreshShipmentStatus(9).Return(domain.ShipmentStatusDelivered, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "unknown status",
+			event:   models.ShipmentEventRequest{Carrier: "bluedart", TrackingNumber: "BD123", Status: "LOST IN SPACE", OccurredAt: scannedAt},
+			stub:    func(m shipmentTestMocks) {},
+			wantErr: errors.New("unknown shipment status"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, m := newShipmentTestUseCase(ctrl)
+			tc.stub(m)
+
+			err := uc.RecordTrackingEvent(tc.event)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
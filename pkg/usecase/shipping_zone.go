@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ahdaan98/pkg/domain"
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	services "github.com/ahdaan98/pkg/usecase/interface"
+	"github.com/ahdaan98/pkg/utils/models"
+)
+
+type shippingZoneUseCase struct {
+	shippingZoneRepository interfaces.ShippingZoneRepository
+	transaction            interfaces.TransactionRepository
+}
+
+func NewShippingZoneUseCase(repo interfaces.ShippingZoneRepository, transaction interfaces.TransactionRepository) services.ShippingZoneUseCase {
+	return &shippingZoneUseCase{
+		shippingZoneRepository: repo,
+		transaction:            transaction,
+	}
+}
+
+func (s *shippingZoneUseCase) AddShippingZone(zone models.ShippingZoneRequest) (models.ShippingZone, error) {
+	if err := validateShippingZone(&zone); err != nil {
+		return models.ShippingZone{}, err
+	}
+
+	var id int
+	err := s.transaction.WithTransaction(func(repos interfaces.TxRepositories) error {
+		var err error
+		id, err = repos.ShippingZone.CreateShippingZone(zone)
+		if err != nil {
+			return err
+		}
+		return repos.ShippingZone.SetShippingZoneRules(id, zone.Areas, zone.Rates)
+	})
+	if err != nil {
+		return models.ShippingZone{}, err
+	}
+
+	return s.shippingZoneRepository.GetShippingZone(id)
+}
+
+// EditShippingZone replaces a zone together with its areas and rates.
+func (s *shippingZoneUseCase) EditShippingZone(id int, zone models.ShippingZoneRequest) (models.ShippingZone, error) {
+	if id <= 0 {
+		return models.ShippingZone{}, errors.New("enter a valid shipping zone id")
+	}
+	if err := validateShippingZone(&zone); err != nil {
+		return models.ShippingZone{}, err
+	}
+
+	err := s.transaction.WithTransaction(func(repos interfaces.TxRepositories) error {
+		if err := repos.ShippingZone.UpdateShippingZone(id, zone); err != nil {
+			return err
+		}
+		return repos.ShippingZone.SetShippingZoneRules(id, zone.Areas, zone.Rates)
+	})
+	if err != nil {
+		return models.ShippingZone{}, err
+	}
+
+	return s.shippingZoneRepository.GetShippingZone(id)
+}
+
+func (s *shippingZoneUseCase) DeleteShippingZone(id int) error {
+	if id <= 0 {
+		return errors.New("enter a valid shipping zone id")
+	}
+
+	return s.shippingZoneRepository.DeleteShippingZone(id)
+}
+
+func (s *shippingZoneUseCase) GetShippingZones() ([]models.ShippingZone, error) {
+	return s.shippingZoneRepository.GetShippingZones()
+}
+
+func (s *shippingZoneUseCase) GetShippingZone(id int) (models.ShippingZone, error) {
+	if id <= 0 {
+		return models.ShippingZone{}, errors.New("enter a valid shipping zone id")
+	}
+
+	return s.shippingZoneRepository.GetShippingZone(id)
+}
+
+// validateShippingZone checks a zone and normalises the methods and bases of
+// its rates to upper case.
+func validateShippingZone(zone *models.ShippingZoneRequest) error {
+	if strings.TrimSpace(zone.Name) == "" {
+		return errors.New("shipping zone name cannot be empty")
+	}
+	if zone.FreeShippingThreshold < 0 {
+		return errors.New("free shipping threshold cannot be negative")
+	}
+	if len(zone.Areas) == 0 {
+		return errors.New("add at least one state or pin code range to the zone")
+	}
+	if len(zone.Rates) == 0 {
+		return errors.New("add at least one shipping rate to the zone")
+	}
+
+	for i := range zone.Areas {
+		a := &zone.Areas[i]
+		a.State = strings.TrimSpace(a.State)
+		if a.PinFrom == 0 && a.PinTo == 0 {
+			if a.State == "" {
+				return errors.New("a zone area needs a state or a pin code range")
+			}
+			continue
+		}
+		if !validPin(a.PinFrom) || !validPin(a.PinTo) || a.PinFrom > a.PinTo {
+			return errors.New("pin code range must run between two 6 digit pin codes")
+		}
+	}
+
+	standard := false
+	for i := range zone.Rates {
+		r := &zone.Rates[i]
+		r.Method = strings.ToUpper(strings.TrimSpace(r.Method))
+		r.Basis = strings.ToUpper(strings.TrimSpace(r.Basis))
+		if r.Method != domain.ShippingStandard && r.Method != domain.ShippingExpress {
+			return errors.New("shipping method must be STANDARD or EXPRESS")
+		}
+		if r.Basis != domain.RateByWeight && r.Basis != domain.RateByValue {
+			return errors.New("rate basis must be WEIGHT or ORDER_VALUE")
+		}
+		if r.MinValue < 0 || r.Charge < 0 || (r.MaxValue != 0 && r.MaxValue <= r.MinValue) {
+			return errors.New("rate band or charge is not valid")
+		}
+		if r.Method == domain.ShippingStandard {
+			standard = true
+		}
+	}
+	if !standard {
+		return errors.New("a zone needs at least one standard shipping rate")
+	}
+
+	return nil
+}
+
+func validPin(pin int) bool {
+	return pin >= 100000 && pin <= 999999
+}
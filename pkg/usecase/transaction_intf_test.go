@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	interfaces "github.com/ahdaan98/pkg/repository/interface"
+	repo_mocks "github.com/ahdaan98/pkg/repository/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+// newTestTransaction returns a transaction that hands repos to every function
+// it runs, so the mocks a test stubs are also the transaction's bound
+// repositories.
+func newTestTransaction(ctrl *gomock.Controller, repos interfaces.TxRepositories) *repo_mocks.MockTransactionRepository {
+	transaction := repo_mocks.NewMockTransactionRepository(ctrl)
+	transaction.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(fn func(interfaces.TxRepositories) error) error {
+		return fn(repos)
+	}).AnyTimes()
+	return transaction
+}
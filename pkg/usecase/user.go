@@ -6,6 +6,7 @@ import (
 	"github.com/ahdaan98/pkg/config"
 	"github.com/ahdaan98/pkg/domain"
 	helper "github.com/ahdaan98/pkg/helper/interfaces"
+	"github.com/ahdaan98/pkg/pricing"
 	repo "github.com/ahdaan98/pkg/repository/interface"
 	service "github.com/ahdaan98/pkg/usecase/interface"
 	"github.com/ahdaan98/pkg/utils/models"
@@ -16,14 +17,16 @@ type UserUseCase struct {
 	helper              helper.Helper
 	cfg                 config.Config
 	inventoryRepository repo.InventoryRepository
+	calculator          *pricing.Calculator
 }
 
-func NewUserUseCase(repo repo.UserRepository, helper helper.Helper, cfg config.Config, inv repo.InventoryRepository) service.UserUseCase {
+func NewUserUseCase(repo repo.UserRepository, helper helper.Helper, cfg config.Config, inv repo.InventoryRepository, calculator *pricing.Calculator) service.UserUseCase {
 	return &UserUseCase{
 		repo:                repo,
 		helper:              helper,
 		cfg:                 cfg,
 		inventoryRepository: inv,
+		calculator:          calculator,
 	}
 }
 
@@ -174,10 +177,16 @@ func (u *UserUseCase) GetCart(id int) (models.GetCartResponse, error) {
 		return models.GetCartResponse{}, errors.New(InternalError)
 	}
 	//find products inide cart
-	products, err := u.repo.GetProductsInCart(cart_id)
+	cartProducts, err := u.repo.GetProductsInCart(cart_id)
 	if err != nil {
 		return models.GetCartResponse{}, errors.New(InternalError)
 	}
+	var products []int
+	var weight []int
+	for _, p := range cartProducts {
+		products = append(products, p.InventoryID)
+		weight = append(weight, p.WeightGrams)
+	}
 	//find product names
 	var product_names []string
 	for i := range products {
@@ -243,6 +252,25 @@ func (u *UserUseCase) GetCart(id int) (models.GetCartResponse, error) {
 		brand = append(brand, c)
 	}
 
+	var items []pricing.Item
+	for i := range product_names {
+		items = append(items, pricing.Item{
+			InventoryID: products[i],
+			ProductName: product_names[i],
+			CategoryID:  uint(categoryID[i]),
+			BrandID:     uint(brandID[i]),
+			Quantity:    quantity[i],
+			UnitPrice:   price[i],
+			WeightGrams: weight[i],
+		})
+	}
+
+	// the cart has no address or coupon yet, so it is priced without them
+	quote, err := u.calculator.Quote(pricing.Input{UserID: id, Items: items})
+	if err != nil {
+		return models.GetCartResponse{}, err
+	}
+
 	var getcart []models.GetCart
 	for i := range product_names {
 		var get models.GetCart
@@ -253,8 +281,9 @@ func (u *UserUseCase) GetCart(id int) (models.GetCartResponse, error) {
 		get.CategoryID = uint(categoryID[i])
 		get.Category = category[i]
 		get.Quantity = quantity[i]
-		get.Price = int(price[i])
-		get.Total = (price[i]) * float64(quantity[i])
+		get.Price = price[i]
+		get.WeightGrams = weight[i]
+		get.Total = quote.Lines[i].Subtotal
 
 		getcart = append(getcart, get)
 	}
@@ -262,6 +291,7 @@ func (u *UserUseCase) GetCart(id int) (models.GetCartResponse, error) {
 	var response models.GetCartResponse
 	response.ID = cart_id
 	response.Data = getcart
+	response.Subtotal = quote.Subtotal
 	//then return in appropriate format
 
 	return response, nil
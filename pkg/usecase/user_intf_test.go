@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/ahdaan98/pkg/config"
+	"github.com/ahdaan98/pkg/pricing"
 
 	helper_mocks "github.com/ahdaan98/pkg/helper/mocks"
 	repo_mocks "github.com/ahdaan98/pkg/repository/mocks"
@@ -57,7 +58,7 @@ func TestValidatingDetails(t *testing.T) {
 		SECRET_KEY_FOR_PAY: "dummy_secret_key_for_pay",
 		PORT:               "dummy_port",
 	}
-	userUseCase := NewUserUseCase(mockUserRepo, mockHelper, cfg, mockInventoryRepo)
+	userUseCase := NewUserUseCase(mockUserRepo, mockHelper, cfg, mockInventoryRepo, pricing.NewCalculator())
 
 	tests := []struct {
 		name    string
@@ -179,7 +180,7 @@ func TestUserSignUp(t *testing.T) {
 		PORT:               "dummy_port",
 	}
 
-	userUC := NewUserUseCase(mockRepo, mockHelper, cfg, mockInventoryRepo)
+	userUC := NewUserUseCase(mockRepo, mockHelper, cfg, mockInventoryRepo, pricing.NewCalculator())
 
 	tests := []struct {
 		name      string
@@ -270,7 +271,7 @@ func TestUserLogin(t *testing.T) {
 		PORT:               "dummy_port",
 	}
 
-	userUC := NewUserUseCase(mockRepo, mockHelper, cfg, mockInventoryRepo)
+	userUC := NewUserUseCase(mockRepo, mockHelper, cfg, mockInventoryRepo, pricing.NewCalculator())
 
 	tests := []struct {
 		name      string
@@ -371,7 +372,7 @@ func TestEditProfile(t *testing.T) {
 		PORT:               "dummy_port",
 	}
 
-	userUC := NewUserUseCase(mockRepo, mockHelper, cfg, mockInventoryRepo)
+	userUC := NewUserUseCase(mockRepo, mockHelper, cfg, mockInventoryRepo, pricing.NewCalculator())
 
 	tests := []struct {
 		name      string
@@ -441,7 +442,7 @@ func TestChangePassword(t *testing.T) {
 		PORT:               "dummy_port",
 	}
 
-	userUC := NewUserUseCase(mockRepo, mockHelper, cfg, mockInventoryRepo)
+	userUC := NewUserUseCase(mockRepo, mockHelper, cfg, mockInventoryRepo, pricing.NewCalculator())
 
 	tests := []struct {
 		name       string
@@ -526,3 +527,34 @@ func TestChangePassword(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repo_mocks.NewMockUserRepository(ctrl)
+	mockHelper := helper_mocks.NewMockHelper(ctrl)
+	mockInventoryRepo := repo_mocks.NewMockInventoryRepository(ctrl)
+
+	// the weight comes with the cart's products rather than one query each
+	mockRepo.EXPECT().GetCartID(1).Return(3, nil)
+	mockRepo.EXPECT().GetProductsInCart(3).Return([]models.CartProduct{{InventoryID: 7, WeightGrams: 250}}, nil)
+	mockRepo.EXPECT().FindProductNames(7).Return("Soap", nil)
+	mockRepo.EXPECT().FindCartQuantity(3, 7).Return(2, nil)
+	mockRepo.EXPECT().FindPrice(7).Return(40.0, nil)
+	mockRepo.EXPECT().FindCategory(7).Return(1, nil)
+	mockRepo.EXPECT().FindBrand(7).Return(2, nil)
+	mockRepo.EXPECT().FindCategoryName(1).Return("Bath", nil)
+	mockRepo.EXPECT().FindBrandName(2).Return("Acme", nil)
+
+	userUC := NewUserUseCase(mockRepo, mockHelper, config.Config{}, mockInventoryRepo, pricing.NewCalculator())
+	got, err := userUC.GetCart(1)
+	assert.NoError(t, err)
+	assert.Equal(t, models.GetCartResponse{
+		ID:       3,
+		Subtotal: 80,
+		Data: []models.GetCart{
+			{ProductID: 7, ProductName: "Soap", BrandID: 2, Brand: "Acme", CategoryID: 1, Category: "Bath", Quantity: 2, Price: 40, Total: 80, WeightGrams: 250},
+		},
+	}, got)
+}
@@ -13,6 +13,8 @@ type AdminDetailsResponse struct {
 
 type NewPaymentMethod struct {
 	PaymentMethod string `json:"payment_method"`
+	// Kind is online, cod or other, see domain.PaymentKindOnline.
+	Kind string `json:"kind"`
 }
 
 type CompleteAdminDashboard struct {
@@ -50,6 +52,7 @@ type DashboardAmount struct {
 
 type SalesReport struct {
 	TotalSales      float64
+	TotalShipping   float64
 	TotalOrders     int
 	CompletedOrders int
 	PendingOrders   int
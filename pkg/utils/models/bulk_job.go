@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// BulkOrderRequest applies Action to the listed orders, or to every order
+// matching Filter when no ids are given.
+type BulkOrderRequest struct {
+	Action   string            `json:"action" binding:"required"`
+	OrderIDs []int             `json:"order_ids"`
+	Filter   *AdminOrderFilter `json:"filter"`
+}
+
+type BulkJobDetails struct {
+	ID         int                `json:"id"`
+	AdminID    int                `json:"admin_id"`
+	Action     string             `json:"action"`
+	Status     string             `json:"status"`
+	Total      int                `json:"total"`
+	Processed  int                `json:"processed"`
+	Succeeded  int                `json:"succeeded"`
+	Failed     int                `json:"failed"`
+	CreatedAt  time.Time          `json:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+	FinishedAt *time.Time         `json:"finished_at"`
+	Results    []BulkResultDetail `json:"results" gorm:"-"`
+}
+
+type BulkResultDetail struct {
+	OrderID int    `json:"order_id"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
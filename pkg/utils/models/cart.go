@@ -8,8 +8,15 @@ type GetCart struct {
 	CategoryID  uint    `json:"category_id"`
 	Category    string  `json:"category"`
 	Quantity    int     `json:"quantity"`
-	Price       int     `json:"price"`
+	Price       float64 `json:"price"`
 	Total       float64 `json:"total_price"`
+	WeightGrams int     `json:"weight_grams"`
+}
+
+// CartProduct is a product in a cart with the weight it is shipped by.
+type CartProduct struct {
+	InventoryID int `json:"inventory_id"`
+	WeightGrams int `json:"weight_grams"`
 }
 
 // check out
@@ -18,6 +25,8 @@ type CheckOut struct {
 	Addresses     []Address
 	Products      []GetCart
 	PaymentMethod []PaymentMethodResponse
+	Delivery      []DeliveryEstimate
+	Shipping      []ShippingOption
 }
 
 type AddToCart struct {
@@ -4,14 +4,21 @@ package models
 
 type AddInventory struct {
 	ProductName string  `json:"product_name"`
+	SKU         string  `json:"sku"`
 	BrandID     uint    `json:"brand_id"`
 	CategoryID  uint    `json:"category_id"`
 	Stock       int     `json:"stock"`
 	Price       float64 `json:"price"`
+	// HSNCode and GSTRate are left empty to use the category's.
+	HSNCode     string   `json:"hsn_code"`
+	GSTRate     *float64 `json:"gst_rate"`
+	WeightGrams int      `json:"weight_grams"`
 }
 
 type AddCategory struct {
-	CategoryName string `json:"category_name"`
+	CategoryName string  `json:"category_name"`
+	HSNCode      string  `json:"hsn_code"`
+	GSTRate      float64 `json:"gst_rate"`
 }
 
 type AddBrand struct {
@@ -22,15 +29,27 @@ type Order struct {
 	AddressID       int `json:"address_id"`
 	PaymentMethodID int `json:"payment_id"`
 	CouponID        int `json:"coupon_id"`
+	// ShippingMethod is STANDARD, the default, or EXPRESS.
+	ShippingMethod string `json:"shipping_method"`
+}
+
+type CheckoutQuoteQuery struct {
+	AddressID      int    `form:"address_id" binding:"required"`
+	CouponID       int    `form:"coupon_id"`
+	ShippingMethod string `form:"shipping_method"`
 }
 
 // Edit Details
 
 type EditInventory struct {
-	ProductName string  `json:"product_name"`
-	CategoryID  uint    `json:"category_id"`
-	BrandID     uint    `json:"brand_id"`
-	Price       float64 `json:"price"`
+	ProductName string   `json:"product_name"`
+	SKU         string   `json:"sku"`
+	CategoryID  uint     `json:"category_id"`
+	BrandID     uint     `json:"brand_id"`
+	Price       float64  `json:"price"`
+	HSNCode     string   `json:"hsn_code"`
+	GSTRate     *float64 `json:"gst_rate"`
+	WeightGrams int      `json:"weight_grams"`
 }
 
 type EditCategory struct {
@@ -38,6 +57,11 @@ type EditCategory struct {
 	CategoryName string `json:"category_name"`
 }
 
+type CategoryTax struct {
+	HSNCode string  `json:"hsn_code"`
+	GSTRate float64 `json:"gst_rate"`
+}
+
 type EditBrand struct {
 	BrandID   uint   `json:"brand_id"`
 	BrandName string `json:"brand_name"`
@@ -105,3 +129,11 @@ type FilterByBrandResponse struct {
 	Stock       int     `json:"stock"`
 	Price       float64 `json:"price"`
 }
+
+// TaxClass is the HSN code and GST rate a product is sold under, taken from
+// the product or else its category.
+type TaxClass struct {
+	InventoryID int     `json:"inventory_id"`
+	HSNCode     string  `json:"hsn_code"`
+	GSTRate     float64 `json:"gst_rate"`
+}
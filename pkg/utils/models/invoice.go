@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+type Invoice struct {
+	ID            int          `json:"id"`
+	OrderID       int          `json:"order_id"`
+	Number        string       `json:"number"`
+	FinancialYear string       `json:"financial_year"`
+	FilePath      string       `json:"-"`
+	IssuedAt      time.Time    `json:"issued_at"`
+	Buyer         InvoiceBuyer `json:"buyer" gorm:"embedded;embeddedPrefix:buyer_"`
+}
+
+// InvoiceBuyer is the buyer as they were when the invoice was issued.
+type InvoiceBuyer struct {
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Phone     string `json:"phone"`
+	HouseName string `json:"house_name"`
+	Street    string `json:"street"`
+	City      string `json:"city"`
+	State     string `json:"state"`
+	Pin       string `json:"pin"`
+}
+
+type CreditNote struct {
+	ID            int              `json:"id"`
+	InvoiceID     int              `json:"invoice_id"`
+	InvoiceNumber string           `json:"invoice_number"`
+	OrderID       int              `json:"order_id"`
+	Number        string           `json:"number"`
+	FinancialYear string           `json:"financial_year"`
+	Amount        float64          `json:"amount"`
+	Reason        string           `json:"reason"`
+	FilePath      string           `json:"-"`
+	IssuedAt      time.Time        `json:"issued_at"`
+	Items         []CreditNoteItem `json:"items" gorm:"-"`
+}
+
+type CreditNoteItem struct {
+	OrderItemID int     `json:"order_item_id"`
+	ProductName string  `json:"product_name"`
+	Quantity    int     `json:"quantity"`
+	Amount      float64 `json:"amount"`
+}
+
+// OrderDocuments are the invoice and credit notes issued for an order.
+type OrderDocuments struct {
+	Invoice     *Invoice     `json:"invoice"`
+	CreditNotes []CreditNote `json:"credit_notes"`
+}
+
+// DocumentFile is a stored PDF and the name it is downloaded as.
+type DocumentFile struct {
+	FileName string
+	Path     string
+}
+
+// InvoiceLink is a signed invoice download link that expires at ExpiresAt.
+type InvoiceLink struct {
+	InvoiceID int       `json:"invoice_id"`
+	Expires   int64     `json:"-"`
+	Signature string    `json:"-"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -1,5 +1,6 @@
 package models
 
+import "time"
 
 type OrderDetails struct {
 	OrderID         int     `json:"order_id" gorm:"column:order_id"`
@@ -8,6 +9,8 @@ type OrderDetails struct {
 	Price           float64 `json:"price" gorm:"column:price"`
 	OrderStatus     string  `json:"order_status" gorm:"column:order_status"`
 	PaymentStatus   string  `json:"payment_status" gorm:"payment_status:4;default:'NOT PAID';check:payment_status IN ('PAID', 'NOT PAID','REFUND IN PROGRESS','RETURNED TO WALLET')"`
+	// PaymentExpiresAt is set while an online payment is still pending.
+	PaymentExpiresAt *time.Time `json:"payment_expires_at,omitempty" gorm:"column:payment_expires_at"`
 }
 
 type OrderDetailsAdmin struct {
@@ -18,10 +21,15 @@ type OrderDetailsAdmin struct {
 type PaymentMethodResponse struct {
 	ID           uint   `gorm:"primarykey"`
 	Payment_Name string `json:"payment_name"`
+	Kind         string `json:"kind"`
 }
 
 type CombinedOrderDetails struct {
 	OrderId       string  `json:"order_id"`
+	Subtotal      float64 `json:"subtotal"`
+	Discount      float64 `json:"discount"`
+	Shipping      float64 `json:"shipping"`
+	Tax           float64 `json:"tax"`
 	FinalPrice    float64 `json:"final_price"`
 	OrderStatus   string  `json:"order_status" gorm:"column:order_status"`
 	PaymentStatus string  `json:"payment_status" gorm:"default:'NOT PAID'"`
@@ -33,6 +41,9 @@ type CombinedOrderDetails struct {
 	City          string  `json:"city" validate:"required"`
 	State         string  `json:"state" validate:"required"`
 	Pin           string  `json:"pin" validate:"required"`
+	// ShippingMethod and OrderDate are only read for invoices.
+	ShippingMethod string     `json:"shipping_method,omitempty"`
+	OrderDate      *time.Time `json:"order_date,omitempty"`
 }
 
 type OrderPaymentDetails struct {
@@ -44,9 +55,183 @@ type OrderPaymentDetails struct {
 }
 
 type ItemDetails struct {
+	OrderItemID int            `json:"order_item_id"`
+	ProductName string         `json:"product_name"`
+	SKU         string         `json:"sku"`
+	HSNCode     string         `json:"hsn_code"`
+	FinalPrice  float64        `json:"final_price"`
+	Price       float64        `json:"price" `
+	Total       float64        `json:"total_price"`
+	Quantity    int            `json:"quantity"`
+	Discount    float64        `json:"discount"`
+	TaxRate     float64        `json:"tax_rate"`
+	TaxAmount   float64        `json:"tax_amount"`
+	Taxes       []OrderItemTax `json:"taxes" gorm:"-"`
+}
+type OrderState struct {
+	OrderID       int     `json:"order_id"`
+	UserID        int     `json:"user_id"`
+	OrderStatus   string  `json:"order_status"`
+	PaymentStatus string  `json:"payment_status"`
+	FinalPrice    float64 `json:"final_price"`
+}
+
+// OrderItemState is an order_items row together with how much of it has
+// already been canceled or returned.
+type OrderItemState struct {
+	ID               int     `json:"id"`
+	InventoryID      int     `json:"inventory_id"`
+	Quantity         int     `json:"quantity"`
+	TotalPrice       float64 `json:"total_price"`
+	Status           string  `json:"status"`
+	CanceledQuantity int     `json:"canceled_quantity"`
+	ReturnedQuantity int     `json:"returned_quantity"`
+	DamagedQuantity  int     `json:"damaged_quantity"`
+	RefundAmount     float64 `json:"refund_amount"`
+
+	ProductName  string  `json:"product_name"`
+	SKU          string  `json:"sku"`
+	BrandName    string  `json:"brand_name"`
+	CategoryName string  `json:"category_name"`
+	UnitPrice    float64 `json:"unit_price"`
+	Discount     float64 `json:"discount"`
+	TaxAmount    float64 `json:"tax_amount"`
+}
+
+// OpenQuantity is the number of units that are neither canceled nor returned.
+func (i OrderItemState) OpenQuantity() int {
+	return i.Quantity - i.CanceledQuantity - i.ReturnedQuantity
+}
+
+// OrderTotals are the amounts of a priced order.
+type OrderTotals struct {
+	Subtotal       float64
+	Discount       float64
+	ShippingMethod string
+	Shipping       float64
+	Tax            float64
+	GrandTotal     float64
+}
+
+// OrderItemLine is an item of a new order. The product's name, SKU, brand and
+// category are copied from the inventory when the line is stored.
+type OrderItemLine struct {
+	InventoryID int
+	Quantity    int
+	UnitPrice   float64
+	TotalPrice  float64
+	Discount    float64
+	HSNCode     string
+	TaxRate     float64
+	TaxAmount   float64
+	Taxes       []OrderItemTax
+}
+
+// OrderItemTax is one GST component charged on an order item.
+type OrderItemTax struct {
+	OrderItemID int     `json:"order_item_id"`
+	Component   string  `json:"component"`
+	Rate        float64 `json:"rate"`
+	Amount      float64 `json:"amount"`
+}
+
+type OrderItemQuantity struct {
+	OrderItemID int `json:"order_item_id" binding:"required"`
+	Quantity    int `json:"quantity" binding:"required"`
+}
+
+type CancelOrderItems struct {
+	Items []OrderItemQuantity `json:"items" binding:"required,dive"`
+	// RefundTo is wallet or original; empty picks the default.
+	RefundTo string `json:"refund_to"`
+}
+
+// ReorderItem is a product of a past order with its current price and stock.
+// Available is false when the product no longer exists.
+type ReorderItem struct {
+	InventoryID int     `json:"inventory_id"`
 	ProductName string  `json:"product_name"`
-	FinalPrice  float64 `json:"final_price"`
-	Price       float64 `json:"price" `
-	Total       float64 `json:"total_price"`
 	Quantity    int     `json:"quantity"`
-}
\ No newline at end of file
+	Stock       int     `json:"stock"`
+	Price       float64 `json:"price"`
+	Available   bool    `json:"available"`
+}
+
+type ReorderLine struct {
+	InventoryID     int     `json:"inventory_id"`
+	ProductName     string  `json:"product_name"`
+	OrderedQuantity int     `json:"ordered_quantity"`
+	AddedQuantity   int     `json:"added_quantity"`
+	Price           float64 `json:"price"`
+	Reason          string  `json:"reason,omitempty"`
+}
+
+// ReorderResult tells which products of a past order made it into the cart.
+type ReorderResult struct {
+	Added          []ReorderLine `json:"added"`
+	PartiallyAdded []ReorderLine `json:"partially_added"`
+	Skipped        []ReorderLine `json:"skipped"`
+}
+
+// AdminOrderFilter narrows down the orders listed to admins. Zero values
+// leave a filter out; From and To are dates in dd-mm-yyyy form.
+type AdminOrderFilter struct {
+	OrderStatus     string   `form:"status" json:"status"`
+	PaymentStatus   string   `form:"payment_status" json:"payment_status"`
+	PaymentMethodID int      `form:"payment_method_id" json:"payment_method_id"`
+	UserID          int      `form:"user_id" json:"user_id"`
+	From            string   `form:"from" json:"from"`
+	To              string   `form:"to" json:"to"`
+	MinAmount       *float64 `form:"min_amount" json:"min_amount"`
+	MaxAmount       *float64 `form:"max_amount" json:"max_amount"`
+	Search          string   `form:"search" json:"search"`
+	Sort            string   `form:"sort" json:"sort"`
+	Page            int      `form:"page" json:"page"`
+	PageSize        int      `form:"page_size" json:"page_size"`
+}
+
+// AdminOrderQuery is an AdminOrderFilter that has been checked and parsed.
+type AdminOrderQuery struct {
+	OrderStatus     string
+	PaymentStatus   string
+	PaymentMethodID int
+	UserID          int
+	From            *time.Time
+	Before          *time.Time
+	MinAmount       *float64
+	MaxAmount       *float64
+	Search          string
+	Sort            string
+	Page            int
+	PageSize        int
+}
+
+type AdminOrderSummary struct {
+	OrderID       int       `json:"order_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	UserID        int       `json:"user_id"`
+	Name          string    `json:"name"`
+	Email         string    `json:"email"`
+	Phone         string    `json:"phone"`
+	PaymentMethod string    `json:"payment_method"`
+	FinalPrice    float64   `json:"final_price"`
+	OrderStatus   string    `json:"order_status"`
+	PaymentStatus string    `json:"payment_status"`
+	HouseName     string    `json:"house_name"`
+	Street        string    `json:"street"`
+	City          string    `json:"city"`
+	State         string    `json:"state"`
+	Pin           string    `json:"pin"`
+}
+
+type PageMeta struct {
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	TotalCount int64 `json:"total_count"`
+	TotalPages int   `json:"total_pages"`
+}
+
+type AdminOrderList struct {
+	Orders []AdminOrderSummary `json:"orders"`
+	Meta   PageMeta            `json:"meta"`
+}
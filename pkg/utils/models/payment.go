@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// PaymentDetails is a payment attempt. Amount is in paise.
+type PaymentDetails struct {
+	ID             int       `json:"id"`
+	OrderID        int       `json:"order_id"`
+	GatewayOrderID string    `json:"gateway_order_id"`
+	PaymentID      string    `json:"payment_id"`
+	Amount         int64     `json:"amount"`
+	Status         string    `json:"status"`
+	FailureReason  string    `json:"failure_reason"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// RazorpayEvent is a webhook event posted by razorpay. Amounts are in paise.
+type RazorpayEvent struct {
+	Event   string          `json:"event"`
+	Payload RazorpayPayload `json:"payload"`
+}
+
+type RazorpayPayload struct {
+	Payment RazorpayPaymentEntity `json:"payment"`
+	Refund  RazorpayRefundEntity  `json:"refund"`
+}
+
+type RazorpayPaymentEntity struct {
+	Entity RazorpayPayment `json:"entity"`
+}
+
+type RazorpayRefundEntity struct {
+	Entity RazorpayRefund `json:"entity"`
+}
+
+type RazorpayPayment struct {
+	ID               string `json:"id"`
+	OrderID          string `json:"order_id"`
+	Amount           int64  `json:"amount"`
+	Status           string `json:"status"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type RazorpayRefund struct {
+	ID        string `json:"id"`
+	PaymentID string `json:"payment_id"`
+	Amount    int64  `json:"amount"`
+	Status    string `json:"status"`
+	// Receipt is the reference the refund was created with, if any.
+	Receipt string `json:"receipt"`
+}
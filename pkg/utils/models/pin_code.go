@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type PinCode struct {
+	Pin          string `json:"pin" binding:"required"`
+	Serviceable  bool   `json:"serviceable"`
+	CODAllowed   bool   `json:"cod_allowed"`
+	DeliveryDays int    `json:"delivery_days"`
+}
+
+// PinCodeImport reports how many rows of an imported CSV were saved and
+// why the others were skipped.
+type PinCodeImport struct {
+	Imported int      `json:"imported"`
+	Skipped  []string `json:"skipped,omitempty"`
+}
+
+// DeliveryEstimate answers whether a product, or an order to an address,
+// can be delivered to a pin code and by when.
+type DeliveryEstimate struct {
+	ProductID         int        `json:"product_id,omitempty"`
+	AddressID         int        `json:"address_id,omitempty"`
+	Pin               string     `json:"pin"`
+	Serviceable       bool       `json:"serviceable"`
+	CODAllowed        bool       `json:"cod_allowed"`
+	InStock           bool       `json:"in_stock"`
+	DeliveryDays      int        `json:"delivery_days,omitempty"`
+	EstimatedDelivery *time.Time `json:"estimated_delivery,omitempty"`
+}
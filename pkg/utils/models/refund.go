@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type RefundDetails struct {
+	ID              int       `json:"id"`
+	OrderID         int       `json:"order_id"`
+	Method          string    `json:"method"`
+	Amount          float64   `json:"amount"`
+	PaymentID       string    `json:"payment_id"`
+	GatewayRefundID string    `json:"gateway_refund_id"`
+	Status          string    `json:"status"`
+	Attempts        int       `json:"attempts"`
+	FailureReason   string    `json:"failure_reason"`
+	Reason          string    `json:"reason"`
+	Unapplied       bool      `json:"unapplied"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
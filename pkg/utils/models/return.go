@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+type ReturnRequest struct {
+	OrderID int                 `json:"order_id" binding:"required"`
+	Reason  string              `json:"reason" binding:"required"`
+	Items   []ReturnRequestItem `json:"items" binding:"required,dive"`
+	Photos  []string            `json:"photos"`
+	// RefundTo is wallet or original; empty picks the default.
+	RefundTo string `json:"refund_to"`
+}
+
+// ReturnRequestItem is a quantity of an order item to return. DamagedQuantity
+// is how many of those units the customer says arrived damaged; the
+// inspection may correct it.
+type ReturnRequestItem struct {
+	OrderItemID     int `json:"order_item_id" binding:"required"`
+	Quantity        int `json:"quantity" binding:"required"`
+	DamagedQuantity int `json:"damaged_quantity"`
+}
+
+// ReturnOrderItems is the body of the order items return endpoint, which
+// opens a return request for the order in the path.
+type ReturnOrderItems struct {
+	Items    []ReturnRequestItem `json:"items" binding:"required,dive"`
+	Reason   string              `json:"reason"`
+	RefundTo string              `json:"refund_to"`
+}
+
+// ReturnOrder is the optional body of the whole order return endpoint. It only
+// lists the units that came back damaged.
+type ReturnOrder struct {
+	DamagedItems []DamagedItem `json:"damaged_items"`
+}
+
+type DamagedItem struct {
+	OrderItemID int `json:"order_item_id" binding:"required"`
+	Quantity    int `json:"quantity" binding:"required"`
+}
+
+type ReturnRequestDetails struct {
+	ID         int                 `json:"id"`
+	OrderID    int                 `json:"order_id"`
+	UserID     int                 `json:"user_id"`
+	Status     string              `json:"status"`
+	Reason     string              `json:"reason"`
+	AdminNote  string              `json:"admin_note"`
+	RefundTo   string              `json:"refund_to"`
+	PickupDate *time.Time          `json:"pickup_date"`
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+	Items      []ReturnItemDetails `json:"items" gorm:"-"`
+	Photos     []string            `json:"photos" gorm:"-"`
+}
+
+type ReturnItemDetails struct {
+	ID              int `json:"id"`
+	OrderItemID     int `json:"order_item_id"`
+	Quantity        int `json:"quantity"`
+	DamagedQuantity int `json:"damaged_quantity"`
+}
+
+type ReturnDecision struct {
+	Note string `json:"note"`
+}
+
+type ReturnPickup struct {
+	PickupDate time.Time `json:"pickup_date" binding:"required"`
+}
+
+type InspectedItem struct {
+	OrderItemID     int `json:"order_item_id" binding:"required"`
+	DamagedQuantity int `json:"damaged_quantity"`
+}
+
+type ReturnInspection struct {
+	Items []InspectedItem `json:"items" binding:"dive"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+type ShipmentRequest struct {
+	Carrier        string  `json:"carrier" binding:"required"`
+	TrackingNumber string  `json:"tracking_number" binding:"required"`
+	Packages       int     `json:"packages"`
+	WeightKg       float64 `json:"weight_kg"`
+}
+
+// ShipmentEventRequest is the body a courier posts to the tracking webhook.
+type ShipmentEventRequest struct {
+	Carrier        string    `json:"carrier" binding:"required"`
+	TrackingNumber string    `json:"tracking_number" binding:"required"`
+	Status         string    `json:"status" binding:"required"`
+	Location       string    `json:"location"`
+	Description    string    `json:"description"`
+	OccurredAt     time.Time `json:"occurred_at" binding:"required"`
+}
+
+type ShipmentDetails struct {
+	ID             int                  `json:"id"`
+	OrderID        int                  `json:"order_id"`
+	Carrier        string               `json:"carrier"`
+	TrackingNumber string               `json:"tracking_number"`
+	Packages       int                  `json:"packages"`
+	WeightKg       float64              `json:"weight_kg"`
+	Status         string               `json:"status"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+	Events         []ShipmentEventState `json:"events" gorm:"-"`
+}
+
+type ShipmentEventState struct {
+	Status      string    `json:"status"`
+	Location    string    `json:"location"`
+	Description string    `json:"description"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,44 @@
+package models
+
+// ShippingZoneRequest defines a shipping zone together with the areas it
+// covers and its rate table.
+type ShippingZoneRequest struct {
+	Name                  string         `json:"name" binding:"required"`
+	FreeShippingThreshold float64        `json:"free_shipping_threshold"`
+	Areas                 []ShippingArea `json:"areas" binding:"required,dive"`
+	Rates                 []ShippingRate `json:"rates" binding:"required,dive"`
+}
+
+// ShippingArea is a state, or a range of pin codes when PinFrom is set.
+type ShippingArea struct {
+	State   string `json:"state"`
+	PinFrom int    `json:"pin_from"`
+	PinTo   int    `json:"pin_to"`
+}
+
+// ShippingRate charges Charge when the order's weight in grams or value in
+// rupees is at least MinValue and, when MaxValue is set, below it.
+type ShippingRate struct {
+	Method   string  `json:"method" binding:"required"`
+	Basis    string  `json:"basis" binding:"required"`
+	MinValue float64 `json:"min_value"`
+	MaxValue float64 `json:"max_value"`
+	Charge   float64 `json:"charge"`
+}
+
+type ShippingZone struct {
+	ID                    int            `json:"id"`
+	Name                  string         `json:"name"`
+	FreeShippingThreshold float64        `json:"free_shipping_threshold"`
+	Areas                 []ShippingArea `json:"areas" gorm:"-"`
+	Rates                 []ShippingRate `json:"rates" gorm:"-"`
+}
+
+// ShippingOption is what shipping an order to one of the user's addresses
+// by one method costs.
+type ShippingOption struct {
+	AddressID  int     `json:"address_id"`
+	Method     string  `json:"method"`
+	Charge     float64 `json:"charge"`
+	GrandTotal float64 `json:"grand_total"`
+}
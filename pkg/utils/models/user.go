@@ -64,8 +64,9 @@ type EditUserDetails struct {
 }
 
 type GetCartResponse struct {
-	ID   int
-	Data []GetCart
+	ID       int
+	Data     []GetCart
+	Subtotal float64
 }
 
 type ChangePassword struct {